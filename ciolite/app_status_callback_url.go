@@ -2,6 +2,10 @@ package ciolite
 
 // Api functions that support: https://context.io/docs/app/status_callback_url
 
+import (
+	"context"
+)
+
 // GetStatusCallbackURLResponse data struct
 // 	https://context.io/docs/app/status_callback_url#get
 type GetStatusCallbackURLResponse struct {
@@ -26,6 +30,12 @@ type CreateDeleteStatusCallbackURLResponse struct {
 // GetStatusCallbackURL gets a list of app status callback url's.
 // 	https://context.io/docs/app/status_callback_url#get
 func (cioLite CioLite) GetStatusCallbackURL() (GetStatusCallbackURLResponse, error) {
+	return cioLite.GetStatusCallbackURLContext(context.Background())
+}
+
+// GetStatusCallbackURLContext is the same as GetStatusCallbackURL, but uses the provided context.Context
+// for cancellation and deadlines of the request (including any retries).
+func (cioLite CioLite) GetStatusCallbackURLContext(ctx context.Context) (GetStatusCallbackURLResponse, error) {
 
 	// Make request
 	request := clientRequest{
@@ -37,7 +47,7 @@ func (cioLite CioLite) GetStatusCallbackURL() (GetStatusCallbackURLResponse, err
 	var response GetStatusCallbackURLResponse
 
 	// Request
-	err := cioLite.doFormRequest(request, &response)
+	err := cioLite.doFormRequest(ctx, request, &response)
 
 	return response, err
 }
@@ -46,6 +56,12 @@ func (cioLite CioLite) GetStatusCallbackURL() (GetStatusCallbackURLResponse, err
 // Requires: StatusCallbackURL
 // 	https://context.io/docs/app/status_callback_url#post
 func (cioLite CioLite) CreateStatusCallbackURL(formValues CreateStatusCallbackURLParams) (CreateDeleteStatusCallbackURLResponse, error) {
+	return cioLite.CreateStatusCallbackURLContext(context.Background(), formValues)
+}
+
+// CreateStatusCallbackURLContext is the same as CreateStatusCallbackURL, but uses the provided context.Context
+// for cancellation and deadlines of the request (including any retries).
+func (cioLite CioLite) CreateStatusCallbackURLContext(ctx context.Context, formValues CreateStatusCallbackURLParams) (CreateDeleteStatusCallbackURLResponse, error) {
 
 	// Make request
 	request := clientRequest{
@@ -58,7 +74,7 @@ func (cioLite CioLite) CreateStatusCallbackURL(formValues CreateStatusCallbackUR
 	var response CreateDeleteStatusCallbackURLResponse
 
 	// Request
-	err := cioLite.doFormRequest(request, &response)
+	err := cioLite.doFormRequest(ctx, request, &response)
 
 	return response, err
 }
@@ -66,6 +82,12 @@ func (cioLite CioLite) CreateStatusCallbackURL(formValues CreateStatusCallbackUR
 // DeleteStatusCallbackURL removes an app status callback url.
 // 	https://context.io/docs/app/status_callback_url#delete
 func (cioLite CioLite) DeleteStatusCallbackURL() (CreateDeleteStatusCallbackURLResponse, error) {
+	return cioLite.DeleteStatusCallbackURLContext(context.Background())
+}
+
+// DeleteStatusCallbackURLContext is the same as DeleteStatusCallbackURL, but uses the provided context.Context
+// for cancellation and deadlines of the request (including any retries).
+func (cioLite CioLite) DeleteStatusCallbackURLContext(ctx context.Context) (CreateDeleteStatusCallbackURLResponse, error) {
 
 	// Make request
 	request := clientRequest{
@@ -77,7 +99,7 @@ func (cioLite CioLite) DeleteStatusCallbackURL() (CreateDeleteStatusCallbackURLR
 	var response CreateDeleteStatusCallbackURLResponse
 
 	// Request
-	err := cioLite.doFormRequest(request, &response)
+	err := cioLite.doFormRequest(ctx, request, &response)
 
 	return response, err
 }
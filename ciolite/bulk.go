@@ -0,0 +1,186 @@
+package ciolite
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultBulkWorkers is the default number of messages operated on concurrently by RunBulkMessageOperation
+const DefaultBulkWorkers = 4
+
+// ErrOperationUnsuccessful is returned by the MessageOperations when CIO responds without success
+var ErrOperationUnsuccessful = errors.New("CIO: Operation was not successful")
+
+// MessageOperation is an operation on a single message, run by RunBulkMessageOperation.
+// It should use the Context variant of the CioLite function, so that canceling the bulk operation stops it.
+type MessageOperation func(ctx context.Context, cioLite CioLite, userID string, label string, folder string, messageID string) error
+
+// MoveMessageOperation returns a MessageOperation that moves each message (see MoveUserEmailAccountFolderMessage)
+func MoveMessageOperation(formValues MoveUserEmailAccountFolderMessageParams) MessageOperation {
+	return func(ctx context.Context, cioLite CioLite, userID string, label string, folder string, messageID string) error {
+		response, err := cioLite.MoveUserEmailAccountFolderMessageContext(ctx, userID, label, folder, messageID, formValues)
+		if err == nil && !response.Success {
+			err = ErrOperationUnsuccessful
+		}
+		return err
+	}
+}
+
+// MarkReadMessageOperation returns a MessageOperation that marks each message as read (see MarkUserEmailAccountsFolderMessageRead)
+func MarkReadMessageOperation(formValues EmailAccountFolderDelimiterParam) MessageOperation {
+	return func(ctx context.Context, cioLite CioLite, userID string, label string, folder string, messageID string) error {
+		response, err := cioLite.MarkUserEmailAccountsFolderMessageReadContext(ctx, userID, label, folder, messageID, formValues)
+		if err == nil && !response.Success {
+			err = ErrOperationUnsuccessful
+		}
+		return err
+	}
+}
+
+// MarkUnReadMessageOperation returns a MessageOperation that marks each message as unread (see MarkUserEmailAccountsFolderMessageUnRead)
+func MarkUnReadMessageOperation(formValues EmailAccountFolderDelimiterParam) MessageOperation {
+	return func(ctx context.Context, cioLite CioLite, userID string, label string, folder string, messageID string) error {
+		response, err := cioLite.MarkUserEmailAccountsFolderMessageUnReadContext(ctx, userID, label, folder, messageID, formValues)
+		if err == nil && !response.Success {
+			err = ErrOperationUnsuccessful
+		}
+		return err
+	}
+}
+
+// SetFlagsMessageOperation returns a MessageOperation that sets and/or clears the flags of each message (see SetUserEmailAccountsFolderMessageFlags)
+func SetFlagsMessageOperation(formValues SetUserEmailAccountsFolderMessageFlagsParams) MessageOperation {
+	return func(ctx context.Context, cioLite CioLite, userID string, label string, folder string, messageID string) error {
+		response, err := cioLite.SetUserEmailAccountsFolderMessageFlagsContext(ctx, userID, label, folder, messageID, formValues)
+		if err == nil && !response.Success {
+			err = ErrOperationUnsuccessful
+		}
+		return err
+	}
+}
+
+// BulkOptions configures RunBulkMessageOperation.
+type BulkOptions struct {
+	// Workers is the number of messages operated on concurrently. Defaults to DefaultBulkWorkers.
+	Workers int
+
+	// StopOnError stops starting new operations after the first failure, while letting those
+	// already running finish. The messages that were not attempted are reported with the error context.Canceled.
+	StopOnError bool
+
+	// OnResult, if set, is called (from the worker goroutines, so it must be safe for concurrent use)
+	// as each message finishes, ex: for progress reporting
+	OnResult func(BulkResult)
+}
+
+// BulkResult is the outcome of the operation on a single message
+type BulkResult struct {
+	MessageID string
+	Err       error
+}
+
+// BulkReport is the outcome of RunBulkMessageOperation
+type BulkReport struct {
+	// Results are in the same order as the message IDs
+	Results []BulkResult
+
+	Succeeded int
+	Failed    int
+}
+
+// Failures returns the results of the messages whose operation failed
+func (report BulkReport) Failures() []BulkResult {
+	var failures []BulkResult
+	for _, result := range report.Results {
+		if result.Err != nil {
+			failures = append(failures, result)
+		}
+	}
+	return failures
+}
+
+// RunBulkMessageOperation runs the operation on each of the messages in the folder, with up to options.Workers
+// running concurrently, and returns a report of the result for each message. Each operation uses the CioLite's
+// RetryPolicy and RateLimiter (set a RateLimiter to stay within CIO's limits when using many workers).
+// Canceling the context stops starting new operations, and the messages that were not attempted are
+// reported with the context's error.
+func (cioLite CioLite) RunBulkMessageOperation(ctx context.Context, userID string, label string, folder string, messageIDs []string, operation MessageOperation, options BulkOptions) BulkReport {
+	workers := options.Workers
+	if workers <= 0 {
+		workers = DefaultBulkWorkers
+	}
+	if workers > len(messageIDs) {
+		workers = len(messageIDs)
+	}
+
+	// stop is closed after the first failure (with StopOnError), to stop handing out messages
+	// without canceling the operations already running
+	stop := make(chan struct{})
+	var stopOnce sync.Once
+
+	report := BulkReport{Results: make([]BulkResult, len(messageIDs))}
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				err := operation(ctx, cioLite, userID, label, folder, messageIDs[i])
+				report.Results[i] = BulkResult{MessageID: messageIDs[i], Err: err}
+				if err != nil && options.StopOnError {
+					stopOnce.Do(func() { close(stop) })
+				}
+				if options.OnResult != nil {
+					options.OnResult(report.Results[i])
+				}
+			}
+		}()
+	}
+
+	// Hand out the messages until done, canceled, or stopped
+	next := 0
+	var notAttempted error
+handOut:
+	for next < len(messageIDs) {
+		select {
+		case <-ctx.Done():
+			notAttempted = ctx.Err()
+			break handOut
+		case <-stop:
+			notAttempted = context.Canceled
+			break handOut
+		default:
+		}
+		select {
+		case indexes <- next:
+			next++
+		case <-ctx.Done():
+			notAttempted = ctx.Err()
+			break handOut
+		case <-stop:
+			notAttempted = context.Canceled
+			break handOut
+		}
+	}
+	close(indexes)
+	wg.Wait()
+
+	// Messages that were never handed out
+	for i := next; i < len(messageIDs); i++ {
+		report.Results[i] = BulkResult{MessageID: messageIDs[i], Err: notAttempted}
+	}
+
+	for _, result := range report.Results {
+		if result.Err != nil {
+			report.Failed++
+		} else {
+			report.Succeeded++
+		}
+	}
+
+	return report
+}
@@ -0,0 +1,138 @@
+package ciolite
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+// TestSimulatedRunBulkMessageOperation tests that every message is operated on, with partial failures reported
+func TestSimulatedRunBulkMessageOperation(t *testing.T) {
+	t.Parallel()
+
+	cioLite, logger, testServer, mux := NewTestCioLiteWithLoggerAndTestServer(t)
+	defer testServer.Close()
+
+	var mu sync.Mutex
+	seen := make(map[string]bool)
+	mux.HandleFunc("/lite/users/123abc/email_accounts/0/folders/INBOX/messages/", func(w http.ResponseWriter, r *http.Request) {
+		messageID := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/lite/users/123abc/email_accounts/0/folders/INBOX/messages/"), "/read")
+		mu.Lock()
+		seen[messageID] = true
+		mu.Unlock()
+
+		switch messageID {
+		case "bad":
+			w.WriteHeader(http.StatusNotFound)
+			_, err := io.WriteString(w, `{"type":"error","value":"not found"}`)
+			Must(err)
+		case "unsuccessful":
+			_, err := io.WriteString(w, `{"success":false}`)
+			Must(err)
+		default:
+			_, err := io.WriteString(w, `{"success":true}`)
+			Must(err)
+		}
+	})
+
+	messageIDs := []string{"m1", "bad", "m2", "unsuccessful", "m3", "m4", "m5"}
+	results := 0
+	report := cioLite.RunBulkMessageOperation(context.Background(), "123abc", "0", "INBOX", messageIDs, MarkReadMessageOperation(EmailAccountFolderDelimiterParam{}), BulkOptions{
+		Workers: 3,
+		OnResult: func(BulkResult) {
+			mu.Lock()
+			results++
+			mu.Unlock()
+		},
+	})
+
+	if report.Succeeded != 5 || report.Failed != 2 || results != len(messageIDs) || len(seen) != len(messageIDs) {
+		t.Error("Expected: ", "5 succeeded, 2 failed", "; Got: ", report, "; With Results: ", results, "; With Log: ", logger.String())
+	}
+	for i, result := range report.Results {
+		if result.MessageID != messageIDs[i] {
+			t.Error("Expected: ", messageIDs[i], "; Got: ", result.MessageID)
+		}
+	}
+
+	failures := report.Failures()
+	if len(failures) != 2 || ErrorStatusCode(failures[0].Err) != http.StatusNotFound || failures[1].Err != ErrOperationUnsuccessful {
+		t.Error("Expected failures for: ", "bad, unsuccessful", "; Got: ", failures)
+	}
+}
+
+// TestRunBulkMessageOperationStopOnError tests that no new operations are started after a failure
+func TestRunBulkMessageOperationStopOnError(t *testing.T) {
+	t.Parallel()
+
+	failure := errors.New("failed")
+	operation := func(ctx context.Context, cioLite CioLite, userID string, label string, folder string, messageID string) error {
+		if messageID == "m2" {
+			return failure
+		}
+		return nil
+	}
+
+	messageIDs := []string{"m1", "m2", "m3", "m4", "m5"}
+	report := CioLite{}.RunBulkMessageOperation(context.Background(), "123abc", "0", "INBOX", messageIDs, operation, BulkOptions{Workers: 1, StopOnError: true})
+
+	if report.Succeeded != 1 || report.Failed != 4 {
+		t.Error("Expected: ", "1 succeeded, 4 failed", "; Got: ", report)
+	}
+	if report.Results[1].Err != failure {
+		t.Error("Expected: ", failure, "; Got: ", report.Results[1].Err)
+	}
+	for _, result := range report.Results[2:] {
+		if result.Err != context.Canceled {
+			t.Error("Expected: ", context.Canceled, "; Got: ", result)
+		}
+	}
+}
+
+// TestRunBulkMessageOperationStopOnErrorInFlight tests that operations already running when another fails
+// are not canceled, and finish
+func TestRunBulkMessageOperationStopOnErrorInFlight(t *testing.T) {
+	t.Parallel()
+
+	failure := errors.New("failed")
+	failed := make(chan struct{})
+	operation := func(ctx context.Context, cioLite CioLite, userID string, label string, folder string, messageID string) error {
+		switch messageID {
+		case "m1":
+			// Still running when m2 fails
+			<-failed
+			return ctx.Err()
+		case "m2":
+			return failure
+		}
+		return nil
+	}
+	onResult := func(result BulkResult) {
+		if result.MessageID == "m2" {
+			close(failed)
+		}
+	}
+
+	messageIDs := []string{"m1", "m2", "m3", "m4"}
+	report := CioLite{}.RunBulkMessageOperation(context.Background(), "123abc", "0", "INBOX", messageIDs, operation, BulkOptions{Workers: 2, StopOnError: true, OnResult: onResult})
+
+	if report.Results[0].Err != nil {
+		t.Error("Expected the operation in flight to finish; Got: ", report.Results[0].Err)
+	}
+	if report.Results[1].Err != failure {
+		t.Error("Expected: ", failure, "; Got: ", report.Results[1].Err)
+	}
+	for _, result := range report.Results[2:] {
+		if result.Err != context.Canceled {
+			t.Error("Expected: ", context.Canceled, "; Got: ", result)
+		}
+	}
+	if report.Succeeded != 1 || report.Failed != 3 {
+		t.Error("Expected: ", "1 succeeded, 3 failed", "; Got: ", report)
+	}
+}
@@ -0,0 +1,319 @@
+package ciolite
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+// CassetteMode is whether a Cassette records real requests, or replays recorded ones
+type CassetteMode int
+
+const (
+	// CassetteReplay replays the recorded responses, without making any real requests
+	CassetteReplay CassetteMode = iota
+
+	// CassetteRecord makes real requests, and records them (see Cassette.Save)
+	CassetteRecord
+)
+
+// Cassette is an http.RoundTripper that records requests and their responses to a fixture file,
+// and replays them deterministically, so that tests can run without a real CIO key/secret.
+// Set it as the Transport of CioLite.HTTPClient (see Cassette.Client).
+//
+// Secrets are never recorded: the Authorization header (with its OAuth signature) and cookies are dropped,
+// and the same values redacted from the PreRequestHook are redacted from the request params and json responses.
+// Requests are replayed by matching their method, path, and sorted params (including the redacted values),
+// and each recorded interaction is only replayed once, in the order recorded.
+type Cassette struct {
+	// Path of the fixture file (ex: testdata/cassettes/discovery.json)
+	Path string
+
+	// Mode is whether the Cassette records or replays
+	Mode CassetteMode
+
+	// Transport makes the real requests while recording (http.DefaultTransport if nil)
+	Transport http.RoundTripper
+
+	mu           sync.Mutex
+	interactions []CassetteInteraction
+	replayed     []bool
+}
+
+// CassetteInteraction is a recorded request and its response
+type CassetteInteraction struct {
+	Request  CassetteRequest  `json:"request"`
+	Response CassetteResponse `json:"response"`
+}
+
+// CassetteRequest is a recorded request
+type CassetteRequest struct {
+	Method string `json:"method"`
+
+	// Path is the escaped path of the request (ex: /lite/users/abc/email_accounts)
+	Path string `json:"path"`
+
+	// Params are the query and form values, redacted, encoded and sorted by key then value
+	Params string `json:"params,omitempty"`
+}
+
+// CassetteResponse is a recorded response
+type CassetteResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+
+	// Body of the response, if it is valid UTF-8 (with any json redacted)
+	Body string `json:"body,omitempty"`
+
+	// BodyBytes of the response, if it is not valid UTF-8 (ex: attachment content)
+	BodyBytes []byte `json:"body_bytes,omitempty"`
+}
+
+// cassetteFile is the json format of a fixture file
+type cassetteFile struct {
+	Interactions []CassetteInteraction `json:"interactions"`
+}
+
+// NewCassette returns a new *Cassette for the fixture file. When replaying, the fixture file is loaded
+// (and must exist). When recording, the Cassette starts empty, and the fixture file is written by Save.
+func NewCassette(path string, mode CassetteMode) (*Cassette, error) {
+	cassette := &Cassette{Path: path, Mode: mode}
+	if mode != CassetteReplay {
+		return cassette, nil
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "CIO: Unable to read cassette")
+	}
+	var file cassetteFile
+	if err = json.Unmarshal(data, &file); err != nil {
+		return nil, errors.Wrap(err, "CIO: Unable to unmarshal cassette")
+	}
+	cassette.interactions = file.Interactions
+	cassette.replayed = make([]bool, len(file.Interactions))
+	return cassette, nil
+}
+
+// Client returns an *http.Client using the Cassette, to set as CioLite.HTTPClient
+func (cassette *Cassette) Client() *http.Client {
+	return &http.Client{Transport: cassette, Timeout: DefaultRequestTimeout}
+}
+
+// Interactions returns the interactions recorded so far (or loaded for replay)
+func (cassette *Cassette) Interactions() []CassetteInteraction {
+	cassette.mu.Lock()
+	defer cassette.mu.Unlock()
+	return append([]CassetteInteraction(nil), cassette.interactions...)
+}
+
+// Save writes the recorded interactions to the fixture file (creating its directory if needed).
+// It does nothing when replaying.
+func (cassette *Cassette) Save() error {
+	if cassette.Mode != CassetteRecord {
+		return nil
+	}
+
+	cassette.mu.Lock()
+	data, err := json.MarshalIndent(cassetteFile{Interactions: cassette.interactions}, "", "\t")
+	cassette.mu.Unlock()
+	if err != nil {
+		return errors.Wrap(err, "CIO: Unable to marshal cassette")
+	}
+
+	if err = os.MkdirAll(filepath.Dir(cassette.Path), 0755); err != nil {
+		return errors.Wrap(err, "CIO: Unable to create cassette directory")
+	}
+	return errors.Wrap(ioutil.WriteFile(cassette.Path, append(data, '\n'), 0644), "CIO: Unable to write cassette")
+}
+
+// RoundTrip implements http.RoundTripper, recording or replaying the request
+func (cassette *Cassette) RoundTrip(req *http.Request) (*http.Response, error) {
+	cassetteReq, err := newCassetteRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if cassette.Mode == CassetteRecord {
+		return cassette.record(req, cassetteReq)
+	}
+	return cassette.replay(req, cassetteReq)
+}
+
+// record makes the real request, and records it with its response
+func (cassette *Cassette) record(req *http.Request, cassetteReq CassetteRequest) (*http.Response, error) {
+	transport := cassette.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	res, err := transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "CIO: Unable to read response to record")
+	}
+
+	// Record the redacted response, but return the real one
+	cassetteRes := CassetteResponse{StatusCode: res.StatusCode, Header: http.Header{}}
+	for key, values := range res.Header {
+		if key != "Set-Cookie" && key != "Authorization" {
+			cassetteRes.Header[key] = values
+		}
+	}
+	if utf8.Valid(body) {
+		cassetteRes.Body = redactJSON(string(body))
+	} else {
+		cassetteRes.BodyBytes = body
+	}
+
+	cassette.mu.Lock()
+	cassette.interactions = append(cassette.interactions, CassetteInteraction{Request: cassetteReq, Response: cassetteRes})
+	cassette.replayed = append(cassette.replayed, true)
+	cassette.mu.Unlock()
+
+	res.Body = ioutil.NopCloser(bytes.NewReader(body))
+	return res, nil
+}
+
+// replay returns the response of the first matching interaction that has not been replayed yet
+func (cassette *Cassette) replay(req *http.Request, cassetteReq CassetteRequest) (*http.Response, error) {
+	cassette.mu.Lock()
+	defer cassette.mu.Unlock()
+
+	for i, interaction := range cassette.interactions {
+		if cassette.replayed[i] || interaction.Request != cassetteReq {
+			continue
+		}
+		cassette.replayed[i] = true
+
+		body := []byte(interaction.Response.Body)
+		if interaction.Response.BodyBytes != nil {
+			body = interaction.Response.BodyBytes
+		}
+		header := http.Header{}
+		for key, values := range interaction.Response.Header {
+			header[key] = append([]string(nil), values...)
+		}
+		header.Set("Content-Length", strconv.Itoa(len(body)))
+
+		return &http.Response{
+			Status:        strconv.Itoa(interaction.Response.StatusCode) + " " + http.StatusText(interaction.Response.StatusCode),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          ioutil.NopCloser(bytes.NewReader(body)),
+			ContentLength: int64(len(body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, errors.Errorf("CIO: No unplayed cassette interaction matches %s %s %s", cassetteReq.Method, cassetteReq.Path, cassetteReq.Params)
+}
+
+// newCassetteRequest returns the request as recorded (or matched), reading and then restoring its body
+func newCassetteRequest(req *http.Request) (CassetteRequest, error) {
+	params := url.Values{}
+	for key, values := range req.URL.Query() {
+		params[key] = append(params[key], values...)
+	}
+
+	if req.Body != nil {
+		body, err := ioutil.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return CassetteRequest{}, errors.Wrap(err, "CIO: Unable to read request body")
+		}
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+		if mediaType, _, _ := mime.ParseMediaType(req.Header.Get("Content-Type")); mediaType == "application/x-www-form-urlencoded" && len(body) > 0 {
+			form, err := url.ParseQuery(string(body))
+			if err != nil {
+				return CassetteRequest{}, errors.Wrap(err, "CIO: Unable to parse request body")
+			}
+			for key, values := range form {
+				params[key] = append(params[key], values...)
+			}
+		}
+	}
+
+	// Redact the params, and drop any OAuth params (including the signature)
+	params = redactBodyValues(params)
+	for key, values := range params {
+		if strings.HasPrefix(key, "oauth_") {
+			delete(params, key)
+			continue
+		}
+		values = append([]string(nil), values...)
+		sort.Strings(values)
+		params[key] = values
+	}
+
+	return CassetteRequest{Method: req.Method, Path: req.URL.EscapedPath(), Params: params.Encode()}, nil
+}
+
+// redactJSON returns the json body with the values of the redactedKeys redacted,
+// or the body unchanged if it is not json or has nothing to redact
+func redactJSON(body string) string {
+	decoder := json.NewDecoder(strings.NewReader(body))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil || !redactJSONValue(value) {
+		return body
+	}
+	redacted, err := json.Marshal(value)
+	if err != nil {
+		return body
+	}
+	return string(redacted)
+}
+
+// redactJSONValue redacts the values of the redactedKeys in the decoded json, returning true if any were redacted
+func redactJSONValue(value interface{}) bool {
+	redacted := false
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		for key, v := range typed {
+			if s, ok := v.(string); ok && len(s) > 0 && isRedactedKey(key) {
+				typed[key] = "redacted"
+				redacted = true
+			} else if redactJSONValue(v) {
+				redacted = true
+			}
+		}
+	case []interface{}:
+		for _, v := range typed {
+			if redactJSONValue(v) {
+				redacted = true
+			}
+		}
+	}
+	return redacted
+}
+
+// isRedactedKey returns true if the key is one of the redactedKeys
+func isRedactedKey(key string) bool {
+	for _, redactedKey := range redactedKeys {
+		if key == redactedKey {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,119 @@
+package ciolite
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestSimulatedCassette tests recording requests to a simulated server, and replaying them without it
+func TestSimulatedCassette(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "cassette")
+	Must(err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "cassettes", "oauth_providers.json")
+
+	cioLite, logger, testServer, mux := NewTestCioLiteWithLoggerAndTestServer(t)
+
+	mux.HandleFunc("/lite/oauth_providers", func(w http.ResponseWriter, r *http.Request) {
+		Must(r.ParseForm())
+		if r.PostForm.Get("provider_consumer_secret") != "topSecret" {
+			t.Error("Expected the real secret to be sent while recording; Got: ", r.PostForm)
+		}
+		_, err := io.WriteString(w, `{"success": true, "provider_consumer_key": "123-abc.xzy.com"}`)
+		Must(err)
+	})
+	mux.HandleFunc("/lite/oauth_providers/123-abc.xzy.com", func(w http.ResponseWriter, r *http.Request) {
+		_, err := io.WriteString(w, `{"type": "GMAIL_OAUTH", "provider_consumer_key": "123-abc.xzy.com", "provider_consumer_secret": "topSecret"}`)
+		Must(err)
+	})
+
+	// Record
+	recorder, err := NewCassette(path, CassetteRecord)
+	Must(err)
+	cioLite.HTTPClient = recorder.Client()
+
+	params := CreateOAuthProviderParams{Type: string(ProviderGmailOAuth), ProviderConsumerKey: "123-abc.xzy.com", ProviderConsumerSecret: "topSecret"}
+	created, err := cioLite.CreateOAuthProvider(params)
+	if err != nil || !created.Success {
+		t.Error("Expected successful create; Got: ", created, "; With Error: ", err, "; With Log: ", logger.String())
+	}
+	recorded, err := cioLite.GetOAuthProvider("123-abc.xzy.com")
+	if err != nil || recorded.ProviderConsumerSecret != "topSecret" {
+		t.Error("Expected the real response while recording; Got: ", recorded, "; With Error: ", err)
+	}
+	Must(recorder.Save())
+	testServer.Close()
+
+	fixture, err := ioutil.ReadFile(path)
+	Must(err)
+	for _, secret := range []string{"topSecret", "oauth_signature", "Authorization"} {
+		if strings.Contains(string(fixture), secret) {
+			t.Error("Expected the cassette not to contain: ", secret, "; Got: ", string(fixture))
+		}
+	}
+
+	// Replay, with a different key and host
+	player, err := NewCassette(path, CassetteReplay)
+	Must(err)
+	replayCioLite := NewCioLite("otherKey", "otherSecret")
+	replayCioLite.Host = "https://api.example.com"
+	replayCioLite.HTTPClient = player.Client()
+
+	replayedCreate, err := replayCioLite.CreateOAuthProvider(params)
+	expectedCreate := created
+	expectedCreate.ProviderConsumerKey = "redacted"
+	if err != nil || !reflect.DeepEqual(replayedCreate, expectedCreate) {
+		t.Error("Expected: ", expectedCreate, "; Got: ", replayedCreate, "; With Error: ", err)
+	}
+	replayed, err := replayCioLite.GetOAuthProvider("123-abc.xzy.com")
+	expected := recorded
+	expected.ProviderConsumerKey = "redacted"
+	expected.ProviderConsumerSecret = "redacted"
+	if err != nil || !reflect.DeepEqual(replayed, expected) {
+		t.Error("Expected: ", expected, "; Got: ", replayed, "; With Error: ", err)
+	}
+
+	// Each interaction is only replayed once, and requests must match
+	if _, err = replayCioLite.GetOAuthProvider("123-abc.xzy.com"); err == nil {
+		t.Error("Expected an error replaying an interaction twice")
+	}
+	if _, err = replayCioLite.GetOAuthProvider("other"); err == nil {
+		t.Error("Expected an error for an unrecorded request")
+	}
+}
+
+// TestCassetteRequestMatching tests that requests match regardless of param order, and without secrets
+func TestCassetteRequestMatching(t *testing.T) {
+	t.Parallel()
+
+	first, err := http.NewRequest("POST", "https://api.context.io/lite/users?b=2&a=1", strings.NewReader("password=hunter2&email=a%40b.com"))
+	Must(err)
+	first.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	second, err := http.NewRequest("POST", "http://localhost/lite/users?a=1&oauth_signature=abc&b=2", strings.NewReader("email=a%40b.com&password=letmein"))
+	Must(err)
+	second.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	firstReq, err := newCassetteRequest(first)
+	Must(err)
+	secondReq, err := newCassetteRequest(second)
+	Must(err)
+
+	expected := CassetteRequest{Method: "POST", Path: "/lite/users", Params: "a=1&b=2&email=a%40b.com&password=redacted"}
+	if firstReq != expected || secondReq != expected {
+		t.Error("Expected: ", expected, "; Got: ", firstReq, " and ", secondReq)
+	}
+
+	// The body can still be sent
+	body, err := ioutil.ReadAll(first.Body)
+	if err != nil || string(body) != "password=hunter2&email=a%40b.com" {
+		t.Error("Expected the request body to be restored; Got: ", string(body), "; With Error: ", err)
+	}
+}
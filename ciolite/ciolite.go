@@ -11,6 +11,8 @@ import (
 	"net/url"
 	"strconv"
 	"time"
+
+	"github.com/pkg/errors"
 )
 
 const (
@@ -19,6 +21,16 @@ const (
 
 	// DefaultRequestTimeout is the default timeout duration used on HTTP requests
 	DefaultRequestTimeout = 120 * time.Second
+
+	// DefaultCallbackMaxAge is the default maximum age (or clock skew) of a callback's Timestamp
+	DefaultCallbackMaxAge = 5 * time.Minute
+)
+
+// Errors returned when validating Webhook Callbacks and User Account Status Callbacks
+var (
+	ErrCallbackSignature = errors.New("CIO: Callback signature is invalid")
+	ErrCallbackTimestamp = errors.New("CIO: Callback timestamp is too old or too far in the future")
+	ErrCallbackReplayed  = errors.New("CIO: Callback token has already been received")
 )
 
 // CioLite struct contains the api key and secret, along with an optional logger,
@@ -39,6 +51,7 @@ type CioLite struct {
 	// 	Method (GET/POST/etc),
 	// 	URL,
 	// 	redacted body values.
+	// See RequestHook, which is easier to use and to extend.
 	PreRequestHook func(string, string, string, string, url.Values)
 
 	// PostRequestShouldRetryHook is a function (mostly for logging) that will be
@@ -56,12 +69,50 @@ type CioLite struct {
 	// 	any error received while attempting this request.
 	// The returned boolean is whether this request should be retried or not, which
 	// if False then this is the last call of this function, but if True means this
-	// function will be called again.
+	// function will be called again (unless the request's context is done, in which
+	// case no further attempts are made).
+	// If a RetryPolicy is also set, the request is retried if either this function
+	// or the RetryPolicy says it should be, so a logging-only hook can return False.
+	// If the RateLimiter refuses an attempt, this is still called (with a Status Code of 0
+	// and the error), but the request is not retried whatever this function returns.
+	// See ResponseHook, which is easier to use and to extend, for hooks that only log.
 	PostRequestShouldRetryHook func(int, string, string, string, string, int, string, time.Time, time.Time, error) bool
 
+	// RequestHook is an optional function (mostly for logging) that will be executed
+	// before the request is made, with a RequestInfo describing it.
+	// It is called after the PreRequestHook (if both are set).
+	RequestHook RequestHook
+
+	// ResponseHook is an optional function (mostly for logging or metrics) that will be
+	// executed after each attempt of the request, with a ResponseInfo describing the
+	// attempt (including whether it will be retried). It is also executed for an attempt
+	// that is never sent, because the RateLimiter refused it or the request's context was
+	// done before the retry, so the last call for each request always has WillRetry False.
+	// It is called after the PostRequestShouldRetryHook (if both are set).
+	ResponseHook ResponseHook
+
+	// RetryPolicy is an optional built-in policy for retrying failed requests with
+	// exponential backoff and jitter (see DefaultRetryPolicy). If nil, requests are
+	// only retried when PostRequestShouldRetryHook says they should be.
+	RetryPolicy *RetryPolicy
+
+	// RateLimiter is an optional client-side rate limiter (see NewRateLimiter), which is
+	// waited on before every attempt of every request. Being a pointer, it is shared by
+	// all copies of this CioLite.
+	RateLimiter *RateLimiter
+
+	// FolderCache is an optional cache of each account's folders (see NewFolderCache),
+	// used by FindFolderBySymbolicName. Being a pointer, it is shared by all copies of this CioLite.
+	FolderCache *FolderCache
+
 	// ResponseBodyCloseErrorHook is a function (purely for logging) that will
 	// execute if there is an error closing the response body.
 	ResponseBodyCloseErrorHook func(error)
+
+	// PreviousAPISecrets are older api secrets that are still accepted when validating
+	// callbacks, so that the api secret can be rotated without rejecting callbacks
+	// that were signed with the old secret during the changeover.
+	PreviousAPISecrets []string
 }
 
 // NewCioLite returns a CIO Lite struct (without a logger) for accessing the CIO Lite API.
@@ -87,12 +138,76 @@ func NewTestCioLiteServer(handler http.Handler) (CioLite, *httptest.Server) {
 	return testCioLite, testServer
 }
 
-// ValidateCallback returns true if this Webhook Callback or User Account Status Callback authenticates
+// ValidateCallback returns true if this Webhook Callback or User Account Status Callback authenticates,
+// using either the api secret or any of the PreviousAPISecrets.
 func (cio CioLite) ValidateCallback(token string, signature string, timestamp int) bool {
-	// Hash timestamp and token with secret, compare to signature
+	// Hash timestamp and token with each secret, compare to signature in constant time
 	message := strconv.Itoa(timestamp) + token
-	hash := hashHmac(sha256.New, message, cio.apiSecret)
-	return len(hash) > 0 && signature == hash
+	if validSignature(message, signature, cio.apiSecret) {
+		return true
+	}
+	for _, secret := range cio.PreviousAPISecrets {
+		if len(secret) > 0 && validSignature(message, signature, secret) {
+			return true
+		}
+	}
+	return false
+}
+
+// SignCallback returns the signature of a Webhook Callback or User Account Status Callback
+// with the token and timestamp, as CIO signs them with the api secret (see ValidateCallback).
+func (cio CioLite) SignCallback(token string, timestamp int) string {
+	return hashHmac(sha256.New, strconv.Itoa(timestamp)+token, cio.apiSecret)
+}
+
+// validSignature returns true if the signature matches the message hashed with the secret
+func validSignature(message string, signature string, secret string) bool {
+	hash := hashHmac(sha256.New, message, secret)
+	return len(hash) > 0 && hmac.Equal([]byte(signature), []byte(hash))
+}
+
+// ValidateCallbackStrict returns nil if this Webhook Callback or User Account Status Callback authenticates,
+// its timestamp is within maxSkew of now (DefaultCallbackMaxAge if maxSkew is not positive),
+// and its token has not been received before according to the NonceStore (if not nil).
+// Otherwise it returns ErrCallbackSignature, ErrCallbackTimestamp, ErrCallbackReplayed, or an error from the NonceStore.
+func (cio CioLite) ValidateCallbackStrict(token string, signature string, timestamp int, maxSkew time.Duration, store NonceStore) error {
+	if maxSkew <= 0 {
+		maxSkew = DefaultCallbackMaxAge
+	}
+	return cio.validateCallback(token, signature, timestamp, maxSkew, store, time.Now())
+}
+
+// validateCallback checks the signature, then the timestamp (unless maxAge is negative), then the token against the store (if any)
+func (cio CioLite) validateCallback(token string, signature string, timestamp int, maxAge time.Duration, store NonceStore, now time.Time) error {
+	if !cio.ValidateCallback(token, signature, timestamp) {
+		return ErrCallbackSignature
+	}
+
+	if maxAge == 0 {
+		maxAge = DefaultCallbackMaxAge
+	}
+	if maxAge > 0 {
+		age := now.Sub(time.Unix(int64(timestamp), 0))
+		if age > maxAge || age < -maxAge {
+			return ErrCallbackTimestamp
+		}
+	}
+
+	if store != nil {
+		// Remember the token for as long as its timestamp would still be accepted
+		expires := time.Unix(int64(timestamp), 0).Add(maxAge)
+		if maxAge < 0 {
+			expires = now.Add(DefaultCallbackMaxAge)
+		}
+		seen, err := store.MarkSeen(token, expires)
+		if err != nil {
+			return errors.Wrap(err, "CIO: Could not check callback token")
+		}
+		if seen {
+			return ErrCallbackReplayed
+		}
+	}
+	return nil
 }
 
 // hashHmac returns the hash of a message hashed with the provided hash function, using the provided secret
@@ -0,0 +1,591 @@
+package ciolitetest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"net/http/httptest"
+	"net/mail"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/contextio/contextio-go/ciolite"
+	"github.com/pkg/errors"
+)
+
+// DefaultFakeMaxClockSkew is the default maximum age (or clock skew) of a request's OAuth timestamp
+const DefaultFakeMaxClockSkew = 5 * time.Minute
+
+// DefaultFakeFolders are the folders (and their symbolic names) of each new email account on a FakeServer
+var DefaultFakeFolders = []ciolite.GetUsersEmailAccountFoldersResponse{
+	{Name: "INBOX"},
+	{Name: "Drafts", SymbolicName: `\Drafts`},
+	{Name: "Sent", SymbolicName: `\Sent`},
+	{Name: "Junk", SymbolicName: `\Junk`},
+	{Name: "Trash", SymbolicName: `\Trash`},
+}
+
+// Fault is an error injected into the responses of a FakeServer, for requests that
+// are authenticated (so a Fault is returned instead of the endpoint's normal response).
+type Fault struct {
+	// Method matches the request method (ex: GET), or every method if empty
+	Method string
+
+	// Path is a path.Match pattern matched against the escaped request path
+	// (ex: /lite/users/*/email_accounts/*/folders), or every path if empty
+	Path string
+
+	// StatusCode of the response, which defaults to 500
+	StatusCode int
+
+	// Body of the response, which defaults to a CIO json error
+	Body string
+
+	// Header is added to the response (ex: Retry-After)
+	Header http.Header
+
+	// Times is the number of matching requests that fail, or 0 for every matching request
+	Times int
+}
+
+// FakeRequest is a request received by a FakeServer
+type FakeRequest struct {
+	Method string
+
+	// Path is the escaped request path (ex: /lite/users/abc/email_accounts)
+	Path string
+
+	// Values are the query and form values, without the OAuth parameters
+	Values url.Values
+
+	// StatusCode is the status code of the response
+	StatusCode int
+}
+
+// FakeServer is a stateful in-memory fake of the CIO Lite API, for tests that need to run offline.
+// It implements every endpoint used by the ciolite package, keeping users, email accounts, folders,
+// messages, webhooks, connect tokens, OAuth providers and the status callback url in memory, so that
+// (for example) a message moved by one request is listed in its new folder by the next request.
+// Every request must be signed with OAuth 1.0 (as a ciolite.CioLite does) using the FakeServer's key and secret.
+// Messages are added with AddMessage, as the API itself has no way to create them.
+type FakeServer struct {
+	// Server is the underlying *httptest.Server
+	Server *httptest.Server
+
+	// MaxClockSkew is the maximum age (or clock skew) of a request's OAuth timestamp (DefaultFakeMaxClockSkew if 0)
+	MaxClockSkew time.Duration
+
+	key    string
+	secret string
+
+	mu                sync.Mutex
+	lastID            int
+	users             []*fakeUser
+	webhooks          []*fakeWebhook
+	connectTokens     []*fakeConnectToken
+	oauthProviders    []ciolite.GetOAuthProvidersResponse
+	discovery         map[string]ciolite.GetDiscoveryIMAPResponse
+	statusCallbackURL string
+	nonces            map[string]bool
+	faults            []*Fault
+	requests          []FakeRequest
+}
+
+// fakeUser is a user, and its email accounts
+type fakeUser struct {
+	user     ciolite.GetUsersResponse
+	accounts []*fakeAccount
+}
+
+// fakeAccount is an email account, and its folders
+type fakeAccount struct {
+	account ciolite.GetUsersEmailAccountsResponse
+	email   string
+	folders []*fakeFolder
+}
+
+// fakeFolder is a folder, and its messages (oldest first)
+type fakeFolder struct {
+	name         string
+	symbolicName string
+	messages     []*fakeMessage
+}
+
+// fakeMessage is a message, as listed and as parsed from its raw source
+type fakeMessage struct {
+	listing ciolite.GetUsersEmailAccountFolderMessagesResponse
+	flags   ciolite.UserEmailAccountsFolderMessageFlags
+	raw     string
+	parsed  *ciolite.Message
+}
+
+// fakeWebhook is a webhook of a user, or of the app if userID is empty
+type fakeWebhook struct {
+	userID  string
+	webhook ciolite.GetUsersWebhooksResponse
+}
+
+// fakeConnectToken is a connect token of an email account, a user, or the app (if userID is empty)
+type fakeConnectToken struct {
+	userID string
+	label  string
+	token  ciolite.GetConnectTokenResponse
+
+	// connectedUserID is the user of the token, or the user created by completing a token of the app
+	connectedUserID string
+}
+
+// NewFakeServer starts and returns a new, empty, *FakeServer (which must be closed when done being used),
+// that accepts requests signed with the api key and secret
+func NewFakeServer(key string, secret string) *FakeServer {
+	server := &FakeServer{
+		key:    key,
+		secret: secret,
+		discovery: map[string]ciolite.GetDiscoveryIMAPResponse{
+			"gmail.com":      {Server: "imap.gmail.com", UseSSL: true, OAuth: true, Port: 993},
+			"googlemail.com": {Server: "imap.gmail.com", UseSSL: true, OAuth: true, Port: 993},
+			"outlook.com":    {Server: "imap-mail.outlook.com", UseSSL: true, OAuth: true, Port: 993},
+			"hotmail.com":    {Server: "imap-mail.outlook.com", UseSSL: true, OAuth: true, Port: 993},
+			"yahoo.com":      {Server: "imap.mail.yahoo.com", UseSSL: true, Port: 993},
+		},
+		nonces: make(map[string]bool),
+	}
+	server.Server = httptest.NewServer(server)
+	return server
+}
+
+// URL returns the base url of the FakeServer
+func (server *FakeServer) URL() string {
+	return server.Server.URL
+}
+
+// Close shuts down the FakeServer
+func (server *FakeServer) Close() {
+	server.Server.Close()
+}
+
+// CioLite returns a ciolite.CioLite that makes its requests to the FakeServer, with its api key and secret
+func (server *FakeServer) CioLite() ciolite.CioLite {
+	cioLite := ciolite.NewCioLite(server.key, server.secret)
+	cioLite.Host = server.URL()
+	cioLite.HTTPClient = &http.Client{Timeout: 5 * time.Second}
+	return cioLite
+}
+
+// InjectFault makes matching requests fail, until the Fault has been used Times times (or until ClearFaults).
+// Faults are matched in the order they were injected.
+func (server *FakeServer) InjectFault(fault Fault) {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	server.faults = append(server.faults, &fault)
+}
+
+// ClearFaults removes all injected Faults
+func (server *FakeServer) ClearFaults() {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	server.faults = nil
+}
+
+// Requests returns the requests received so far, in order
+func (server *FakeServer) Requests() []FakeRequest {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	return append([]FakeRequest(nil), server.requests...)
+}
+
+// SetDiscovery sets the IMAP settings returned by discovery for email addresses of the domain (ex: example.com)
+func (server *FakeServer) SetDiscovery(domain string, imap ciolite.GetDiscoveryIMAPResponse) {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	server.discovery[strings.ToLower(domain)] = imap
+}
+
+// AddMessage parses the raw RFC 822 message and adds it to the folder, as if it had just been received.
+// It returns the message as it is listed (with its new EmailMessageID).
+func (server *FakeServer) AddMessage(userID string, label string, folder string, raw string) (ciolite.GetUsersEmailAccountFolderMessagesResponse, error) {
+	parsed, err := ciolite.ParseRawMessage(strings.NewReader(raw))
+	if err != nil {
+		return ciolite.GetUsersEmailAccountFolderMessagesResponse{}, err
+	}
+
+	server.mu.Lock()
+	defer server.mu.Unlock()
+
+	_, account, err := server.lookupAccount(userID, label)
+	if err != nil {
+		return ciolite.GetUsersEmailAccountFolderMessagesResponse{}, err
+	}
+	fakeFolder := account.folder(folder)
+	if fakeFolder == nil {
+		return ciolite.GetUsersEmailAccountFolderMessagesResponse{}, errors.Errorf("ciolitetest: Folder %s not found", folder)
+	}
+
+	message := &fakeMessage{raw: raw, parsed: parsed, listing: messageListing(server.newID(), parsed)}
+	fakeFolder.messages = append(fakeFolder.messages, message)
+	return server.messageResponse(userID, account, fakeFolder, message, url.Values{"include_flags": {"1"}}), nil
+}
+
+// CompleteConnectToken simulates the user authorizing access to their email account with the connect token.
+// For a connect token of the app, a user is created with the email account, and for a connect token of a user,
+// the email account is added to the user, and for a connect token of an email account, the account is reconnected.
+func (server *FakeServer) CompleteConnectToken(token string, account ciolite.CreateUserParams) error {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+
+	connectToken := server.lookupConnectToken(token)
+	if connectToken == nil {
+		return errors.Errorf("ciolitetest: Connect token %s not found", token)
+	}
+	if connectToken.token.Used != 0 {
+		return errors.Errorf("ciolitetest: Connect token %s has already been used", token)
+	}
+	if len(account.Email) == 0 {
+		account.Email = connectToken.token.Email
+	}
+
+	switch {
+	case len(connectToken.userID) == 0:
+		account.FirstName = connectToken.token.FirstName
+		account.LastName = connectToken.token.LastName
+		user, fakeAccount, err := server.addUser(account)
+		if err != nil {
+			return err
+		}
+		if fakeAccount == nil {
+			return errors.New("ciolitetest: The email account's server is required")
+		}
+		connectToken.connectedUserID = user.user.ID
+		connectToken.token.ServerLabel = fakeAccount.account.Label
+
+	case len(connectToken.label) == 0:
+		user, err := server.lookupUser(connectToken.userID)
+		if err != nil {
+			return err
+		}
+		fakeAccount, err := server.addEmailAccount(user, account)
+		if err != nil {
+			return err
+		}
+		connectToken.token.ServerLabel = fakeAccount.account.Label
+
+	default:
+		_, fakeAccount, err := server.lookupAccount(connectToken.userID, connectToken.label)
+		if err != nil {
+			return err
+		}
+		fakeAccount.account.Status = string(ciolite.AccountStatusOK)
+		connectToken.token.ServerLabel = fakeAccount.account.Label
+	}
+
+	connectToken.token.Email = account.Email
+	connectToken.token.Used = int(time.Now().Unix())
+	connectToken.token.Expires = ciolite.ExpiresMixed{}
+	return nil
+}
+
+// ServeHTTP implements http.Handler
+func (server *FakeServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		writeFakeError(w, http.StatusBadRequest, "Could not read request body")
+		return
+	}
+	form := url.Values{}
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType == "application/x-www-form-urlencoded" && len(body) > 0 {
+		if form, err = url.ParseQuery(string(body)); err != nil {
+			writeFakeError(w, http.StatusBadRequest, "Invalid form values")
+			return
+		}
+	}
+
+	server.mu.Lock()
+	defer server.mu.Unlock()
+
+	values := url.Values{}
+	for _, v := range []url.Values{r.URL.Query(), form} {
+		for key, vs := range v {
+			values[key] = append(values[key], vs...)
+		}
+	}
+	recorded := FakeRequest{Method: r.Method, Path: r.URL.EscapedPath(), Values: values}
+	statusCode := server.serve(w, r, form, values)
+	recorded.StatusCode = statusCode
+	server.requests = append(server.requests, recorded)
+}
+
+// serve authenticates and handles the request, and returns the status code of the response
+func (server *FakeServer) serve(w http.ResponseWriter, r *http.Request, form url.Values, values url.Values) int {
+	if err := server.authenticate(r, form); err != nil {
+		return writeFakeError(w, http.StatusUnauthorized, err.Error())
+	}
+
+	if fault := server.matchFault(r); fault != nil {
+		for key, vs := range fault.Header {
+			for _, v := range vs {
+				w.Header().Add(key, v)
+			}
+		}
+		statusCode := fault.StatusCode
+		if statusCode == 0 {
+			statusCode = http.StatusInternalServerError
+		}
+		if len(fault.Body) == 0 {
+			return writeFakeError(w, statusCode, "Injected fault")
+		}
+		w.WriteHeader(statusCode)
+		_, _ = w.Write([]byte(fault.Body))
+		return statusCode
+	}
+
+	// Split the escaped path, so that folder names and message IDs can contain an escaped /
+	var segments []string
+	for _, segment := range strings.Split(strings.Trim(r.URL.EscapedPath(), "/"), "/") {
+		unescaped, err := url.PathUnescape(segment)
+		if err != nil {
+			return writeFakeError(w, http.StatusBadRequest, "Invalid path")
+		}
+		segments = append(segments, unescaped)
+	}
+
+	handle, params, methodAllowed := matchFakeRoute(r.Method, segments)
+	if handle == nil {
+		if methodAllowed {
+			return writeFakeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		}
+		return writeFakeError(w, http.StatusNotFound, "Resource not found")
+	}
+
+	result, err := handle(server, &fakeRequest{params: params, values: values, accept: r.Header.Get("Accept")})
+	if err != nil {
+		if fakeErr, ok := err.(fakeError); ok {
+			return writeFakeError(w, fakeErr.statusCode, fakeErr.message)
+		}
+		return writeFakeError(w, http.StatusInternalServerError, err.Error())
+	}
+
+	if content, ok := result.(fakeContent); ok {
+		w.Header().Set("Content-Type", content.contentType)
+		if len(content.fileName) > 0 {
+			w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": content.fileName}))
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(content.content)))
+		_, _ = w.Write(content.content)
+		return http.StatusOK
+	}
+
+	payload, err := json.Marshal(result)
+	if err != nil {
+		return writeFakeError(w, http.StatusInternalServerError, err.Error())
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(payload)
+	return http.StatusOK
+}
+
+// matchFault returns the first Fault matching the request (using it up), or nil
+func (server *FakeServer) matchFault(r *http.Request) *Fault {
+	for i, fault := range server.faults {
+		if len(fault.Method) > 0 && !strings.EqualFold(fault.Method, r.Method) {
+			continue
+		}
+		if len(fault.Path) > 0 {
+			if matched, err := path.Match(fault.Path, r.URL.EscapedPath()); err != nil || !matched {
+				continue
+			}
+		}
+		if fault.Times > 0 {
+			fault.Times--
+			if fault.Times == 0 {
+				server.faults = append(server.faults[:i:i], server.faults[i+1:]...)
+			}
+		}
+		return fault
+	}
+	return nil
+}
+
+// fakeRequest is a request being handled
+type fakeRequest struct {
+	// params are the path segments matched by the *s of the route
+	params []string
+
+	// values are the query and form values
+	values url.Values
+
+	accept string
+}
+
+// fakeContent is a non-json response (ex: a raw message, or attachment content)
+type fakeContent struct {
+	contentType string
+	fileName    string
+	content     []byte
+}
+
+// fakeError is an error response, in the same json format as CIO
+type fakeError struct {
+	statusCode int
+	message    string
+}
+
+// Error implements error
+func (err fakeError) Error() string {
+	return err.message
+}
+
+// badRequest returns a 400 fakeError
+func badRequest(format string, args ...interface{}) error {
+	return fakeError{http.StatusBadRequest, fmt.Sprintf(format, args...)}
+}
+
+// notFound returns a 404 fakeError
+func notFound(format string, args ...interface{}) error {
+	return fakeError{http.StatusNotFound, fmt.Sprintf(format, args...)}
+}
+
+// writeFakeError writes a CIO json error, and returns the status code
+func writeFakeError(w http.ResponseWriter, statusCode int, message string) int {
+	payload, _ := json.Marshal(struct {
+		Type  string `json:"type"`
+		Value string `json:"value"`
+	}{"error", message})
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_, _ = w.Write(payload)
+	return statusCode
+}
+
+// newID returns a new unique ID, in the same format as CIO's IDs
+func (server *FakeServer) newID() string {
+	server.lastID++
+	return fmt.Sprintf("%024x", server.lastID)
+}
+
+// resourceURL returns the url of the resource at the path
+func (server *FakeServer) resourceURL(format string, args ...interface{}) string {
+	escaped := make([]interface{}, len(args))
+	for i, arg := range args {
+		escaped[i] = strings.Replace(url.QueryEscape(fmt.Sprint(arg)), "+", "%20", -1)
+	}
+	return server.URL() + fmt.Sprintf(format, escaped...)
+}
+
+// lookupUser returns the user, or a 404 fakeError
+func (server *FakeServer) lookupUser(userID string) (*fakeUser, error) {
+	for _, user := range server.users {
+		if user.user.ID == userID {
+			return user, nil
+		}
+	}
+	return nil, notFound("User %s not found", userID)
+}
+
+// lookupAccount returns the user and its email account, or a 404 fakeError
+func (server *FakeServer) lookupAccount(userID string, label string) (*fakeUser, *fakeAccount, error) {
+	user, err := server.lookupUser(userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, account := range user.accounts {
+		if account.account.Label == label {
+			return user, account, nil
+		}
+	}
+	return nil, nil, notFound("Email account %s not found", label)
+}
+
+// lookupConnectToken returns the connect token, or nil
+func (server *FakeServer) lookupConnectToken(token string) *fakeConnectToken {
+	for _, connectToken := range server.connectTokens {
+		if connectToken.token.Token == token {
+			return connectToken
+		}
+	}
+	return nil
+}
+
+// folder returns the folder with the name, or nil
+func (account *fakeAccount) folder(name string) *fakeFolder {
+	for _, folder := range account.folders {
+		if folder.name == name {
+			return folder
+		}
+	}
+	return nil
+}
+
+// folderName converts a folder name using the delimiter to the account's delimiter
+func (account *fakeAccount) folderName(name string, delimiter string) string {
+	if len(delimiter) == 0 || delimiter == fakeDelimiter {
+		return name
+	}
+	return strings.Replace(name, delimiter, fakeDelimiter, -1)
+}
+
+// fakeDelimiter is the folder delimiter of every email account
+const fakeDelimiter = "/"
+
+// messageListing returns the parsed message as listed by CIO, without its folders, flags or resource url
+func messageListing(emailMessageID string, parsed *ciolite.Message) ciolite.GetUsersEmailAccountFolderMessagesResponse {
+	listing := ciolite.GetUsersEmailAccountFolderMessagesResponse{
+		EmailMessageID: emailMessageID,
+		Subject:        parsed.Subject,
+		MessageID:      parsed.MessageID,
+		InReplyTo:      parsed.InReplyTo,
+		References:     parsed.References,
+		ReceivedAt:     int(time.Now().Unix()),
+		ListHeaders:    ciolite.ListHeaders{},
+		Addresses: ciolite.GetUsersEmailAccountFolderMessageAddresses{
+			From:    fakeAddresses(parsed.From),
+			To:      fakeAddresses(parsed.To),
+			Cc:      fakeAddresses(parsed.Cc),
+			Bcc:     fakeAddresses(parsed.Bcc),
+			Sender:  fakeAddresses(parsed.Sender),
+			ReplyTo: fakeAddresses(parsed.ReplyTo),
+		},
+	}
+	if !parsed.Date.IsZero() {
+		listing.SentAt = int(parsed.Date.Unix())
+	}
+	for name := range parsed.Header {
+		if strings.HasPrefix(name, "List-") {
+			listing.ListHeaders[name] = parsed.Header.Get(name)
+		}
+	}
+	for _, body := range parsed.Bodies {
+		listing.Bodies = append(listing.Bodies, ciolite.UsersEmailAccountFolderMessageBody{
+			BodySection: body.BodySection,
+			Type:        body.Type,
+			Encoding:    body.Encoding,
+			Size:        body.Size,
+		})
+	}
+	for i, attachment := range parsed.Attachments {
+		listing.Attachments = append(listing.Attachments, ciolite.UsersEmailAccountFolderMessageAttachment{
+			Type:               attachment.Type,
+			FileName:           attachment.FileName,
+			BodySection:        attachment.BodySection,
+			ContentDisposition: attachment.ContentDisposition,
+			EmailMessageID:     listing.EmailMessageID,
+			Size:               attachment.Size,
+			AttachmentID:       i + 1,
+		})
+	}
+	return listing
+}
+
+// fakeAddresses converts parsed addresses to CIO addresses
+func fakeAddresses(addresses []*mail.Address) []ciolite.Address {
+	var converted []ciolite.Address
+	for _, address := range addresses {
+		converted = append(converted, ciolite.Address{Email: address.Address, Name: address.Name})
+	}
+	return converted
+}
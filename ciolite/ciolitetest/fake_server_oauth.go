@@ -0,0 +1,146 @@
+package ciolitetest
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// authenticate checks the OAuth 1.0 (RFC 5849) HMAC-SHA1 signature of the request, which must
+// be signed with the FakeServer's key and secret, and must not reuse a nonce
+func (server *FakeServer) authenticate(r *http.Request, form url.Values) error {
+	oauthParams, err := parseOAuthHeader(r.Header.Get("Authorization"))
+	if err != nil {
+		return err
+	}
+
+	if oauthParams["oauth_consumer_key"] != server.key {
+		return errors.New("Invalid consumer key")
+	}
+	if oauthParams["oauth_signature_method"] != "HMAC-SHA1" {
+		return errors.New("Unsupported signature method")
+	}
+	if version, ok := oauthParams["oauth_version"]; ok && version != "1.0" {
+		return errors.New("Unsupported OAuth version")
+	}
+
+	timestamp, err := strconv.ParseInt(oauthParams["oauth_timestamp"], 10, 64)
+	if err != nil {
+		return errors.New("Invalid timestamp")
+	}
+	maxClockSkew := server.MaxClockSkew
+	if maxClockSkew == 0 {
+		maxClockSkew = DefaultFakeMaxClockSkew
+	}
+	if age := time.Since(time.Unix(timestamp, 0)); age > maxClockSkew || age < -maxClockSkew {
+		return errors.New("Timestamp is too old or too far in the future")
+	}
+
+	signature := oauthParams["oauth_signature"]
+	delete(oauthParams, "oauth_signature")
+	expected := oauthSignature(server.secret, oauthBaseString(r, form, oauthParams))
+	if !hmac.Equal([]byte(signature), []byte(expected)) {
+		return errors.New("Invalid signature")
+	}
+
+	nonce := oauthParams["oauth_timestamp"] + ":" + oauthParams["oauth_nonce"]
+	if len(oauthParams["oauth_nonce"]) == 0 || server.nonces[nonce] {
+		return errors.New("Invalid or reused nonce")
+	}
+	server.nonces[nonce] = true
+
+	return nil
+}
+
+// parseOAuthHeader returns the parameters of an OAuth Authorization header
+func parseOAuthHeader(header string) (map[string]string, error) {
+	if !strings.HasPrefix(header, "OAuth ") {
+		return nil, errors.New("Missing OAuth authorization")
+	}
+	oauthParams := make(map[string]string)
+	for _, param := range strings.Split(header[len("OAuth "):], ",") {
+		param = strings.TrimSpace(param)
+		i := strings.Index(param, "=")
+		if i <= 0 {
+			return nil, errors.New("Invalid OAuth authorization")
+		}
+		value, err := url.PathUnescape(strings.Trim(param[i+1:], `"`))
+		if err != nil {
+			return nil, errors.New("Invalid OAuth authorization")
+		}
+		oauthParams[param[:i]] = value
+	}
+	return oauthParams, nil
+}
+
+// oauthBaseString returns the signature base string of the request
+func oauthBaseString(r *http.Request, form url.Values, oauthParams map[string]string) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	host := strings.ToLower(r.Host)
+	if (scheme == "http" && strings.HasSuffix(host, ":80")) || (scheme == "https" && strings.HasSuffix(host, ":443")) {
+		host = host[:strings.LastIndex(host, ":")]
+	}
+	requestPath := r.RequestURI
+	if i := strings.Index(requestPath, "?"); i >= 0 {
+		requestPath = requestPath[:i]
+	}
+
+	// Normalize the parameters, sorted by encoded name then value
+	var params [][2]string
+	for _, values := range []url.Values{r.URL.Query(), form} {
+		for key, vs := range values {
+			for _, v := range vs {
+				params = append(params, [2]string{oauthEncode(key), oauthEncode(v)})
+			}
+		}
+	}
+	for key, v := range oauthParams {
+		params = append(params, [2]string{oauthEncode(key), oauthEncode(v)})
+	}
+	sort.Slice(params, func(i, j int) bool {
+		if params[i][0] != params[j][0] {
+			return params[i][0] < params[j][0]
+		}
+		return params[i][1] < params[j][1]
+	})
+	normalized := make([]string, len(params))
+	for i, param := range params {
+		normalized[i] = param[0] + "=" + param[1]
+	}
+
+	return strings.ToUpper(r.Method) + "&" + oauthEncode(scheme+"://"+host+requestPath) + "&" + oauthEncode(strings.Join(normalized, "&"))
+}
+
+// oauthSignature returns the HMAC-SHA1 signature of the base string, for a request without a token
+func oauthSignature(secret string, baseString string) string {
+	h := hmac.New(sha1.New, []byte(oauthEncode(secret)+"&"))
+	_, _ = h.Write([]byte(baseString))
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
+
+// oauthEncode percent-encodes every byte other than the unreserved characters (RFC 3986)
+func oauthEncode(s string) string {
+	var buf bytes.Buffer
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if ('A' <= c && c <= 'Z') || ('a' <= c && c <= 'z') || ('0' <= c && c <= '9') || c == '-' || c == '.' || c == '_' || c == '~' {
+			buf.WriteByte(c)
+		} else {
+			fmt.Fprintf(&buf, "%%%02X", c)
+		}
+	}
+	return buf.String()
+}
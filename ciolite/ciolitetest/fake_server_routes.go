@@ -0,0 +1,1252 @@
+package ciolitetest
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/contextio/contextio-go/ciolite"
+)
+
+// fakeHandler handles a request, returning a value to encode as json, a fakeContent, or an error
+type fakeHandler func(server *FakeServer, req *fakeRequest) (interface{}, error)
+
+// fakeRoute routes requests to a fakeHandler, with each * in the pattern matching one path segment
+type fakeRoute struct {
+	method  string
+	pattern string
+	handle  fakeHandler
+}
+
+// fakeRoutes are the endpoints implemented by the FakeServer
+var fakeRoutes = []fakeRoute{
+	// Users
+	{"GET", "/lite/users", (*FakeServer).getUsers},
+	{"POST", "/lite/users", (*FakeServer).createUser},
+	{"GET", "/lite/users/*", (*FakeServer).getUser},
+	{"POST", "/lite/users/*", (*FakeServer).modifyUser},
+	{"DELETE", "/lite/users/*", (*FakeServer).deleteUser},
+
+	// Email accounts
+	{"GET", "/lite/users/*/email_accounts", (*FakeServer).getEmailAccounts},
+	{"POST", "/lite/users/*/email_accounts", (*FakeServer).createEmailAccount},
+	{"GET", "/lite/users/*/email_accounts/*", (*FakeServer).getEmailAccount},
+	{"POST", "/lite/users/*/email_accounts/*", (*FakeServer).modifyEmailAccount},
+	{"DELETE", "/lite/users/*/email_accounts/*", (*FakeServer).deleteEmailAccount},
+
+	// Folders
+	{"GET", "/lite/users/*/email_accounts/*/folders", (*FakeServer).getFolders},
+	{"GET", "/lite/users/*/email_accounts/*/folders/*", (*FakeServer).getFolder},
+	{"POST", "/lite/users/*/email_accounts/*/folders/*", (*FakeServer).createFolder},
+	{"DELETE", "/lite/users/*/email_accounts/*/folders/*", (*FakeServer).deleteFolder},
+	{"PUT", "/lite/users/*/email_accounts/*/folders/*", (*FakeServer).renameFolder},
+
+	// Messages
+	{"GET", "/lite/users/*/email_accounts/*/folders/*/messages", (*FakeServer).getMessages},
+	{"GET", "/lite/users/*/email_accounts/*/folders/*/messages/*", (*FakeServer).getMessage},
+	{"PUT", "/lite/users/*/email_accounts/*/folders/*/messages/*", (*FakeServer).moveMessage},
+	{"PUT", "/lite/users/*/email_accounts/*/folders/*/messages2/*", (*FakeServer).moveMessage},
+	{"GET", "/lite/users/*/email_accounts/*/folders/*/messages/*/attachments", (*FakeServer).getAttachments},
+	{"GET", "/lite/users/*/email_accounts/*/folders/*/messages/*/attachments/*", (*FakeServer).getAttachment},
+	{"GET", "/lite/users/*/email_accounts/*/folders/*/messages/*/body", (*FakeServer).getBody},
+	{"GET", "/lite/users/*/email_accounts/*/folders/*/messages/*/flags", (*FakeServer).getFlags},
+	{"POST", "/lite/users/*/email_accounts/*/folders/*/messages/*/flags", (*FakeServer).setFlags},
+	{"GET", "/lite/users/*/email_accounts/*/folders/*/messages/*/headers", (*FakeServer).getHeaders},
+	{"GET", "/lite/users/*/email_accounts/*/folders/*/messages/*/raw", (*FakeServer).getRaw},
+	{"POST", "/lite/users/*/email_accounts/*/folders/*/messages/*/read", (*FakeServer).markRead},
+	{"DELETE", "/lite/users/*/email_accounts/*/folders/*/messages/*/read", (*FakeServer).markUnRead},
+
+	// Webhooks, of the app and of users
+	{"GET", "/lite/webhooks", (*FakeServer).getWebhooks},
+	{"POST", "/lite/webhooks", (*FakeServer).createWebhook},
+	{"GET", "/lite/webhooks/*", (*FakeServer).getWebhook},
+	{"POST", "/lite/webhooks/*", (*FakeServer).modifyWebhook},
+	{"DELETE", "/lite/webhooks/*", (*FakeServer).deleteWebhook},
+	{"GET", "/lite/users/*/webhooks", (*FakeServer).getWebhooks},
+	{"POST", "/lite/users/*/webhooks", (*FakeServer).createWebhook},
+	{"GET", "/lite/users/*/webhooks/*", (*FakeServer).getWebhook},
+	{"POST", "/lite/users/*/webhooks/*", (*FakeServer).modifyWebhook},
+	{"DELETE", "/lite/users/*/webhooks/*", (*FakeServer).deleteWebhook},
+
+	// Connect tokens, of the app, of users, and of email accounts
+	{"GET", "/lite/connect_tokens", (*FakeServer).getConnectTokens},
+	{"POST", "/lite/connect_tokens", (*FakeServer).createConnectToken},
+	{"GET", "/lite/connect_tokens/*", (*FakeServer).getConnectToken},
+	{"DELETE", "/lite/connect_tokens/*", (*FakeServer).deleteConnectToken},
+	{"GET", "/lite/users/*/connect_tokens", (*FakeServer).getConnectTokens},
+	{"POST", "/lite/users/*/connect_tokens", (*FakeServer).createConnectToken},
+	{"GET", "/lite/users/*/connect_tokens/*", (*FakeServer).getConnectToken},
+	{"DELETE", "/lite/users/*/connect_tokens/*", (*FakeServer).deleteConnectToken},
+	{"GET", "/lite/users/*/email_accounts/*/connect_tokens", (*FakeServer).getConnectTokens},
+	{"POST", "/lite/users/*/email_accounts/*/connect_tokens", (*FakeServer).createConnectToken},
+	{"GET", "/lite/users/*/email_accounts/*/connect_tokens/*", (*FakeServer).getConnectToken},
+	{"DELETE", "/lite/users/*/email_accounts/*/connect_tokens/*", (*FakeServer).deleteConnectToken},
+
+	// OAuth providers
+	{"GET", "/lite/oauth_providers", (*FakeServer).getOAuthProviders},
+	{"POST", "/lite/oauth_providers", (*FakeServer).createOAuthProvider},
+	{"GET", "/lite/oauth_providers/*", (*FakeServer).getOAuthProvider},
+	{"DELETE", "/lite/oauth_providers/*", (*FakeServer).deleteOAuthProvider},
+
+	// Discovery
+	{"GET", "/lite/discovery", (*FakeServer).getDiscovery},
+
+	// Status callback url
+	{"GET", "/app/status_callback_url", (*FakeServer).getStatusCallbackURL},
+	{"POST", "/app/status_callback_url", (*FakeServer).createStatusCallbackURL},
+	{"DELETE", "/app/status_callback_url", (*FakeServer).deleteStatusCallbackURL},
+}
+
+// matchFakeRoute returns the handler and the path segments matched by its *s, and whether
+// the path matches a route of another method (if there is no handler)
+func matchFakeRoute(method string, segments []string) (fakeHandler, []string, bool) {
+	methodAllowed := false
+	for _, route := range fakeRoutes {
+		patternSegments := strings.Split(strings.Trim(route.pattern, "/"), "/")
+		if len(patternSegments) != len(segments) {
+			continue
+		}
+		var params []string
+		matched := true
+		for i, patternSegment := range patternSegments {
+			if patternSegment == "*" {
+				params = append(params, segments[i])
+			} else if patternSegment != segments[i] {
+				matched = false
+				break
+			}
+		}
+		if !matched {
+			continue
+		}
+		if route.method != method {
+			methodAllowed = true
+			continue
+		}
+		return route.handle, params, false
+	}
+	return nil, nil, methodAllowed
+}
+
+// required returns the value, or a 400 fakeError if it is missing
+func required(values url.Values, key string) (string, error) {
+	value := values.Get(key)
+	if len(value) == 0 {
+		return "", badRequest("%s is required", key)
+	}
+	return value, nil
+}
+
+// paginate returns the slice bounds of the page selected by the limit and offset values
+func paginate(length int, values url.Values) (int, int) {
+	start, _ := strconv.Atoi(values.Get("offset"))
+	if start < 0 || start > length {
+		start = length
+	}
+	end := length
+	if limit, _ := strconv.Atoi(values.Get("limit")); limit > 0 && start+limit < length {
+		end = start + limit
+	}
+	return start, end
+}
+
+// unixNow returns the current Unix timestamp
+func unixNow() int {
+	return int(time.Now().Unix())
+}
+
+// Users
+
+// userResponse returns the user as returned by CIO
+func (server *FakeServer) userResponse(user *fakeUser) ciolite.GetUsersResponse {
+	response := user.user
+	response.ResourceURL = server.resourceURL("/lite/users/%s", user.user.ID)
+	response.EmailAccounts = nil
+	for _, account := range user.accounts {
+		response.EmailAccounts = append(response.EmailAccounts, server.accountResponse(user, account))
+	}
+	return response
+}
+
+// getUsers implements GET /lite/users
+func (server *FakeServer) getUsers(req *fakeRequest) (interface{}, error) {
+	email := req.values.Get("email")
+	users := []ciolite.GetUsersResponse{}
+	for _, user := range server.users {
+		if len(email) > 0 && !containsFold(user.user.EmailAddresses, email) {
+			continue
+		}
+		if !anyAccountMatchesStatus(user.accounts, req.values) {
+			continue
+		}
+		users = append(users, server.userResponse(user))
+	}
+	start, end := paginate(len(users), req.values)
+	return users[start:end], nil
+}
+
+// getUser implements GET /lite/users/:id
+func (server *FakeServer) getUser(req *fakeRequest) (interface{}, error) {
+	user, err := server.lookupUser(req.params[0])
+	if err != nil {
+		return nil, err
+	}
+	return server.userResponse(user), nil
+}
+
+// createUser implements POST /lite/users
+func (server *FakeServer) createUser(req *fakeRequest) (interface{}, error) {
+	user, account, err := server.addUser(createUserParams(req.values))
+	if err != nil {
+		return nil, err
+	}
+	response := ciolite.CreateUserResponse{
+		Success:     true,
+		ID:          user.user.ID,
+		ResourceURL: server.resourceURL("/lite/users/%s", user.user.ID),
+	}
+	if account != nil {
+		response.EmailAccount = ciolite.CreateEmailAccountResponse{
+			Status:      account.account.Status,
+			Label:       account.account.Label,
+			ResourceURL: server.accountResponse(user, account).ResourceURL,
+		}
+	}
+	return response, nil
+}
+
+// modifyUser implements POST /lite/users/:id
+func (server *FakeServer) modifyUser(req *fakeRequest) (interface{}, error) {
+	user, err := server.lookupUser(req.params[0])
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := req.values["first_name"]; ok {
+		user.user.FirstName = req.values.Get("first_name")
+	}
+	if _, ok := req.values["last_name"]; ok {
+		user.user.LastName = req.values.Get("last_name")
+	}
+	return ciolite.ModifyUserResponse{Success: true, ResourceURL: server.resourceURL("/lite/users/%s", user.user.ID)}, nil
+}
+
+// deleteUser implements DELETE /lite/users/:id, which also deletes the user's webhooks and connect tokens
+func (server *FakeServer) deleteUser(req *fakeRequest) (interface{}, error) {
+	user, err := server.lookupUser(req.params[0])
+	if err != nil {
+		return nil, err
+	}
+	for i, u := range server.users {
+		if u == user {
+			server.users = append(server.users[:i], server.users[i+1:]...)
+			break
+		}
+	}
+	var webhooks []*fakeWebhook
+	for _, webhook := range server.webhooks {
+		if webhook.userID != user.user.ID {
+			webhooks = append(webhooks, webhook)
+		}
+	}
+	server.webhooks = webhooks
+	server.deleteConnectTokens(user.user.ID, "")
+	return ciolite.DeleteUserResponse{Success: true, ResourceURL: server.resourceURL("/lite/users/%s", user.user.ID)}, nil
+}
+
+// createUserParams returns the form values of a user or email account being created
+func createUserParams(values url.Values) ciolite.CreateUserParams {
+	port, _ := strconv.Atoi(values.Get("port"))
+	return ciolite.CreateUserParams{
+		Email:                values.Get("email"),
+		Server:               values.Get("server"),
+		Username:             values.Get("username"),
+		Type:                 values.Get("type"),
+		UseSSL:               values.Get("use_ssl") == "1",
+		Port:                 port,
+		ProviderRefreshToken: values.Get("provider_refresh_token"),
+		ProviderConsumerKey:  values.Get("provider_consumer_key"),
+		Password:             values.Get("password"),
+		StatusCallbackURL:    values.Get("status_callback_url"),
+		MigrateAccountID:     values.Get("migrate_account_id"),
+		FirstName:            values.Get("first_name"),
+		LastName:             values.Get("last_name"),
+	}
+}
+
+// addUser adds a user, along with an email account if the params include a server
+func (server *FakeServer) addUser(params ciolite.CreateUserParams) (*fakeUser, *fakeAccount, error) {
+	if len(params.Email) == 0 {
+		return nil, nil, badRequest("email is required")
+	}
+	user := &fakeUser{user: ciolite.GetUsersResponse{
+		ID:             server.newID(),
+		EmailAddresses: []string{params.Email},
+		FirstName:      params.FirstName,
+		LastName:       params.LastName,
+		Created:        unixNow(),
+	}}
+
+	var account *fakeAccount
+	if len(params.Server) > 0 {
+		var err error
+		if account, err = server.addEmailAccount(user, params); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	server.users = append(server.users, user)
+	return user, account, nil
+}
+
+// Email accounts
+
+// accountResponse returns the email account as returned by CIO
+func (server *FakeServer) accountResponse(user *fakeUser, account *fakeAccount) ciolite.GetUsersEmailAccountsResponse {
+	response := account.account
+	response.ResourceURL = server.resourceURL("/lite/users/%s/email_accounts/%s", user.user.ID, account.account.Label)
+	return response
+}
+
+// accountMatchesStatus returns true if the email account matches the status and status_ok values (if any)
+func accountMatchesStatus(account *fakeAccount, values url.Values) bool {
+	if status := values.Get("status"); len(status) > 0 && string(account.account.Status) != status {
+		return false
+	}
+	switch values.Get("status_ok") {
+	case "1":
+		return account.account.Status == string(ciolite.AccountStatusOK)
+	case "0":
+		return account.account.Status != string(ciolite.AccountStatusOK)
+	}
+	return true
+}
+
+// anyAccountMatchesStatus returns true if there are no status and status_ok values, or any email account matches them
+func anyAccountMatchesStatus(accounts []*fakeAccount, values url.Values) bool {
+	if len(values.Get("status")) == 0 && len(values.Get("status_ok")) == 0 {
+		return true
+	}
+	for _, account := range accounts {
+		if accountMatchesStatus(account, values) {
+			return true
+		}
+	}
+	return false
+}
+
+// getEmailAccounts implements GET /lite/users/:id/email_accounts
+func (server *FakeServer) getEmailAccounts(req *fakeRequest) (interface{}, error) {
+	user, err := server.lookupUser(req.params[0])
+	if err != nil {
+		return nil, err
+	}
+	accounts := []ciolite.GetUsersEmailAccountsResponse{}
+	for _, account := range user.accounts {
+		if accountMatchesStatus(account, req.values) {
+			accounts = append(accounts, server.accountResponse(user, account))
+		}
+	}
+	return accounts, nil
+}
+
+// getEmailAccount implements GET /lite/users/:id/email_accounts/:label
+func (server *FakeServer) getEmailAccount(req *fakeRequest) (interface{}, error) {
+	user, account, err := server.lookupAccount(req.params[0], req.params[1])
+	if err != nil {
+		return nil, err
+	}
+	return server.accountResponse(user, account), nil
+}
+
+// createEmailAccount implements POST /lite/users/:id/email_accounts
+func (server *FakeServer) createEmailAccount(req *fakeRequest) (interface{}, error) {
+	user, err := server.lookupUser(req.params[0])
+	if err != nil {
+		return nil, err
+	}
+	account, err := server.addEmailAccount(user, createUserParams(req.values))
+	if err != nil {
+		return nil, err
+	}
+	return ciolite.CreateEmailAccountResponse{
+		Status:      account.account.Status,
+		Label:       account.account.Label,
+		ResourceURL: server.accountResponse(user, account).ResourceURL,
+	}, nil
+}
+
+// addEmailAccount adds an email account (with the DefaultFakeFolders) to the user
+func (server *FakeServer) addEmailAccount(user *fakeUser, params ciolite.CreateUserParams) (*fakeAccount, error) {
+	for key, value := range map[string]string{"email": params.Email, "server": params.Server, "username": params.Username} {
+		if len(value) == 0 {
+			return nil, badRequest("%s is required", key)
+		}
+	}
+	if len(params.Type) > 0 && !ciolite.SourceType(params.Type).Valid() {
+		return nil, badRequest("Unsupported type %s", params.Type)
+	}
+
+	authType := ciolite.AuthenticationTypePassword
+	switch {
+	case len(params.ProviderRefreshToken) > 0:
+		if server.lookupOAuthProvider(params.ProviderConsumerKey) < 0 {
+			return nil, badRequest("OAuth provider %s not found", params.ProviderConsumerKey)
+		}
+		authType = ciolite.AuthenticationTypeOAuth2
+	case len(params.Password) == 0:
+		return nil, badRequest("password or provider_refresh_token is required")
+	}
+
+	// Labels are unique within the user, ex: 0::imap.example.com
+	label := ""
+	for n := len(user.accounts); len(label) == 0; n++ {
+		label = fmt.Sprintf("%d::%s", n, strings.ToLower(params.Server))
+		for _, existing := range user.accounts {
+			if existing.account.Label == label {
+				label = ""
+				break
+			}
+		}
+	}
+
+	account := &fakeAccount{
+		account: ciolite.GetUsersEmailAccountsResponse{
+			Status:             string(ciolite.AccountStatusOK),
+			Type:               string(ciolite.SourceTypeIMAP),
+			AuthenticationType: string(authType),
+			Server:             params.Server,
+			Label:              label,
+			Username:           params.Username,
+			UseSSL:             params.UseSSL,
+			Port:               params.Port,
+		},
+		email: params.Email,
+	}
+	for _, folder := range DefaultFakeFolders {
+		account.folders = append(account.folders, &fakeFolder{name: folder.Name, symbolicName: folder.SymbolicName})
+	}
+
+	user.accounts = append(user.accounts, account)
+	if !containsFold(user.user.EmailAddresses, params.Email) {
+		user.user.EmailAddresses = append(user.user.EmailAddresses, params.Email)
+	}
+	return account, nil
+}
+
+// modifyEmailAccount implements POST /lite/users/:id/email_accounts/:label.
+// New credentials reconnect the account (setting its status to OK), unless a status is also set.
+func (server *FakeServer) modifyEmailAccount(req *fakeRequest) (interface{}, error) {
+	user, account, err := server.lookupAccount(req.params[0], req.params[1])
+	if err != nil {
+		return nil, err
+	}
+
+	status := ciolite.AccountStatus(req.values.Get("status"))
+	if len(status) > 0 && !status.Valid() {
+		return nil, badRequest("Invalid status %s", status)
+	}
+	if refreshToken := req.values.Get("provider_refresh_token"); len(refreshToken) > 0 {
+		if consumerKey := req.values.Get("provider_consumer_key"); len(consumerKey) > 0 && server.lookupOAuthProvider(consumerKey) < 0 {
+			return nil, badRequest("OAuth provider %s not found", consumerKey)
+		}
+		account.account.AuthenticationType = string(ciolite.AuthenticationTypeOAuth2)
+		account.account.Status = string(ciolite.AccountStatusOK)
+	}
+	if len(req.values.Get("password")) > 0 {
+		account.account.AuthenticationType = string(ciolite.AuthenticationTypePassword)
+		account.account.Status = string(ciolite.AccountStatusOK)
+	}
+	if len(status) > 0 {
+		account.account.Status = string(status)
+	}
+
+	return ciolite.ModifyEmailAccountResponse{Success: true, ResourceURL: server.accountResponse(user, account).ResourceURL}, nil
+}
+
+// deleteEmailAccount implements DELETE /lite/users/:id/email_accounts/:label
+func (server *FakeServer) deleteEmailAccount(req *fakeRequest) (interface{}, error) {
+	user, account, err := server.lookupAccount(req.params[0], req.params[1])
+	if err != nil {
+		return nil, err
+	}
+	for i, a := range user.accounts {
+		if a == account {
+			user.accounts = append(user.accounts[:i], user.accounts[i+1:]...)
+			break
+		}
+	}
+	server.deleteConnectTokens(user.user.ID, account.account.Label)
+	return ciolite.DeleteEmailAccountResponse{Success: true, ResourceURL: server.accountResponse(user, account).ResourceURL}, nil
+}
+
+// Folders
+
+// lookupFolder returns the email account and folder of the request, using its delimiter (if any)
+func (server *FakeServer) lookupFolder(req *fakeRequest) (*fakeUser, *fakeAccount, *fakeFolder, error) {
+	user, account, err := server.lookupAccount(req.params[0], req.params[1])
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	name := account.folderName(req.params[2], req.values.Get("delimiter"))
+	folder := account.folder(name)
+	if folder == nil {
+		return nil, nil, nil, notFound("Folder %s not found", name)
+	}
+	return user, account, folder, nil
+}
+
+// folderResponse returns the folder as returned by CIO
+func (server *FakeServer) folderResponse(user *fakeUser, account *fakeAccount, folder *fakeFolder) ciolite.GetUsersEmailAccountFoldersResponse {
+	response := ciolite.GetUsersEmailAccountFoldersResponse{
+		Name:         folder.name,
+		SymbolicName: folder.symbolicName,
+		NbMessages:   len(folder.messages),
+		Delimiter:    fakeDelimiter,
+		ResourceURL:  server.resourceURL("/lite/users/%s/email_accounts/%s/folders/%s", user.user.ID, account.account.Label, folder.name),
+	}
+	for _, message := range folder.messages {
+		if !message.flags.Read {
+			response.NbUnseenMessages++
+		}
+	}
+	return response
+}
+
+// getFolders implements GET /lite/users/:id/email_accounts/:label/folders
+func (server *FakeServer) getFolders(req *fakeRequest) (interface{}, error) {
+	user, account, err := server.lookupAccount(req.params[0], req.params[1])
+	if err != nil {
+		return nil, err
+	}
+	folders := []ciolite.GetUsersEmailAccountFoldersResponse{}
+	for _, folder := range account.folders {
+		if req.values.Get("include_names_only") == "1" {
+			folders = append(folders, ciolite.GetUsersEmailAccountFoldersResponse{Name: folder.name})
+		} else {
+			folders = append(folders, server.folderResponse(user, account, folder))
+		}
+	}
+	return folders, nil
+}
+
+// getFolder implements GET /lite/users/:id/email_accounts/:label/folders/:folder
+func (server *FakeServer) getFolder(req *fakeRequest) (interface{}, error) {
+	user, account, folder, err := server.lookupFolder(req)
+	if err != nil {
+		return nil, err
+	}
+	return server.folderResponse(user, account, folder), nil
+}
+
+// createFolder implements POST /lite/users/:id/email_accounts/:label/folders/:folder
+func (server *FakeServer) createFolder(req *fakeRequest) (interface{}, error) {
+	_, account, err := server.lookupAccount(req.params[0], req.params[1])
+	if err != nil {
+		return nil, err
+	}
+	name := account.folderName(req.params[2], req.values.Get("delimiter"))
+	if account.folder(name) != nil {
+		return nil, badRequest("Folder %s already exists", name)
+	}
+	account.folders = append(account.folders, &fakeFolder{name: name})
+	return ciolite.CreateEmailAccountFolderResponse{Success: true}, nil
+}
+
+// deleteFolder implements DELETE /lite/users/:id/email_accounts/:label/folders/:folder, including its messages
+func (server *FakeServer) deleteFolder(req *fakeRequest) (interface{}, error) {
+	_, account, folder, err := server.lookupFolder(req)
+	if err != nil {
+		return nil, err
+	}
+	if strings.EqualFold(folder.name, "INBOX") {
+		return nil, badRequest("The INBOX cannot be deleted")
+	}
+	for i, f := range account.folders {
+		if f == folder {
+			account.folders = append(account.folders[:i], account.folders[i+1:]...)
+			break
+		}
+	}
+	return ciolite.DeleteEmailAccountFolderResponse{Success: true}, nil
+}
+
+// renameFolder implements PUT /lite/users/:id/email_accounts/:label/folders/:folder, including its child folders
+func (server *FakeServer) renameFolder(req *fakeRequest) (interface{}, error) {
+	_, account, folder, err := server.lookupFolder(req)
+	if err != nil {
+		return nil, err
+	}
+	newFolderID, err := required(req.values, "new_folder_id")
+	if err != nil {
+		return nil, err
+	}
+	newName := account.folderName(newFolderID, req.values.Get("delimiter"))
+	if account.folder(newName) != nil {
+		return nil, badRequest("Folder %s already exists", newName)
+	}
+	oldPrefix := folder.name + fakeDelimiter
+	for _, f := range account.folders {
+		if strings.HasPrefix(f.name, oldPrefix) {
+			f.name = newName + fakeDelimiter + strings.TrimPrefix(f.name, oldPrefix)
+		}
+	}
+	folder.name = newName
+	return ciolite.RenameEmailAccountFolderResponse{Success: true}, nil
+}
+
+// Messages
+
+// lookupMessage returns the email account, folder and message of the request,
+// where the message is identified by its EmailMessageID or its MessageID
+func (server *FakeServer) lookupMessage(req *fakeRequest) (*fakeUser, *fakeAccount, *fakeFolder, *fakeMessage, error) {
+	user, account, folder, err := server.lookupFolder(req)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	for _, message := range folder.messages {
+		if message.listing.EmailMessageID == req.params[3] || message.listing.MessageID == req.params[3] {
+			return user, account, folder, message, nil
+		}
+	}
+	return nil, nil, nil, nil, notFound("Message %s not found", req.params[3])
+}
+
+// messageResponse returns the message as listed by CIO, including its flags and bodies as requested by the values
+func (server *FakeServer) messageResponse(userID string, account *fakeAccount, folder *fakeFolder, message *fakeMessage, values url.Values) ciolite.GetUsersEmailAccountFolderMessagesResponse {
+	response := message.listing
+	response.Folders = []string{folder.name}
+	response.ResourceURL = server.resourceURL("/lite/users/%s/email_accounts/%s/folders/%s/messages/%s", userID, account.account.Label, folder.name, message.listing.EmailMessageID)
+	if values.Get("include_flags") == "1" {
+		response.Flags = message.flags
+	}
+	if bodyType := values.Get("body_type"); len(bodyType) > 0 {
+		response.Bodies = nil
+		for _, body := range message.listing.Bodies {
+			if body.Type == bodyType {
+				response.Bodies = append(response.Bodies, body)
+			}
+		}
+	}
+	return response
+}
+
+// getMessages implements GET /lite/users/:id/email_accounts/:label/folders/:folder/messages, newest first
+func (server *FakeServer) getMessages(req *fakeRequest) (interface{}, error) {
+	user, account, folder, err := server.lookupFolder(req)
+	if err != nil {
+		return nil, err
+	}
+	messages := []ciolite.GetUsersEmailAccountFolderMessagesResponse{}
+	for i := len(folder.messages) - 1; i >= 0; i-- {
+		messages = append(messages, server.messageResponse(user.user.ID, account, folder, folder.messages[i], req.values))
+	}
+	start, end := paginate(len(messages), req.values)
+	return messages[start:end], nil
+}
+
+// getMessage implements GET /lite/users/:id/email_accounts/:label/folders/:folder/messages/:message_id
+func (server *FakeServer) getMessage(req *fakeRequest) (interface{}, error) {
+	user, account, folder, message, err := server.lookupMessage(req)
+	if err != nil {
+		return nil, err
+	}
+	return server.messageResponse(user.user.ID, account, folder, message, req.values), nil
+}
+
+// moveMessage implements PUT /lite/users/:id/email_accounts/:label/folders/:folder/messages/:message_id (and messages2)
+func (server *FakeServer) moveMessage(req *fakeRequest) (interface{}, error) {
+	_, account, folder, message, err := server.lookupMessage(req)
+	if err != nil {
+		return nil, err
+	}
+	newFolderID, err := required(req.values, "new_folder_id")
+	if err != nil {
+		return nil, err
+	}
+	newFolder := account.folder(account.folderName(newFolderID, req.values.Get("delimiter")))
+	if newFolder == nil {
+		return nil, notFound("Folder %s not found", newFolderID)
+	}
+	if newFolder != folder {
+		for i, m := range folder.messages {
+			if m == message {
+				folder.messages = append(folder.messages[:i], folder.messages[i+1:]...)
+				break
+			}
+		}
+		newFolder.messages = append(newFolder.messages, message)
+	}
+	return ciolite.MoveUserEmailAccountFolderMessageResponse{Success: true}, nil
+}
+
+// attachmentResponse returns the attachment as listed by CIO
+func attachmentResponse(attachment ciolite.UsersEmailAccountFolderMessageAttachment) ciolite.GetUserEmailAccountsFolderMessageAttachmentsResponse {
+	return ciolite.GetUserEmailAccountsFolderMessageAttachmentsResponse{
+		Type:               attachment.Type,
+		FileName:           attachment.FileName,
+		BodySection:        attachment.BodySection,
+		ContentDisposition: attachment.ContentDisposition,
+		EmailMessageID:     attachment.EmailMessageID,
+		Size:               attachment.Size,
+		AttachmentID:       attachment.AttachmentID,
+	}
+}
+
+// getAttachments implements GET .../messages/:message_id/attachments
+func (server *FakeServer) getAttachments(req *fakeRequest) (interface{}, error) {
+	_, _, _, message, err := server.lookupMessage(req)
+	if err != nil {
+		return nil, err
+	}
+	attachments := []ciolite.GetUserEmailAccountsFolderMessageAttachmentsResponse{}
+	for _, attachment := range message.listing.Attachments {
+		attachments = append(attachments, attachmentResponse(attachment))
+	}
+	return attachments, nil
+}
+
+// getAttachment implements GET .../messages/:message_id/attachments/:attachment_id,
+// which returns the attachment's json metadata, or its content unless json is accepted
+func (server *FakeServer) getAttachment(req *fakeRequest) (interface{}, error) {
+	_, _, _, message, err := server.lookupMessage(req)
+	if err != nil {
+		return nil, err
+	}
+	attachmentID, err := strconv.Atoi(req.params[4])
+	if err != nil || attachmentID < 1 || attachmentID > len(message.listing.Attachments) {
+		return nil, notFound("Attachment %s not found", req.params[4])
+	}
+	attachment := message.listing.Attachments[attachmentID-1]
+	if strings.Contains(req.accept, "application/json") {
+		return attachmentResponse(attachment), nil
+	}
+	return fakeContent{
+		contentType: attachment.Type,
+		fileName:    attachment.FileName,
+		content:     message.parsed.Attachments[attachmentID-1].Content,
+	}, nil
+}
+
+// getBody implements GET .../messages/:message_id/body
+func (server *FakeServer) getBody(req *fakeRequest) (interface{}, error) {
+	_, _, _, message, err := server.lookupMessage(req)
+	if err != nil {
+		return nil, err
+	}
+	bodies := []ciolite.GetUserEmailAccountsFolderMessageBodyResponse{}
+	for _, body := range message.parsed.Bodies {
+		if bodyType := req.values.Get("type"); len(bodyType) > 0 && body.Type != bodyType {
+			continue
+		}
+		charset := body.Charset
+		if body.Converted {
+			charset = "UTF-8"
+		}
+		bodies = append(bodies, ciolite.GetUserEmailAccountsFolderMessageBodyResponse{
+			Type:        body.Type,
+			Charset:     charset,
+			Content:     body.Content,
+			BodySection: body.BodySection,
+		})
+	}
+	return bodies, nil
+}
+
+// getFlags implements GET .../messages/:message_id/flags
+func (server *FakeServer) getFlags(req *fakeRequest) (interface{}, error) {
+	user, account, folder, message, err := server.lookupMessage(req)
+	if err != nil {
+		return nil, err
+	}
+	return ciolite.GetUserEmailAccountsFolderMessageFlagsResponse{
+		ResourceURL: server.messageResponse(user.user.ID, account, folder, message, nil).ResourceURL + "/flags",
+		Flags:       message.flags,
+	}, nil
+}
+
+// setFlags implements POST .../messages/:message_id/flags, where each flag is set by 1, cleared by 0, or unchanged if missing
+func (server *FakeServer) setFlags(req *fakeRequest) (interface{}, error) {
+	_, _, _, message, err := server.lookupMessage(req)
+	if err != nil {
+		return nil, err
+	}
+	flags := message.flags
+	for key, flag := range map[string]*bool{
+		"seen":     &flags.Read,
+		"answered": &flags.Answered,
+		"flagged":  &flags.Flagged,
+		"draft":    &flags.Draft,
+		"deleted":  &flags.Deleted,
+	} {
+		switch req.values.Get(key) {
+		case ciolite.FlagSet:
+			*flag = true
+		case ciolite.FlagClear:
+			*flag = false
+		case ciolite.FlagUnchanged:
+		default:
+			return nil, badRequest("Invalid %s value %s", key, req.values.Get(key))
+		}
+	}
+	message.flags = flags
+	return ciolite.SetUserEmailAccountsFolderMessageFlagsResponse{Success: true, Flags: flags}, nil
+}
+
+// getHeaders implements GET .../messages/:message_id/headers
+func (server *FakeServer) getHeaders(req *fakeRequest) (interface{}, error) {
+	user, account, folder, message, err := server.lookupMessage(req)
+	if err != nil {
+		return nil, err
+	}
+	return ciolite.GetUserEmailAccountsFolderMessageHeadersResponse{
+		ResourceURL: server.messageResponse(user.user.ID, account, folder, message, nil).ResourceURL + "/headers",
+		Headers:     message.parsed.Header,
+	}, nil
+}
+
+// getRaw implements GET .../messages/:message_id/raw
+func (server *FakeServer) getRaw(req *fakeRequest) (interface{}, error) {
+	_, _, _, message, err := server.lookupMessage(req)
+	if err != nil {
+		return nil, err
+	}
+	return fakeContent{contentType: "message/rfc822", content: []byte(message.raw)}, nil
+}
+
+// markRead implements POST .../messages/:message_id/read
+func (server *FakeServer) markRead(req *fakeRequest) (interface{}, error) {
+	_, _, _, message, err := server.lookupMessage(req)
+	if err != nil {
+		return nil, err
+	}
+	message.flags.Read = true
+	return ciolite.UserEmailAccountsFolderMessageReadResponse{Success: true}, nil
+}
+
+// markUnRead implements DELETE .../messages/:message_id/read
+func (server *FakeServer) markUnRead(req *fakeRequest) (interface{}, error) {
+	_, _, _, message, err := server.lookupMessage(req)
+	if err != nil {
+		return nil, err
+	}
+	message.flags.Read = false
+	return ciolite.UserEmailAccountsFolderMessageReadResponse{Success: true}, nil
+}
+
+// Webhooks
+
+// webhookOwner returns the user ID of the webhooks of the request (from its first param, if any),
+// or an empty string for the app's webhooks
+func (server *FakeServer) webhookOwner(params []string) (string, error) {
+	if len(params) == 0 {
+		return "", nil
+	}
+	user, err := server.lookupUser(params[0])
+	if err != nil {
+		return "", err
+	}
+	return user.user.ID, nil
+}
+
+// lookupWebhook returns the webhook of the request, identified by its last param
+func (server *FakeServer) lookupWebhook(req *fakeRequest) (*fakeWebhook, error) {
+	userID, err := server.webhookOwner(req.params[:len(req.params)-1])
+	if err != nil {
+		return nil, err
+	}
+	webhookID := req.params[len(req.params)-1]
+	for _, webhook := range server.webhooks {
+		if webhook.userID == userID && webhook.webhook.WebhookID == webhookID {
+			return webhook, nil
+		}
+	}
+	return nil, notFound("Webhook %s not found", webhookID)
+}
+
+// webhookResponse returns the webhook as returned by CIO
+func (server *FakeServer) webhookResponse(webhook *fakeWebhook) ciolite.GetUsersWebhooksResponse {
+	response := webhook.webhook
+	if len(webhook.userID) > 0 {
+		response.ResourceURL = server.resourceURL("/lite/users/%s/webhooks/%s", webhook.userID, webhook.webhook.WebhookID)
+	} else {
+		response.ResourceURL = server.resourceURL("/lite/webhooks/%s", webhook.webhook.WebhookID)
+	}
+	return response
+}
+
+// getWebhooks implements GET /lite/webhooks and GET /lite/users/:id/webhooks
+func (server *FakeServer) getWebhooks(req *fakeRequest) (interface{}, error) {
+	userID, err := server.webhookOwner(req.params)
+	if err != nil {
+		return nil, err
+	}
+	webhooks := []ciolite.GetUsersWebhooksResponse{}
+	for _, webhook := range server.webhooks {
+		if webhook.userID == userID {
+			webhooks = append(webhooks, server.webhookResponse(webhook))
+		}
+	}
+	return webhooks, nil
+}
+
+// getWebhook implements GET /lite/webhooks/:webhook_id and GET /lite/users/:id/webhooks/:webhook_id
+func (server *FakeServer) getWebhook(req *fakeRequest) (interface{}, error) {
+	webhook, err := server.lookupWebhook(req)
+	if err != nil {
+		return nil, err
+	}
+	return server.webhookResponse(webhook), nil
+}
+
+// createWebhook implements POST /lite/webhooks and POST /lite/users/:id/webhooks
+func (server *FakeServer) createWebhook(req *fakeRequest) (interface{}, error) {
+	userID, err := server.webhookOwner(req.params)
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range []string{"callback_url", "failure_notif_url"} {
+		if _, err = required(req.values, key); err != nil {
+			return nil, err
+		}
+	}
+	webhook := &fakeWebhook{userID: userID, webhook: ciolite.GetUsersWebhooksResponse{
+		CallbackURL:        req.values.Get("callback_url"),
+		FailureNotifURL:    req.values.Get("failure_notif_url"),
+		WebhookID:          server.newID(),
+		FilterTo:           req.values.Get("filter_to"),
+		FilterFrom:         req.values.Get("filter_from"),
+		FilterCc:           req.values.Get("filter_cc"),
+		FilterSubject:      req.values.Get("filter_subject"),
+		FilterThread:       req.values.Get("filter_thread"),
+		FilterNewImportant: req.values.Get("filter_new_important"),
+		FilterFileName:     req.values.Get("filter_file_name"),
+		FilterFolderAdded:  req.values.Get("filter_folder_added"),
+		FilterToDomain:     req.values.Get("filter_to_domain"),
+		FilterFromDomain:   req.values.Get("filter_from_domain"),
+		BodyType:           req.values.Get("body_type"),
+		Active:             true,
+		IncludeBody:        req.values.Get("include_body") == "1",
+		IncludeHeader:      req.values.Get("include_header") == "1",
+		ReceiveDrafts:      req.values.Get("receive_drafts") == "1",
+		ReceiveAllChanges:  req.values.Get("receive_all_changes") == "1",
+		ReceiveHistorical:  req.values.Get("receive_historical") == "1",
+	}}
+	server.webhooks = append(server.webhooks, webhook)
+	return ciolite.CreateUserWebhookResponse{
+		WebhookID:   webhook.webhook.WebhookID,
+		ResourceURL: server.webhookResponse(webhook).ResourceURL,
+		Success:     true,
+	}, nil
+}
+
+// modifyWebhook implements POST /lite/webhooks/:webhook_id and POST /lite/users/:id/webhooks/:webhook_id
+func (server *FakeServer) modifyWebhook(req *fakeRequest) (interface{}, error) {
+	webhook, err := server.lookupWebhook(req)
+	if err != nil {
+		return nil, err
+	}
+	active, err := required(req.values, "active")
+	if err != nil {
+		return nil, err
+	}
+	webhook.webhook.Active = active == "1"
+	if webhook.webhook.Active {
+		webhook.webhook.Failure = false
+	}
+	return ciolite.ModifyWebhookResponse{ResourceURL: server.webhookResponse(webhook).ResourceURL, Success: true}, nil
+}
+
+// deleteWebhook implements DELETE /lite/webhooks/:webhook_id and DELETE /lite/users/:id/webhooks/:webhook_id
+func (server *FakeServer) deleteWebhook(req *fakeRequest) (interface{}, error) {
+	webhook, err := server.lookupWebhook(req)
+	if err != nil {
+		return nil, err
+	}
+	for i, w := range server.webhooks {
+		if w == webhook {
+			server.webhooks = append(server.webhooks[:i], server.webhooks[i+1:]...)
+			break
+		}
+	}
+	return ciolite.DeleteWebhookResponse{Success: true}, nil
+}
+
+// Connect tokens
+
+// connectTokenOwner returns the user ID and account label of the connect tokens of the request
+// (from its params, if any), or empty strings for the app's connect tokens
+func (server *FakeServer) connectTokenOwner(params []string) (string, string, error) {
+	switch len(params) {
+	case 0:
+		return "", "", nil
+	case 1:
+		user, err := server.lookupUser(params[0])
+		if err != nil {
+			return "", "", err
+		}
+		return user.user.ID, "", nil
+	default:
+		user, account, err := server.lookupAccount(params[0], params[1])
+		if err != nil {
+			return "", "", err
+		}
+		return user.user.ID, account.account.Label, nil
+	}
+}
+
+// lookupRequestConnectToken returns the connect token of the request, identified by its last param
+func (server *FakeServer) lookupRequestConnectToken(req *fakeRequest) (*fakeConnectToken, error) {
+	userID, label, err := server.connectTokenOwner(req.params[:len(req.params)-1])
+	if err != nil {
+		return nil, err
+	}
+	token := req.params[len(req.params)-1]
+	connectToken := server.lookupConnectToken(token)
+	if connectToken == nil || connectToken.userID != userID || connectToken.label != label {
+		return nil, notFound("Connect token %s not found", token)
+	}
+	return connectToken, nil
+}
+
+// connectTokenResponse returns the connect token as returned by CIO, including its user (if any)
+func (server *FakeServer) connectTokenResponse(connectToken *fakeConnectToken) ciolite.GetConnectTokenResponse {
+	response := connectToken.token
+	switch {
+	case len(connectToken.label) > 0:
+		response.ResourceURL = server.resourceURL("/lite/users/%s/email_accounts/%s/connect_tokens/%s", connectToken.userID, connectToken.label, response.Token)
+	case len(connectToken.userID) > 0:
+		response.ResourceURL = server.resourceURL("/lite/users/%s/connect_tokens/%s", connectToken.userID, response.Token)
+	default:
+		response.ResourceURL = server.resourceURL("/lite/connect_tokens/%s", response.Token)
+	}
+	if user, err := server.lookupUser(connectToken.connectedUserID); err == nil {
+		userResponse := server.userResponse(user)
+		response.User = ciolite.GetConnectTokenUserResponse{
+			ID:             userResponse.ID,
+			EmailAddresses: userResponse.EmailAddresses,
+			FirstName:      userResponse.FirstName,
+			LastName:       userResponse.LastName,
+			Created:        userResponse.Created,
+			EmailAccounts:  userResponse.EmailAccounts,
+		}
+	}
+	return response
+}
+
+// getConnectTokens implements GET .../connect_tokens, for the app, a user, or an email account
+func (server *FakeServer) getConnectTokens(req *fakeRequest) (interface{}, error) {
+	userID, label, err := server.connectTokenOwner(req.params)
+	if err != nil {
+		return nil, err
+	}
+	connectTokens := []ciolite.GetConnectTokenResponse{}
+	for _, connectToken := range server.connectTokens {
+		if connectToken.userID == userID && connectToken.label == label {
+			connectTokens = append(connectTokens, server.connectTokenResponse(connectToken))
+		}
+	}
+	return connectTokens, nil
+}
+
+// getConnectToken implements GET .../connect_tokens/:token, for the app, a user, or an email account
+func (server *FakeServer) getConnectToken(req *fakeRequest) (interface{}, error) {
+	connectToken, err := server.lookupRequestConnectToken(req)
+	if err != nil {
+		return nil, err
+	}
+	return server.connectTokenResponse(connectToken), nil
+}
+
+// createConnectToken implements POST .../connect_tokens, for the app, a user, or an email account.
+// The connect token expires in 24 hours, unless it is used with CompleteConnectToken.
+func (server *FakeServer) createConnectToken(req *fakeRequest) (interface{}, error) {
+	userID, label, err := server.connectTokenOwner(req.params)
+	if err != nil {
+		return nil, err
+	}
+	callbackURL, err := required(req.values, "callback_url")
+	if err != nil {
+		return nil, err
+	}
+	now := unixNow()
+	expires := now + int((24 * time.Hour).Seconds())
+	connectToken := &fakeConnectToken{userID: userID, label: label, connectedUserID: userID, token: ciolite.GetConnectTokenResponse{
+		Token:             server.newID(),
+		Email:             req.values.Get("email"),
+		CallbackURL:       callbackURL,
+		StatusCallbackURL: req.values.Get("status_callback_url"),
+		FirstName:         req.values.Get("first_name"),
+		LastName:          req.values.Get("last_name"),
+		ServerLabel:       label,
+		AccountLite:       true,
+		Created:           now,
+		Expires:           ciolite.ExpiresMixed{Expires: &expires},
+	}}
+	connectToken.token.BrowserRedirectURL = server.URL() + "/connect/" + connectToken.token.Token
+	server.connectTokens = append(server.connectTokens, connectToken)
+
+	response := server.connectTokenResponse(connectToken)
+	return ciolite.CreateConnectTokenResponse{
+		Success:            true,
+		Token:              response.Token,
+		ResourceURL:        response.ResourceURL,
+		BrowserRedirectURL: response.BrowserRedirectURL,
+	}, nil
+}
+
+// deleteConnectToken implements DELETE .../connect_tokens/:token, for the app, a user, or an email account
+func (server *FakeServer) deleteConnectToken(req *fakeRequest) (interface{}, error) {
+	connectToken, err := server.lookupRequestConnectToken(req)
+	if err != nil {
+		return nil, err
+	}
+	for i, ct := range server.connectTokens {
+		if ct == connectToken {
+			server.connectTokens = append(server.connectTokens[:i], server.connectTokens[i+1:]...)
+			break
+		}
+	}
+	return ciolite.DeleteConnectTokenResponse{Success: true}, nil
+}
+
+// deleteConnectTokens deletes the connect tokens of the user (and only of the email account, if the label is not empty)
+func (server *FakeServer) deleteConnectTokens(userID string, label string) {
+	var connectTokens []*fakeConnectToken
+	for _, connectToken := range server.connectTokens {
+		if connectToken.userID != userID || (len(label) > 0 && connectToken.label != label) {
+			connectTokens = append(connectTokens, connectToken)
+		}
+	}
+	server.connectTokens = connectTokens
+}
+
+// OAuth providers
+
+// lookupOAuthProvider returns the index of the OAuth provider with the consumer key, or -1
+func (server *FakeServer) lookupOAuthProvider(key string) int {
+	for i, provider := range server.oauthProviders {
+		if provider.ProviderConsumerKey == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// getOAuthProviders implements GET /lite/oauth_providers
+func (server *FakeServer) getOAuthProviders(req *fakeRequest) (interface{}, error) {
+	return append([]ciolite.GetOAuthProvidersResponse{}, server.oauthProviders...), nil
+}
+
+// getOAuthProvider implements GET /lite/oauth_providers/:key
+func (server *FakeServer) getOAuthProvider(req *fakeRequest) (interface{}, error) {
+	i := server.lookupOAuthProvider(req.params[0])
+	if i < 0 {
+		return nil, notFound("OAuth provider %s not found", req.params[0])
+	}
+	return server.oauthProviders[i], nil
+}
+
+// createOAuthProvider implements POST /lite/oauth_providers, replacing any provider with the same consumer key
+func (server *FakeServer) createOAuthProvider(req *fakeRequest) (interface{}, error) {
+	providerType := ciolite.OAuthProviderType(req.values.Get("type"))
+	if !providerType.Valid() {
+		return nil, badRequest("Invalid type %s", providerType)
+	}
+	for _, key := range []string{"provider_consumer_key", "provider_consumer_secret"} {
+		if _, err := required(req.values, key); err != nil {
+			return nil, err
+		}
+	}
+	provider := ciolite.GetOAuthProvidersResponse{
+		Type:                   string(providerType),
+		ProviderConsumerKey:    req.values.Get("provider_consumer_key"),
+		ProviderConsumerSecret: req.values.Get("provider_consumer_secret"),
+		ResourceURL:            server.resourceURL("/lite/oauth_providers/%s", req.values.Get("provider_consumer_key")),
+	}
+	if i := server.lookupOAuthProvider(provider.ProviderConsumerKey); i >= 0 {
+		server.oauthProviders[i] = provider
+	} else {
+		server.oauthProviders = append(server.oauthProviders, provider)
+	}
+	return ciolite.CreateOAuthProviderResponse{
+		Success:             true,
+		ProviderConsumerKey: provider.ProviderConsumerKey,
+		ResourceURL:         provider.ResourceURL,
+	}, nil
+}
+
+// deleteOAuthProvider implements DELETE /lite/oauth_providers/:key
+func (server *FakeServer) deleteOAuthProvider(req *fakeRequest) (interface{}, error) {
+	i := server.lookupOAuthProvider(req.params[0])
+	if i < 0 {
+		return nil, notFound("OAuth provider %s not found", req.params[0])
+	}
+	server.oauthProviders = append(server.oauthProviders[:i], server.oauthProviders[i+1:]...)
+	return ciolite.DeleteOAuthProviderResponse{Success: true}, nil
+}
+
+// Discovery
+
+// getDiscovery implements GET /lite/discovery, using the settings of the email address's domain (see SetDiscovery)
+func (server *FakeServer) getDiscovery(req *fakeRequest) (interface{}, error) {
+	if sourceType := ciolite.SourceType(req.values.Get("source_type")); !sourceType.Valid() {
+		return nil, badRequest("Invalid source_type %s", sourceType)
+	}
+	email, err := required(req.values, "email")
+	if err != nil {
+		return nil, err
+	}
+	i := strings.LastIndex(email, "@")
+	if i < 0 {
+		return nil, badRequest("Invalid email %s", email)
+	}
+
+	imap, found := server.discovery[strings.ToLower(email[i+1:])]
+	if !found {
+		return ciolite.GetDiscoveryResponse{Email: email}, nil
+	}
+	imap.Username = email
+	return ciolite.GetDiscoveryResponse{Email: email, Type: "imap", Found: true, IMAP: imap}, nil
+}
+
+// Status callback url
+
+// getStatusCallbackURL implements GET /app/status_callback_url
+func (server *FakeServer) getStatusCallbackURL(req *fakeRequest) (interface{}, error) {
+	return ciolite.GetStatusCallbackURLResponse{
+		StatusCallbackURL: server.statusCallbackURL,
+		ResourceURL:       server.URL() + "/app/status_callback_url",
+	}, nil
+}
+
+// createStatusCallbackURL implements POST /app/status_callback_url
+func (server *FakeServer) createStatusCallbackURL(req *fakeRequest) (interface{}, error) {
+	statusCallbackURL, err := required(req.values, "status_callback_url")
+	if err != nil {
+		return nil, err
+	}
+	server.statusCallbackURL = statusCallbackURL
+	return ciolite.CreateDeleteStatusCallbackURLResponse{Success: true}, nil
+}
+
+// deleteStatusCallbackURL implements DELETE /app/status_callback_url
+func (server *FakeServer) deleteStatusCallbackURL(req *fakeRequest) (interface{}, error) {
+	if len(server.statusCallbackURL) == 0 {
+		return nil, fakeError{http.StatusNotFound, "Status callback url not set"}
+	}
+	server.statusCallbackURL = ""
+	return ciolite.CreateDeleteStatusCallbackURLResponse{Success: true}, nil
+}
+
+// containsFold returns true if the strings contain the string, ignoring case
+func containsFold(strs []string, str string) bool {
+	for _, s := range strs {
+		if strings.EqualFold(s, str) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,274 @@
+package ciolitetest
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/contextio/contextio-go/ciolite"
+)
+
+const fakeServerTestMessage = "From: Alice <alice@example.com>\r\n" +
+	"To: bob@example.com\r\n" +
+	"Subject: Lunch\r\n" +
+	"Message-ID: <lunch@example.com>\r\n" +
+	"Date: Mon, 02 Jan 2006 15:04:05 -0700\r\n" +
+	"MIME-Version: 1.0\r\n" +
+	"Content-Type: multipart/mixed; boundary=b1\r\n" +
+	"\r\n" +
+	"--b1\r\n" +
+	"Content-Type: text/plain; charset=utf-8\r\n" +
+	"\r\n" +
+	"Noon?\r\n" +
+	"--b1\r\n" +
+	"Content-Type: text/plain\r\n" +
+	"Content-Disposition: attachment; filename=menu.txt\r\n" +
+	"\r\n" +
+	"Soup\r\n" +
+	"--b1--\r\n"
+
+// newFakeServerTestAccount creates a user with an email account on the FakeServer, and returns its ID and label
+func newFakeServerTestAccount(t *testing.T, cioLite ciolite.CioLite) (string, string) {
+	user, err := cioLite.CreateUser(ciolite.CreateUserParams{
+		Email:    "bob@example.com",
+		Server:   "imap.example.com",
+		Username: "bob",
+		UseSSL:   true,
+		Port:     993,
+		Type:     string(ciolite.SourceTypeIMAP),
+		Password: "hunter2",
+	})
+	if err != nil {
+		t.Fatal("Unable to create user: ", err)
+	}
+	if !user.Success || len(user.ID) == 0 || user.EmailAccount.Status != string(ciolite.AccountStatusOK) {
+		t.Fatal("Expected a new user with an OK email account; Got: ", user)
+	}
+	return user.ID, user.EmailAccount.Label
+}
+
+func TestFakeServerMessages(t *testing.T) {
+	t.Parallel()
+
+	server := NewFakeServer("key", "secret")
+	defer server.Close()
+	cioLite := server.CioLite()
+	userID, label := newFakeServerTestAccount(t, cioLite)
+
+	folders, err := cioLite.GetUserEmailAccountsFolders(userID, label, ciolite.GetUserEmailAccountsFoldersParams{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(folders) != len(DefaultFakeFolders) || folders[0].Name != "INBOX" {
+		t.Error("Expected: ", DefaultFakeFolders, "; Got: ", folders)
+	}
+
+	added, err := server.AddMessage(userID, label, "INBOX", fakeServerTestMessage)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if added.Subject != "Lunch" || len(added.Attachments) != 1 || added.Flags.Read {
+		t.Error("Expected an unread message with 1 attachment; Got: ", added)
+	}
+
+	inbox, err := cioLite.GetUserEmailAccountFolder(userID, label, "INBOX", ciolite.EmailAccountFolderDelimiterParam{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if inbox.NbMessages != 1 || inbox.NbUnseenMessages != 1 {
+		t.Error("Expected: 1 unseen message; Got: ", inbox)
+	}
+
+	// Found by its Message-ID as well as its EmailMessageID
+	message, err := cioLite.GetUserEmailAccountFolderMessage(userID, label, "INBOX", "<lunch@example.com>", ciolite.GetUserEmailAccountsFolderMessageParams{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if message.EmailMessageID != added.EmailMessageID {
+		t.Error("Expected: ", added.EmailMessageID, "; Got: ", message.EmailMessageID)
+	}
+
+	content, err := cioLite.GetUserEmailAccountsFolderMessageAttachmentContent(userID, label, "INBOX", added.EmailMessageID, "1", ciolite.EmailAccountFolderDelimiterParam{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ := ioutil.ReadAll(content)
+	_ = content.Close()
+	if string(data) != "Soup" || content.FileName != "menu.txt" {
+		t.Error("Expected: menu.txt Soup; Got: ", content.FileName, " ", string(data))
+	}
+
+	if _, err = cioLite.MarkUserEmailAccountsFolderMessageRead(userID, label, "INBOX", added.EmailMessageID, ciolite.EmailAccountFolderDelimiterParam{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = cioLite.SetUserEmailAccountsFolderMessageFlags(userID, label, "INBOX", added.EmailMessageID, ciolite.SetUserEmailAccountsFolderMessageFlagsParams{Flagged: ciolite.FlagSet}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = cioLite.MoveUserEmailAccountFolderMessage(userID, label, "INBOX", added.EmailMessageID, ciolite.MoveUserEmailAccountFolderMessageParams{NewFolderID: "Trash"}); err != nil {
+		t.Fatal(err)
+	}
+
+	inboxMessages, err := cioLite.GetUserEmailAccountsFolderMessages(userID, label, "INBOX", ciolite.GetUserEmailAccountsFolderMessageParams{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(inboxMessages) != 0 {
+		t.Error("Expected: 0 messages; Got: ", inboxMessages)
+	}
+	trashMessages, err := cioLite.GetUserEmailAccountsFolderMessages(userID, label, "Trash", ciolite.GetUserEmailAccountsFolderMessageParams{IncludeFlags: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(trashMessages) != 1 || !trashMessages[0].Flags.Read || !trashMessages[0].Flags.Flagged || trashMessages[0].Folders[0] != "Trash" {
+		t.Error("Expected: 1 read and flagged message; Got: ", trashMessages)
+	}
+
+	_, err = cioLite.MoveUserEmailAccountFolderMessage(userID, label, "Trash", added.EmailMessageID, ciolite.MoveUserEmailAccountFolderMessageParams{NewFolderID: "Missing"})
+	if requestErr, ok := err.(ciolite.RequestError); !ok || requestErr.StatusCode != http.StatusNotFound {
+		t.Error("Expected: 404; Got: ", err)
+	}
+}
+
+func TestFakeServerAuthentication(t *testing.T) {
+	t.Parallel()
+
+	server := NewFakeServer("key", "secret")
+	defer server.Close()
+
+	cioLite := ciolite.NewCioLite("key", "wrong")
+	cioLite.Host = server.URL()
+	_, err := cioLite.GetUsers(ciolite.GetUsersParams{})
+	if requestErr, ok := err.(ciolite.RequestError); !ok || requestErr.StatusCode != http.StatusUnauthorized {
+		t.Error("Expected: 401; Got: ", err)
+	}
+
+	if _, err = server.CioLite().GetUsers(ciolite.GetUsersParams{}); err != nil {
+		t.Error("Expected no error; Got: ", err)
+	}
+}
+
+func TestFakeServerFaults(t *testing.T) {
+	t.Parallel()
+
+	server := NewFakeServer("key", "secret")
+	defer server.Close()
+	server.InjectFault(Fault{Method: "GET", Path: "/lite/users", StatusCode: http.StatusServiceUnavailable, Times: 2})
+
+	cioLite := server.CioLite()
+	cioLite.RetryPolicy = &ciolite.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}
+	if _, err := cioLite.GetUsers(ciolite.GetUsersParams{}); err != nil {
+		t.Error("Expected the retries to succeed; Got: ", err)
+	}
+
+	var statusCodes []int
+	for _, request := range server.Requests() {
+		statusCodes = append(statusCodes, request.StatusCode)
+	}
+	if len(statusCodes) != 3 || statusCodes[0] != http.StatusServiceUnavailable || statusCodes[2] != http.StatusOK {
+		t.Error("Expected: [503 503 200]; Got: ", statusCodes)
+	}
+}
+
+func TestFakeServerConnectToken(t *testing.T) {
+	t.Parallel()
+
+	server := NewFakeServer("key", "secret")
+	defer server.Close()
+	cioLite := server.CioLite()
+
+	created, err := cioLite.CreateConnectToken(ciolite.CreateConnectTokenParams{CallbackURL: "https://example.com/callback", Email: "bob@example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(created.BrowserRedirectURL, server.URL()) {
+		t.Error("Expected a browser redirect url on the FakeServer; Got: ", created.BrowserRedirectURL)
+	}
+
+	token, err := cioLite.GetConnectToken(created.Token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = cioLite.CheckConnectToken(token, "bob@example.com"); err == nil {
+		t.Error("Expected an error for an unused connect token")
+	}
+
+	err = server.CompleteConnectToken(created.Token, ciolite.CreateUserParams{Server: "imap.example.com", Username: "bob", Password: "hunter2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token, err = cioLite.GetConnectToken(created.Token); err != nil {
+		t.Fatal(err)
+	}
+	if err = cioLite.CheckConnectToken(token, "bob@example.com"); err != nil {
+		t.Error("Expected no error; Got: ", err)
+	}
+
+	users, err := cioLite.GetUsers(ciolite.GetUsersParams{Email: "bob@example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(users) != 1 || users[0].ID != token.User.ID {
+		t.Error("Expected: ", token.User.ID, "; Got: ", users)
+	}
+}
+
+func TestFakeServerWebhooks(t *testing.T) {
+	t.Parallel()
+
+	server := NewFakeServer("key", "secret")
+	defer server.Close()
+	cioLite := server.CioLite()
+
+	webhook, err := cioLite.CreateWebhook(ciolite.CreateUserWebhookParams{
+		CallbackURL:     "https://example.com/callback",
+		FailureNotifURL: "https://example.com/failure",
+		FilterSubject:   "Lunch",
+	})
+	if err != nil || !webhook.Success || len(webhook.WebhookID) == 0 {
+		t.Fatal("Expected a new webhook; Got: ", webhook, "; With Error: ", err)
+	}
+
+	got, err := cioLite.GetWebhook(webhook.WebhookID)
+	if err != nil || got.CallbackURL != "https://example.com/callback" || got.FilterSubject != "Lunch" || !got.Active {
+		t.Error("Expected the active webhook; Got: ", got, "; With Error: ", err)
+	}
+
+	if webhooks, err := cioLite.GetWebhooks(); err != nil || len(webhooks) != 1 || webhooks[0].WebhookID != webhook.WebhookID {
+		t.Error("Expected: ", webhook.WebhookID, "; Got: ", webhooks, "; With Error: ", err)
+	}
+
+	if modified, err := cioLite.ModifyWebhook(webhook.WebhookID, ciolite.ModifyUserWebhookParams{Active: false}); err != nil || !modified.Success {
+		t.Error("Expected the webhook to be modified; Got: ", modified, "; With Error: ", err)
+	}
+	if got, err = cioLite.GetWebhook(webhook.WebhookID); err != nil || got.Active {
+		t.Error("Expected an inactive webhook; Got: ", got, "; With Error: ", err)
+	}
+
+	if deleted, err := cioLite.DeleteWebhookAccount(webhook.WebhookID); err != nil || !deleted.Success {
+		t.Error("Expected the webhook to be deleted; Got: ", deleted, "; With Error: ", err)
+	}
+	if webhooks, err := cioLite.GetWebhooks(); err != nil || len(webhooks) != 0 {
+		t.Error("Expected no webhooks; Got: ", webhooks, "; With Error: ", err)
+	}
+}
+
+func TestFakeServerDiscovery(t *testing.T) {
+	t.Parallel()
+
+	server := NewFakeServer("key", "secret")
+	defer server.Close()
+	cioLite := server.CioLite()
+
+	server.SetDiscovery("example.com", ciolite.GetDiscoveryIMAPResponse{Server: "imap.example.com", UseSSL: true, Port: 993})
+
+	discovery, err := cioLite.GetDiscovery(ciolite.GetDiscoveryParams{Email: "bob@Example.com", SourceType: string(ciolite.SourceTypeIMAP)})
+	if err != nil || !discovery.Found || discovery.IMAP.Server != "imap.example.com" || discovery.IMAP.Username != "bob@Example.com" {
+		t.Error("Expected the domain's settings; Got: ", discovery, "; With Error: ", err)
+	}
+
+	if discovery, err = cioLite.GetDiscovery(ciolite.GetDiscoveryParams{Email: "bob@unknown.example", SourceType: string(ciolite.SourceTypeIMAP)}); err != nil || discovery.Found {
+		t.Error("Expected nothing found; Got: ", discovery, "; With Error: ", err)
+	}
+}
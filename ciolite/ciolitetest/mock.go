@@ -0,0 +1,990 @@
+// Package ciolitetest provides test doubles for code that uses the ciolite package,
+// so that it can be unit tested without making requests to CIO.
+package ciolitetest
+
+import (
+	"context"
+	"sync"
+
+	"github.com/contextio/contextio-go/ciolite"
+	"github.com/pkg/errors"
+)
+
+// ErrNotConfigured is returned (wrapped with the method name) by the Mock methods whose Func is nil
+var ErrNotConfigured = errors.New("ciolitetest: Mock method is not configured")
+
+// Call is a method call received by a Mock
+type Call struct {
+	// Method is the name of the method, without the Context suffix (ex: GetUsers)
+	Method string
+
+	// Args are the arguments of the call, without the context.Context
+	Args []interface{}
+}
+
+// Mock is a ciolite.Client whose methods call the matching Func field (ex: GetUsersFunc for
+// both GetUsers and GetUsersContext), and record each call. If the Func is nil, the method
+// returns the zero value and ErrNotConfigured. A Mock is safe for concurrent use,
+// once its Funcs have been set.
+type Mock struct {
+	mu    sync.Mutex
+	calls []Call
+
+	// Users
+	GetUsersFunc   func(ctx context.Context, queryValues ciolite.GetUsersParams) ([]ciolite.GetUsersResponse, error)
+	GetUserFunc    func(ctx context.Context, userID string) (ciolite.GetUsersResponse, error)
+	CreateUserFunc func(ctx context.Context, formValues ciolite.CreateUserParams) (ciolite.CreateUserResponse, error)
+	ModifyUserFunc func(ctx context.Context, userID string, formValues ciolite.ModifyUserParams) (ciolite.ModifyUserResponse, error)
+	DeleteUserFunc func(ctx context.Context, userID string) (ciolite.DeleteUserResponse, error)
+
+	// Email accounts
+	GetUserEmailAccountsFunc   func(ctx context.Context, userID string, queryValues ciolite.GetUserEmailAccountsParams) ([]ciolite.GetUsersEmailAccountsResponse, error)
+	GetUserEmailAccountFunc    func(ctx context.Context, userID string, label string) (ciolite.GetUsersEmailAccountsResponse, error)
+	CreateUserEmailAccountFunc func(ctx context.Context, userID string, formValues ciolite.CreateUserParams) (ciolite.CreateEmailAccountResponse, error)
+	ModifyUserEmailAccountFunc func(ctx context.Context, userID string, label string, formValues ciolite.ModifyUserEmailAccountParams) (ciolite.ModifyEmailAccountResponse, error)
+	DeleteUserEmailAccountFunc func(ctx context.Context, userID string, label string) (ciolite.DeleteEmailAccountResponse, error)
+
+	// Folders
+	GetUserEmailAccountsFoldersFunc  func(ctx context.Context, userID string, label string, queryValues ciolite.GetUserEmailAccountsFoldersParams) ([]ciolite.GetUsersEmailAccountFoldersResponse, error)
+	GetUserEmailAccountFolderFunc    func(ctx context.Context, userID string, label string, folder string, queryValues ciolite.EmailAccountFolderDelimiterParam) (ciolite.GetUsersEmailAccountFoldersResponse, error)
+	CreateUserEmailAccountFolderFunc func(ctx context.Context, userID string, label string, folder string, formValues ciolite.EmailAccountFolderDelimiterParam) (ciolite.CreateEmailAccountFolderResponse, error)
+	DeleteUserEmailAccountFolderFunc func(ctx context.Context, userID string, label string, folder string, formValues ciolite.EmailAccountFolderDelimiterParam) (ciolite.DeleteEmailAccountFolderResponse, error)
+	RenameUserEmailAccountFolderFunc func(ctx context.Context, userID string, label string, folder string, formValues ciolite.RenameUserEmailAccountFolderParams) (ciolite.RenameEmailAccountFolderResponse, error)
+
+	// Messages
+	GetUserEmailAccountsFolderMessagesFunc                 func(ctx context.Context, userID string, label string, folder string, queryValues ciolite.GetUserEmailAccountsFolderMessageParams) ([]ciolite.GetUsersEmailAccountFolderMessagesResponse, error)
+	GetUserEmailAccountFolderMessageFunc                   func(ctx context.Context, userID string, label string, folder string, messageID string, queryValues ciolite.GetUserEmailAccountsFolderMessageParams) (ciolite.GetUsersEmailAccountFolderMessagesResponse, error)
+	MoveUserEmailAccountFolderMessageFunc                  func(ctx context.Context, userID string, label string, folder string, messageID string, queryValues ciolite.MoveUserEmailAccountFolderMessageParams) (ciolite.MoveUserEmailAccountFolderMessageResponse, error)
+	MoveUserEmailAccountFolderMessage2Func                 func(ctx context.Context, userID string, label string, folder string, messageID string, queryValues ciolite.MoveUserEmailAccountFolderMessageParams) (ciolite.MoveUserEmailAccountFolderMessageResponse, error)
+	GetUserEmailAccountsFolderMessageAttachmentsFunc       func(ctx context.Context, userID string, label string, folder string, messageID string, queryValues ciolite.EmailAccountFolderDelimiterParam) ([]ciolite.GetUserEmailAccountsFolderMessageAttachmentsResponse, error)
+	GetUserEmailAccountsFolderMessageAttachmentFunc        func(ctx context.Context, userID string, label string, folder string, messageID string, attachmentID string, queryValues ciolite.EmailAccountFolderDelimiterParam) (ciolite.GetUserEmailAccountsFolderMessageAttachmentsResponse, error)
+	GetUserEmailAccountsFolderMessageAttachmentContentFunc func(ctx context.Context, userID string, label string, folder string, messageID string, attachmentID string, queryValues ciolite.EmailAccountFolderDelimiterParam) (*ciolite.AttachmentContent, error)
+	GetUserEmailAccountsFolderMessageBodyFunc              func(ctx context.Context, userID string, label string, folder string, messageID string, queryValues ciolite.GetUserEmailAccountsFolderMessageBodyParams) ([]ciolite.GetUserEmailAccountsFolderMessageBodyResponse, error)
+	GetUserEmailAccountsFolderMessageFlagsFunc             func(ctx context.Context, userID string, label string, folder string, messageID string, queryValues ciolite.EmailAccountFolderDelimiterParam) (ciolite.GetUserEmailAccountsFolderMessageFlagsResponse, error)
+	SetUserEmailAccountsFolderMessageFlagsFunc             func(ctx context.Context, userID string, label string, folder string, messageID string, formValues ciolite.SetUserEmailAccountsFolderMessageFlagsParams) (ciolite.SetUserEmailAccountsFolderMessageFlagsResponse, error)
+	GetUserEmailAccountsFolderMessageHeadersFunc           func(ctx context.Context, userID string, label string, folder string, messageID string, queryValues ciolite.GetUserEmailAccountsFolderMessageHeadersParams) (ciolite.GetUserEmailAccountsFolderMessageHeadersResponse, error)
+	GetUserEmailAccountsFolderMessageRawFunc               func(ctx context.Context, userID string, label string, folder string, messageID string, queryValues ciolite.EmailAccountFolderDelimiterParam) (ciolite.GetUserEmailAccountsFolderMessageRawResponse, error)
+	StreamUserEmailAccountsFolderMessageRawFunc            func(ctx context.Context, userID string, label string, folder string, messageID string, queryValues ciolite.EmailAccountFolderDelimiterParam) (*ciolite.RawMessageStream, error)
+	MarkUserEmailAccountsFolderMessageReadFunc             func(ctx context.Context, userID string, label string, folder string, messageID string, formValues ciolite.EmailAccountFolderDelimiterParam) (ciolite.UserEmailAccountsFolderMessageReadResponse, error)
+	MarkUserEmailAccountsFolderMessageUnReadFunc           func(ctx context.Context, userID string, label string, folder string, messageID string, formValues ciolite.EmailAccountFolderDelimiterParam) (ciolite.UserEmailAccountsFolderMessageReadResponse, error)
+
+	// Webhooks
+	GetWebhooksFunc              func(ctx context.Context) ([]ciolite.GetUsersWebhooksResponse, error)
+	GetWebhookFunc               func(ctx context.Context, webhookID string) (ciolite.GetUsersWebhooksResponse, error)
+	CreateWebhookFunc            func(ctx context.Context, formValues ciolite.CreateUserWebhookParams) (ciolite.CreateUserWebhookResponse, error)
+	ModifyWebhookFunc            func(ctx context.Context, webhookID string, formValues ciolite.ModifyUserWebhookParams) (ciolite.ModifyWebhookResponse, error)
+	DeleteWebhookAccountFunc     func(ctx context.Context, webhookID string) (ciolite.DeleteWebhookResponse, error)
+	GetUserWebhooksFunc          func(ctx context.Context, userID string) ([]ciolite.GetUsersWebhooksResponse, error)
+	GetUserWebhookFunc           func(ctx context.Context, userID string, webhookID string) (ciolite.GetUsersWebhooksResponse, error)
+	CreateUserWebhookFunc        func(ctx context.Context, userID string, formValues ciolite.CreateUserWebhookParams) (ciolite.CreateUserWebhookResponse, error)
+	ModifyUserWebhookFunc        func(ctx context.Context, userID string, webhookID string, formValues ciolite.ModifyUserWebhookParams) (ciolite.ModifyWebhookResponse, error)
+	DeleteUserWebhookAccountFunc func(ctx context.Context, userID string, webhookID string) (ciolite.DeleteWebhookResponse, error)
+
+	// Connect tokens
+	GetConnectTokensFunc                   func(ctx context.Context) ([]ciolite.GetConnectTokenResponse, error)
+	GetConnectTokenFunc                    func(ctx context.Context, token string) (ciolite.GetConnectTokenResponse, error)
+	CreateConnectTokenFunc                 func(ctx context.Context, formValues ciolite.CreateConnectTokenParams) (ciolite.CreateConnectTokenResponse, error)
+	DeleteConnectTokenFunc                 func(ctx context.Context, token string) (ciolite.DeleteConnectTokenResponse, error)
+	GetUserConnectTokensFunc               func(ctx context.Context, userID string) ([]ciolite.GetConnectTokenResponse, error)
+	GetUserConnectTokenFunc                func(ctx context.Context, userID string, token string) (ciolite.GetConnectTokenResponse, error)
+	CreateUserConnectTokenFunc             func(ctx context.Context, userID string, formValues ciolite.CreateConnectTokenParams) (ciolite.CreateConnectTokenResponse, error)
+	DeleteUserConnectTokenFunc             func(ctx context.Context, userID string, token string) (ciolite.DeleteConnectTokenResponse, error)
+	GetUserEmailAccountConnectTokensFunc   func(ctx context.Context, userID string, label string) ([]ciolite.GetConnectTokenResponse, error)
+	GetUserEmailAccountConnectTokenFunc    func(ctx context.Context, userID string, label string, token string) (ciolite.GetConnectTokenResponse, error)
+	CreateUserEmailAccountConnectTokenFunc func(ctx context.Context, userID string, label string, formValues ciolite.CreateConnectTokenParams) (ciolite.CreateConnectTokenResponse, error)
+	DeleteUserEmailAccountConnectTokenFunc func(ctx context.Context, userID string, label string, token string) (ciolite.DeleteConnectTokenResponse, error)
+
+	// OAuth providers
+	GetOAuthProvidersFunc   func(ctx context.Context) ([]ciolite.GetOAuthProvidersResponse, error)
+	GetOAuthProviderFunc    func(ctx context.Context, key string) (ciolite.GetOAuthProvidersResponse, error)
+	CreateOAuthProviderFunc func(ctx context.Context, formValues ciolite.CreateOAuthProviderParams) (ciolite.CreateOAuthProviderResponse, error)
+	DeleteOAuthProviderFunc func(ctx context.Context, key string) (ciolite.DeleteOAuthProviderResponse, error)
+
+	// Discovery
+	GetDiscoveryFunc func(ctx context.Context, queryValues ciolite.GetDiscoveryParams) (ciolite.GetDiscoveryResponse, error)
+
+	// Status callback URL
+	GetStatusCallbackURLFunc    func(ctx context.Context) (ciolite.GetStatusCallbackURLResponse, error)
+	CreateStatusCallbackURLFunc func(ctx context.Context, formValues ciolite.CreateStatusCallbackURLParams) (ciolite.CreateDeleteStatusCallbackURLResponse, error)
+	DeleteStatusCallbackURLFunc func(ctx context.Context) (ciolite.CreateDeleteStatusCallbackURLResponse, error)
+}
+
+// Mock must implement ciolite.Client
+var _ ciolite.Client = &Mock{}
+
+// Calls returns the calls received so far, in order
+func (mock *Mock) Calls() []Call {
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
+	return append([]Call(nil), mock.calls...)
+}
+
+// CallsTo returns the calls received so far to the method (ex: GetUsers), in order
+func (mock *Mock) CallsTo(method string) []Call {
+	var calls []Call
+	for _, call := range mock.Calls() {
+		if call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// Reset forgets the calls received so far
+func (mock *Mock) Reset() {
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
+	mock.calls = nil
+}
+
+// record records a call to the method
+func (mock *Mock) record(method string, args ...interface{}) {
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
+	mock.calls = append(mock.calls, Call{Method: method, Args: args})
+}
+
+// notConfigured returns ErrNotConfigured for the method
+func notConfigured(method string) error {
+	return errors.Wrap(ErrNotConfigured, method)
+}
+
+// GetUsers implements ciolite.Client
+func (mock *Mock) GetUsers(queryValues ciolite.GetUsersParams) ([]ciolite.GetUsersResponse, error) {
+	return mock.GetUsersContext(context.Background(), queryValues)
+}
+
+// GetUsersContext implements ciolite.Client
+func (mock *Mock) GetUsersContext(ctx context.Context, queryValues ciolite.GetUsersParams) ([]ciolite.GetUsersResponse, error) {
+	mock.record("GetUsers", queryValues)
+	if mock.GetUsersFunc == nil {
+		return nil, notConfigured("GetUsers")
+	}
+	return mock.GetUsersFunc(ctx, queryValues)
+}
+
+// GetUser implements ciolite.Client
+func (mock *Mock) GetUser(userID string) (ciolite.GetUsersResponse, error) {
+	return mock.GetUserContext(context.Background(), userID)
+}
+
+// GetUserContext implements ciolite.Client
+func (mock *Mock) GetUserContext(ctx context.Context, userID string) (ciolite.GetUsersResponse, error) {
+	mock.record("GetUser", userID)
+	if mock.GetUserFunc == nil {
+		return ciolite.GetUsersResponse{}, notConfigured("GetUser")
+	}
+	return mock.GetUserFunc(ctx, userID)
+}
+
+// CreateUser implements ciolite.Client
+func (mock *Mock) CreateUser(formValues ciolite.CreateUserParams) (ciolite.CreateUserResponse, error) {
+	return mock.CreateUserContext(context.Background(), formValues)
+}
+
+// CreateUserContext implements ciolite.Client
+func (mock *Mock) CreateUserContext(ctx context.Context, formValues ciolite.CreateUserParams) (ciolite.CreateUserResponse, error) {
+	mock.record("CreateUser", formValues)
+	if mock.CreateUserFunc == nil {
+		return ciolite.CreateUserResponse{}, notConfigured("CreateUser")
+	}
+	return mock.CreateUserFunc(ctx, formValues)
+}
+
+// ModifyUser implements ciolite.Client
+func (mock *Mock) ModifyUser(userID string, formValues ciolite.ModifyUserParams) (ciolite.ModifyUserResponse, error) {
+	return mock.ModifyUserContext(context.Background(), userID, formValues)
+}
+
+// ModifyUserContext implements ciolite.Client
+func (mock *Mock) ModifyUserContext(ctx context.Context, userID string, formValues ciolite.ModifyUserParams) (ciolite.ModifyUserResponse, error) {
+	mock.record("ModifyUser", userID, formValues)
+	if mock.ModifyUserFunc == nil {
+		return ciolite.ModifyUserResponse{}, notConfigured("ModifyUser")
+	}
+	return mock.ModifyUserFunc(ctx, userID, formValues)
+}
+
+// DeleteUser implements ciolite.Client
+func (mock *Mock) DeleteUser(userID string) (ciolite.DeleteUserResponse, error) {
+	return mock.DeleteUserContext(context.Background(), userID)
+}
+
+// DeleteUserContext implements ciolite.Client
+func (mock *Mock) DeleteUserContext(ctx context.Context, userID string) (ciolite.DeleteUserResponse, error) {
+	mock.record("DeleteUser", userID)
+	if mock.DeleteUserFunc == nil {
+		return ciolite.DeleteUserResponse{}, notConfigured("DeleteUser")
+	}
+	return mock.DeleteUserFunc(ctx, userID)
+}
+
+// GetUserEmailAccounts implements ciolite.Client
+func (mock *Mock) GetUserEmailAccounts(userID string, queryValues ciolite.GetUserEmailAccountsParams) ([]ciolite.GetUsersEmailAccountsResponse, error) {
+	return mock.GetUserEmailAccountsContext(context.Background(), userID, queryValues)
+}
+
+// GetUserEmailAccountsContext implements ciolite.Client
+func (mock *Mock) GetUserEmailAccountsContext(ctx context.Context, userID string, queryValues ciolite.GetUserEmailAccountsParams) ([]ciolite.GetUsersEmailAccountsResponse, error) {
+	mock.record("GetUserEmailAccounts", userID, queryValues)
+	if mock.GetUserEmailAccountsFunc == nil {
+		return nil, notConfigured("GetUserEmailAccounts")
+	}
+	return mock.GetUserEmailAccountsFunc(ctx, userID, queryValues)
+}
+
+// GetUserEmailAccount implements ciolite.Client
+func (mock *Mock) GetUserEmailAccount(userID string, label string) (ciolite.GetUsersEmailAccountsResponse, error) {
+	return mock.GetUserEmailAccountContext(context.Background(), userID, label)
+}
+
+// GetUserEmailAccountContext implements ciolite.Client
+func (mock *Mock) GetUserEmailAccountContext(ctx context.Context, userID string, label string) (ciolite.GetUsersEmailAccountsResponse, error) {
+	mock.record("GetUserEmailAccount", userID, label)
+	if mock.GetUserEmailAccountFunc == nil {
+		return ciolite.GetUsersEmailAccountsResponse{}, notConfigured("GetUserEmailAccount")
+	}
+	return mock.GetUserEmailAccountFunc(ctx, userID, label)
+}
+
+// CreateUserEmailAccount implements ciolite.Client
+func (mock *Mock) CreateUserEmailAccount(userID string, formValues ciolite.CreateUserParams) (ciolite.CreateEmailAccountResponse, error) {
+	return mock.CreateUserEmailAccountContext(context.Background(), userID, formValues)
+}
+
+// CreateUserEmailAccountContext implements ciolite.Client
+func (mock *Mock) CreateUserEmailAccountContext(ctx context.Context, userID string, formValues ciolite.CreateUserParams) (ciolite.CreateEmailAccountResponse, error) {
+	mock.record("CreateUserEmailAccount", userID, formValues)
+	if mock.CreateUserEmailAccountFunc == nil {
+		return ciolite.CreateEmailAccountResponse{}, notConfigured("CreateUserEmailAccount")
+	}
+	return mock.CreateUserEmailAccountFunc(ctx, userID, formValues)
+}
+
+// ModifyUserEmailAccount implements ciolite.Client
+func (mock *Mock) ModifyUserEmailAccount(userID string, label string, formValues ciolite.ModifyUserEmailAccountParams) (ciolite.ModifyEmailAccountResponse, error) {
+	return mock.ModifyUserEmailAccountContext(context.Background(), userID, label, formValues)
+}
+
+// ModifyUserEmailAccountContext implements ciolite.Client
+func (mock *Mock) ModifyUserEmailAccountContext(ctx context.Context, userID string, label string, formValues ciolite.ModifyUserEmailAccountParams) (ciolite.ModifyEmailAccountResponse, error) {
+	mock.record("ModifyUserEmailAccount", userID, label, formValues)
+	if mock.ModifyUserEmailAccountFunc == nil {
+		return ciolite.ModifyEmailAccountResponse{}, notConfigured("ModifyUserEmailAccount")
+	}
+	return mock.ModifyUserEmailAccountFunc(ctx, userID, label, formValues)
+}
+
+// DeleteUserEmailAccount implements ciolite.Client
+func (mock *Mock) DeleteUserEmailAccount(userID string, label string) (ciolite.DeleteEmailAccountResponse, error) {
+	return mock.DeleteUserEmailAccountContext(context.Background(), userID, label)
+}
+
+// DeleteUserEmailAccountContext implements ciolite.Client
+func (mock *Mock) DeleteUserEmailAccountContext(ctx context.Context, userID string, label string) (ciolite.DeleteEmailAccountResponse, error) {
+	mock.record("DeleteUserEmailAccount", userID, label)
+	if mock.DeleteUserEmailAccountFunc == nil {
+		return ciolite.DeleteEmailAccountResponse{}, notConfigured("DeleteUserEmailAccount")
+	}
+	return mock.DeleteUserEmailAccountFunc(ctx, userID, label)
+}
+
+// GetUserEmailAccountsFolders implements ciolite.Client
+func (mock *Mock) GetUserEmailAccountsFolders(userID string, label string, queryValues ciolite.GetUserEmailAccountsFoldersParams) ([]ciolite.GetUsersEmailAccountFoldersResponse, error) {
+	return mock.GetUserEmailAccountsFoldersContext(context.Background(), userID, label, queryValues)
+}
+
+// GetUserEmailAccountsFoldersContext implements ciolite.Client
+func (mock *Mock) GetUserEmailAccountsFoldersContext(ctx context.Context, userID string, label string, queryValues ciolite.GetUserEmailAccountsFoldersParams) ([]ciolite.GetUsersEmailAccountFoldersResponse, error) {
+	mock.record("GetUserEmailAccountsFolders", userID, label, queryValues)
+	if mock.GetUserEmailAccountsFoldersFunc == nil {
+		return nil, notConfigured("GetUserEmailAccountsFolders")
+	}
+	return mock.GetUserEmailAccountsFoldersFunc(ctx, userID, label, queryValues)
+}
+
+// GetUserEmailAccountFolder implements ciolite.Client
+func (mock *Mock) GetUserEmailAccountFolder(userID string, label string, folder string, queryValues ciolite.EmailAccountFolderDelimiterParam) (ciolite.GetUsersEmailAccountFoldersResponse, error) {
+	return mock.GetUserEmailAccountFolderContext(context.Background(), userID, label, folder, queryValues)
+}
+
+// GetUserEmailAccountFolderContext implements ciolite.Client
+func (mock *Mock) GetUserEmailAccountFolderContext(ctx context.Context, userID string, label string, folder string, queryValues ciolite.EmailAccountFolderDelimiterParam) (ciolite.GetUsersEmailAccountFoldersResponse, error) {
+	mock.record("GetUserEmailAccountFolder", userID, label, folder, queryValues)
+	if mock.GetUserEmailAccountFolderFunc == nil {
+		return ciolite.GetUsersEmailAccountFoldersResponse{}, notConfigured("GetUserEmailAccountFolder")
+	}
+	return mock.GetUserEmailAccountFolderFunc(ctx, userID, label, folder, queryValues)
+}
+
+// CreateUserEmailAccountFolder implements ciolite.Client
+func (mock *Mock) CreateUserEmailAccountFolder(userID string, label string, folder string, formValues ciolite.EmailAccountFolderDelimiterParam) (ciolite.CreateEmailAccountFolderResponse, error) {
+	return mock.CreateUserEmailAccountFolderContext(context.Background(), userID, label, folder, formValues)
+}
+
+// CreateUserEmailAccountFolderContext implements ciolite.Client
+func (mock *Mock) CreateUserEmailAccountFolderContext(ctx context.Context, userID string, label string, folder string, formValues ciolite.EmailAccountFolderDelimiterParam) (ciolite.CreateEmailAccountFolderResponse, error) {
+	mock.record("CreateUserEmailAccountFolder", userID, label, folder, formValues)
+	if mock.CreateUserEmailAccountFolderFunc == nil {
+		return ciolite.CreateEmailAccountFolderResponse{}, notConfigured("CreateUserEmailAccountFolder")
+	}
+	return mock.CreateUserEmailAccountFolderFunc(ctx, userID, label, folder, formValues)
+}
+
+// DeleteUserEmailAccountFolder implements ciolite.Client
+func (mock *Mock) DeleteUserEmailAccountFolder(userID string, label string, folder string, formValues ciolite.EmailAccountFolderDelimiterParam) (ciolite.DeleteEmailAccountFolderResponse, error) {
+	return mock.DeleteUserEmailAccountFolderContext(context.Background(), userID, label, folder, formValues)
+}
+
+// DeleteUserEmailAccountFolderContext implements ciolite.Client
+func (mock *Mock) DeleteUserEmailAccountFolderContext(ctx context.Context, userID string, label string, folder string, formValues ciolite.EmailAccountFolderDelimiterParam) (ciolite.DeleteEmailAccountFolderResponse, error) {
+	mock.record("DeleteUserEmailAccountFolder", userID, label, folder, formValues)
+	if mock.DeleteUserEmailAccountFolderFunc == nil {
+		return ciolite.DeleteEmailAccountFolderResponse{}, notConfigured("DeleteUserEmailAccountFolder")
+	}
+	return mock.DeleteUserEmailAccountFolderFunc(ctx, userID, label, folder, formValues)
+}
+
+// RenameUserEmailAccountFolder implements ciolite.Client
+func (mock *Mock) RenameUserEmailAccountFolder(userID string, label string, folder string, formValues ciolite.RenameUserEmailAccountFolderParams) (ciolite.RenameEmailAccountFolderResponse, error) {
+	return mock.RenameUserEmailAccountFolderContext(context.Background(), userID, label, folder, formValues)
+}
+
+// RenameUserEmailAccountFolderContext implements ciolite.Client
+func (mock *Mock) RenameUserEmailAccountFolderContext(ctx context.Context, userID string, label string, folder string, formValues ciolite.RenameUserEmailAccountFolderParams) (ciolite.RenameEmailAccountFolderResponse, error) {
+	mock.record("RenameUserEmailAccountFolder", userID, label, folder, formValues)
+	if mock.RenameUserEmailAccountFolderFunc == nil {
+		return ciolite.RenameEmailAccountFolderResponse{}, notConfigured("RenameUserEmailAccountFolder")
+	}
+	return mock.RenameUserEmailAccountFolderFunc(ctx, userID, label, folder, formValues)
+}
+
+// GetUserEmailAccountsFolderMessages implements ciolite.Client
+func (mock *Mock) GetUserEmailAccountsFolderMessages(userID string, label string, folder string, queryValues ciolite.GetUserEmailAccountsFolderMessageParams) ([]ciolite.GetUsersEmailAccountFolderMessagesResponse, error) {
+	return mock.GetUserEmailAccountsFolderMessagesContext(context.Background(), userID, label, folder, queryValues)
+}
+
+// GetUserEmailAccountsFolderMessagesContext implements ciolite.Client
+func (mock *Mock) GetUserEmailAccountsFolderMessagesContext(ctx context.Context, userID string, label string, folder string, queryValues ciolite.GetUserEmailAccountsFolderMessageParams) ([]ciolite.GetUsersEmailAccountFolderMessagesResponse, error) {
+	mock.record("GetUserEmailAccountsFolderMessages", userID, label, folder, queryValues)
+	if mock.GetUserEmailAccountsFolderMessagesFunc == nil {
+		return nil, notConfigured("GetUserEmailAccountsFolderMessages")
+	}
+	return mock.GetUserEmailAccountsFolderMessagesFunc(ctx, userID, label, folder, queryValues)
+}
+
+// GetUserEmailAccountFolderMessage implements ciolite.Client
+func (mock *Mock) GetUserEmailAccountFolderMessage(userID string, label string, folder string, messageID string, queryValues ciolite.GetUserEmailAccountsFolderMessageParams) (ciolite.GetUsersEmailAccountFolderMessagesResponse, error) {
+	return mock.GetUserEmailAccountFolderMessageContext(context.Background(), userID, label, folder, messageID, queryValues)
+}
+
+// GetUserEmailAccountFolderMessageContext implements ciolite.Client
+func (mock *Mock) GetUserEmailAccountFolderMessageContext(ctx context.Context, userID string, label string, folder string, messageID string, queryValues ciolite.GetUserEmailAccountsFolderMessageParams) (ciolite.GetUsersEmailAccountFolderMessagesResponse, error) {
+	mock.record("GetUserEmailAccountFolderMessage", userID, label, folder, messageID, queryValues)
+	if mock.GetUserEmailAccountFolderMessageFunc == nil {
+		return ciolite.GetUsersEmailAccountFolderMessagesResponse{}, notConfigured("GetUserEmailAccountFolderMessage")
+	}
+	return mock.GetUserEmailAccountFolderMessageFunc(ctx, userID, label, folder, messageID, queryValues)
+}
+
+// MoveUserEmailAccountFolderMessage implements ciolite.Client
+func (mock *Mock) MoveUserEmailAccountFolderMessage(userID string, label string, folder string, messageID string, queryValues ciolite.MoveUserEmailAccountFolderMessageParams) (ciolite.MoveUserEmailAccountFolderMessageResponse, error) {
+	return mock.MoveUserEmailAccountFolderMessageContext(context.Background(), userID, label, folder, messageID, queryValues)
+}
+
+// MoveUserEmailAccountFolderMessageContext implements ciolite.Client
+func (mock *Mock) MoveUserEmailAccountFolderMessageContext(ctx context.Context, userID string, label string, folder string, messageID string, queryValues ciolite.MoveUserEmailAccountFolderMessageParams) (ciolite.MoveUserEmailAccountFolderMessageResponse, error) {
+	mock.record("MoveUserEmailAccountFolderMessage", userID, label, folder, messageID, queryValues)
+	if mock.MoveUserEmailAccountFolderMessageFunc == nil {
+		return ciolite.MoveUserEmailAccountFolderMessageResponse{}, notConfigured("MoveUserEmailAccountFolderMessage")
+	}
+	return mock.MoveUserEmailAccountFolderMessageFunc(ctx, userID, label, folder, messageID, queryValues)
+}
+
+// MoveUserEmailAccountFolderMessage2 implements ciolite.Client
+func (mock *Mock) MoveUserEmailAccountFolderMessage2(userID string, label string, folder string, messageID string, queryValues ciolite.MoveUserEmailAccountFolderMessageParams) (ciolite.MoveUserEmailAccountFolderMessageResponse, error) {
+	return mock.MoveUserEmailAccountFolderMessage2Context(context.Background(), userID, label, folder, messageID, queryValues)
+}
+
+// MoveUserEmailAccountFolderMessage2Context implements ciolite.Client
+func (mock *Mock) MoveUserEmailAccountFolderMessage2Context(ctx context.Context, userID string, label string, folder string, messageID string, queryValues ciolite.MoveUserEmailAccountFolderMessageParams) (ciolite.MoveUserEmailAccountFolderMessageResponse, error) {
+	mock.record("MoveUserEmailAccountFolderMessage2", userID, label, folder, messageID, queryValues)
+	if mock.MoveUserEmailAccountFolderMessage2Func == nil {
+		return ciolite.MoveUserEmailAccountFolderMessageResponse{}, notConfigured("MoveUserEmailAccountFolderMessage2")
+	}
+	return mock.MoveUserEmailAccountFolderMessage2Func(ctx, userID, label, folder, messageID, queryValues)
+}
+
+// GetUserEmailAccountsFolderMessageAttachments implements ciolite.Client
+func (mock *Mock) GetUserEmailAccountsFolderMessageAttachments(userID string, label string, folder string, messageID string, queryValues ciolite.EmailAccountFolderDelimiterParam) ([]ciolite.GetUserEmailAccountsFolderMessageAttachmentsResponse, error) {
+	return mock.GetUserEmailAccountsFolderMessageAttachmentsContext(context.Background(), userID, label, folder, messageID, queryValues)
+}
+
+// GetUserEmailAccountsFolderMessageAttachmentsContext implements ciolite.Client
+func (mock *Mock) GetUserEmailAccountsFolderMessageAttachmentsContext(ctx context.Context, userID string, label string, folder string, messageID string, queryValues ciolite.EmailAccountFolderDelimiterParam) ([]ciolite.GetUserEmailAccountsFolderMessageAttachmentsResponse, error) {
+	mock.record("GetUserEmailAccountsFolderMessageAttachments", userID, label, folder, messageID, queryValues)
+	if mock.GetUserEmailAccountsFolderMessageAttachmentsFunc == nil {
+		return nil, notConfigured("GetUserEmailAccountsFolderMessageAttachments")
+	}
+	return mock.GetUserEmailAccountsFolderMessageAttachmentsFunc(ctx, userID, label, folder, messageID, queryValues)
+}
+
+// GetUserEmailAccountsFolderMessageAttachment implements ciolite.Client
+func (mock *Mock) GetUserEmailAccountsFolderMessageAttachment(userID string, label string, folder string, messageID string, attachmentID string, queryValues ciolite.EmailAccountFolderDelimiterParam) (ciolite.GetUserEmailAccountsFolderMessageAttachmentsResponse, error) {
+	return mock.GetUserEmailAccountsFolderMessageAttachmentContext(context.Background(), userID, label, folder, messageID, attachmentID, queryValues)
+}
+
+// GetUserEmailAccountsFolderMessageAttachmentContext implements ciolite.Client
+func (mock *Mock) GetUserEmailAccountsFolderMessageAttachmentContext(ctx context.Context, userID string, label string, folder string, messageID string, attachmentID string, queryValues ciolite.EmailAccountFolderDelimiterParam) (ciolite.GetUserEmailAccountsFolderMessageAttachmentsResponse, error) {
+	mock.record("GetUserEmailAccountsFolderMessageAttachment", userID, label, folder, messageID, attachmentID, queryValues)
+	if mock.GetUserEmailAccountsFolderMessageAttachmentFunc == nil {
+		return ciolite.GetUserEmailAccountsFolderMessageAttachmentsResponse{}, notConfigured("GetUserEmailAccountsFolderMessageAttachment")
+	}
+	return mock.GetUserEmailAccountsFolderMessageAttachmentFunc(ctx, userID, label, folder, messageID, attachmentID, queryValues)
+}
+
+// GetUserEmailAccountsFolderMessageAttachmentContent implements ciolite.Client
+func (mock *Mock) GetUserEmailAccountsFolderMessageAttachmentContent(userID string, label string, folder string, messageID string, attachmentID string, queryValues ciolite.EmailAccountFolderDelimiterParam) (*ciolite.AttachmentContent, error) {
+	return mock.GetUserEmailAccountsFolderMessageAttachmentContentContext(context.Background(), userID, label, folder, messageID, attachmentID, queryValues)
+}
+
+// GetUserEmailAccountsFolderMessageAttachmentContentContext implements ciolite.Client
+func (mock *Mock) GetUserEmailAccountsFolderMessageAttachmentContentContext(ctx context.Context, userID string, label string, folder string, messageID string, attachmentID string, queryValues ciolite.EmailAccountFolderDelimiterParam) (*ciolite.AttachmentContent, error) {
+	mock.record("GetUserEmailAccountsFolderMessageAttachmentContent", userID, label, folder, messageID, attachmentID, queryValues)
+	if mock.GetUserEmailAccountsFolderMessageAttachmentContentFunc == nil {
+		return nil, notConfigured("GetUserEmailAccountsFolderMessageAttachmentContent")
+	}
+	return mock.GetUserEmailAccountsFolderMessageAttachmentContentFunc(ctx, userID, label, folder, messageID, attachmentID, queryValues)
+}
+
+// GetUserEmailAccountsFolderMessageBody implements ciolite.Client
+func (mock *Mock) GetUserEmailAccountsFolderMessageBody(userID string, label string, folder string, messageID string, queryValues ciolite.GetUserEmailAccountsFolderMessageBodyParams) ([]ciolite.GetUserEmailAccountsFolderMessageBodyResponse, error) {
+	return mock.GetUserEmailAccountsFolderMessageBodyContext(context.Background(), userID, label, folder, messageID, queryValues)
+}
+
+// GetUserEmailAccountsFolderMessageBodyContext implements ciolite.Client
+func (mock *Mock) GetUserEmailAccountsFolderMessageBodyContext(ctx context.Context, userID string, label string, folder string, messageID string, queryValues ciolite.GetUserEmailAccountsFolderMessageBodyParams) ([]ciolite.GetUserEmailAccountsFolderMessageBodyResponse, error) {
+	mock.record("GetUserEmailAccountsFolderMessageBody", userID, label, folder, messageID, queryValues)
+	if mock.GetUserEmailAccountsFolderMessageBodyFunc == nil {
+		return nil, notConfigured("GetUserEmailAccountsFolderMessageBody")
+	}
+	return mock.GetUserEmailAccountsFolderMessageBodyFunc(ctx, userID, label, folder, messageID, queryValues)
+}
+
+// GetUserEmailAccountsFolderMessageFlags implements ciolite.Client
+func (mock *Mock) GetUserEmailAccountsFolderMessageFlags(userID string, label string, folder string, messageID string, queryValues ciolite.EmailAccountFolderDelimiterParam) (ciolite.GetUserEmailAccountsFolderMessageFlagsResponse, error) {
+	return mock.GetUserEmailAccountsFolderMessageFlagsContext(context.Background(), userID, label, folder, messageID, queryValues)
+}
+
+// GetUserEmailAccountsFolderMessageFlagsContext implements ciolite.Client
+func (mock *Mock) GetUserEmailAccountsFolderMessageFlagsContext(ctx context.Context, userID string, label string, folder string, messageID string, queryValues ciolite.EmailAccountFolderDelimiterParam) (ciolite.GetUserEmailAccountsFolderMessageFlagsResponse, error) {
+	mock.record("GetUserEmailAccountsFolderMessageFlags", userID, label, folder, messageID, queryValues)
+	if mock.GetUserEmailAccountsFolderMessageFlagsFunc == nil {
+		return ciolite.GetUserEmailAccountsFolderMessageFlagsResponse{}, notConfigured("GetUserEmailAccountsFolderMessageFlags")
+	}
+	return mock.GetUserEmailAccountsFolderMessageFlagsFunc(ctx, userID, label, folder, messageID, queryValues)
+}
+
+// SetUserEmailAccountsFolderMessageFlags implements ciolite.Client
+func (mock *Mock) SetUserEmailAccountsFolderMessageFlags(userID string, label string, folder string, messageID string, formValues ciolite.SetUserEmailAccountsFolderMessageFlagsParams) (ciolite.SetUserEmailAccountsFolderMessageFlagsResponse, error) {
+	return mock.SetUserEmailAccountsFolderMessageFlagsContext(context.Background(), userID, label, folder, messageID, formValues)
+}
+
+// SetUserEmailAccountsFolderMessageFlagsContext implements ciolite.Client
+func (mock *Mock) SetUserEmailAccountsFolderMessageFlagsContext(ctx context.Context, userID string, label string, folder string, messageID string, formValues ciolite.SetUserEmailAccountsFolderMessageFlagsParams) (ciolite.SetUserEmailAccountsFolderMessageFlagsResponse, error) {
+	mock.record("SetUserEmailAccountsFolderMessageFlags", userID, label, folder, messageID, formValues)
+	if mock.SetUserEmailAccountsFolderMessageFlagsFunc == nil {
+		return ciolite.SetUserEmailAccountsFolderMessageFlagsResponse{}, notConfigured("SetUserEmailAccountsFolderMessageFlags")
+	}
+	return mock.SetUserEmailAccountsFolderMessageFlagsFunc(ctx, userID, label, folder, messageID, formValues)
+}
+
+// GetUserEmailAccountsFolderMessageHeaders implements ciolite.Client
+func (mock *Mock) GetUserEmailAccountsFolderMessageHeaders(userID string, label string, folder string, messageID string, queryValues ciolite.GetUserEmailAccountsFolderMessageHeadersParams) (ciolite.GetUserEmailAccountsFolderMessageHeadersResponse, error) {
+	return mock.GetUserEmailAccountsFolderMessageHeadersContext(context.Background(), userID, label, folder, messageID, queryValues)
+}
+
+// GetUserEmailAccountsFolderMessageHeadersContext implements ciolite.Client
+func (mock *Mock) GetUserEmailAccountsFolderMessageHeadersContext(ctx context.Context, userID string, label string, folder string, messageID string, queryValues ciolite.GetUserEmailAccountsFolderMessageHeadersParams) (ciolite.GetUserEmailAccountsFolderMessageHeadersResponse, error) {
+	mock.record("GetUserEmailAccountsFolderMessageHeaders", userID, label, folder, messageID, queryValues)
+	if mock.GetUserEmailAccountsFolderMessageHeadersFunc == nil {
+		return ciolite.GetUserEmailAccountsFolderMessageHeadersResponse{}, notConfigured("GetUserEmailAccountsFolderMessageHeaders")
+	}
+	return mock.GetUserEmailAccountsFolderMessageHeadersFunc(ctx, userID, label, folder, messageID, queryValues)
+}
+
+// GetUserEmailAccountsFolderMessageRaw implements ciolite.Client
+func (mock *Mock) GetUserEmailAccountsFolderMessageRaw(userID string, label string, folder string, messageID string, queryValues ciolite.EmailAccountFolderDelimiterParam) (ciolite.GetUserEmailAccountsFolderMessageRawResponse, error) {
+	return mock.GetUserEmailAccountsFolderMessageRawContext(context.Background(), userID, label, folder, messageID, queryValues)
+}
+
+// GetUserEmailAccountsFolderMessageRawContext implements ciolite.Client
+func (mock *Mock) GetUserEmailAccountsFolderMessageRawContext(ctx context.Context, userID string, label string, folder string, messageID string, queryValues ciolite.EmailAccountFolderDelimiterParam) (ciolite.GetUserEmailAccountsFolderMessageRawResponse, error) {
+	mock.record("GetUserEmailAccountsFolderMessageRaw", userID, label, folder, messageID, queryValues)
+	if mock.GetUserEmailAccountsFolderMessageRawFunc == nil {
+		return "", notConfigured("GetUserEmailAccountsFolderMessageRaw")
+	}
+	return mock.GetUserEmailAccountsFolderMessageRawFunc(ctx, userID, label, folder, messageID, queryValues)
+}
+
+// StreamUserEmailAccountsFolderMessageRaw implements ciolite.Client
+func (mock *Mock) StreamUserEmailAccountsFolderMessageRaw(userID string, label string, folder string, messageID string, queryValues ciolite.EmailAccountFolderDelimiterParam) (*ciolite.RawMessageStream, error) {
+	return mock.StreamUserEmailAccountsFolderMessageRawContext(context.Background(), userID, label, folder, messageID, queryValues)
+}
+
+// StreamUserEmailAccountsFolderMessageRawContext implements ciolite.Client
+func (mock *Mock) StreamUserEmailAccountsFolderMessageRawContext(ctx context.Context, userID string, label string, folder string, messageID string, queryValues ciolite.EmailAccountFolderDelimiterParam) (*ciolite.RawMessageStream, error) {
+	mock.record("StreamUserEmailAccountsFolderMessageRaw", userID, label, folder, messageID, queryValues)
+	if mock.StreamUserEmailAccountsFolderMessageRawFunc == nil {
+		return nil, notConfigured("StreamUserEmailAccountsFolderMessageRaw")
+	}
+	return mock.StreamUserEmailAccountsFolderMessageRawFunc(ctx, userID, label, folder, messageID, queryValues)
+}
+
+// MarkUserEmailAccountsFolderMessageRead implements ciolite.Client
+func (mock *Mock) MarkUserEmailAccountsFolderMessageRead(userID string, label string, folder string, messageID string, formValues ciolite.EmailAccountFolderDelimiterParam) (ciolite.UserEmailAccountsFolderMessageReadResponse, error) {
+	return mock.MarkUserEmailAccountsFolderMessageReadContext(context.Background(), userID, label, folder, messageID, formValues)
+}
+
+// MarkUserEmailAccountsFolderMessageReadContext implements ciolite.Client
+func (mock *Mock) MarkUserEmailAccountsFolderMessageReadContext(ctx context.Context, userID string, label string, folder string, messageID string, formValues ciolite.EmailAccountFolderDelimiterParam) (ciolite.UserEmailAccountsFolderMessageReadResponse, error) {
+	mock.record("MarkUserEmailAccountsFolderMessageRead", userID, label, folder, messageID, formValues)
+	if mock.MarkUserEmailAccountsFolderMessageReadFunc == nil {
+		return ciolite.UserEmailAccountsFolderMessageReadResponse{}, notConfigured("MarkUserEmailAccountsFolderMessageRead")
+	}
+	return mock.MarkUserEmailAccountsFolderMessageReadFunc(ctx, userID, label, folder, messageID, formValues)
+}
+
+// MarkUserEmailAccountsFolderMessageUnRead implements ciolite.Client
+func (mock *Mock) MarkUserEmailAccountsFolderMessageUnRead(userID string, label string, folder string, messageID string, formValues ciolite.EmailAccountFolderDelimiterParam) (ciolite.UserEmailAccountsFolderMessageReadResponse, error) {
+	return mock.MarkUserEmailAccountsFolderMessageUnReadContext(context.Background(), userID, label, folder, messageID, formValues)
+}
+
+// MarkUserEmailAccountsFolderMessageUnReadContext implements ciolite.Client
+func (mock *Mock) MarkUserEmailAccountsFolderMessageUnReadContext(ctx context.Context, userID string, label string, folder string, messageID string, formValues ciolite.EmailAccountFolderDelimiterParam) (ciolite.UserEmailAccountsFolderMessageReadResponse, error) {
+	mock.record("MarkUserEmailAccountsFolderMessageUnRead", userID, label, folder, messageID, formValues)
+	if mock.MarkUserEmailAccountsFolderMessageUnReadFunc == nil {
+		return ciolite.UserEmailAccountsFolderMessageReadResponse{}, notConfigured("MarkUserEmailAccountsFolderMessageUnRead")
+	}
+	return mock.MarkUserEmailAccountsFolderMessageUnReadFunc(ctx, userID, label, folder, messageID, formValues)
+}
+
+// GetWebhooks implements ciolite.Client
+func (mock *Mock) GetWebhooks() ([]ciolite.GetUsersWebhooksResponse, error) {
+	return mock.GetWebhooksContext(context.Background())
+}
+
+// GetWebhooksContext implements ciolite.Client
+func (mock *Mock) GetWebhooksContext(ctx context.Context) ([]ciolite.GetUsersWebhooksResponse, error) {
+	mock.record("GetWebhooks")
+	if mock.GetWebhooksFunc == nil {
+		return nil, notConfigured("GetWebhooks")
+	}
+	return mock.GetWebhooksFunc(ctx)
+}
+
+// GetWebhook implements ciolite.Client
+func (mock *Mock) GetWebhook(webhookID string) (ciolite.GetUsersWebhooksResponse, error) {
+	return mock.GetWebhookContext(context.Background(), webhookID)
+}
+
+// GetWebhookContext implements ciolite.Client
+func (mock *Mock) GetWebhookContext(ctx context.Context, webhookID string) (ciolite.GetUsersWebhooksResponse, error) {
+	mock.record("GetWebhook", webhookID)
+	if mock.GetWebhookFunc == nil {
+		return ciolite.GetUsersWebhooksResponse{}, notConfigured("GetWebhook")
+	}
+	return mock.GetWebhookFunc(ctx, webhookID)
+}
+
+// CreateWebhook implements ciolite.Client
+func (mock *Mock) CreateWebhook(formValues ciolite.CreateUserWebhookParams) (ciolite.CreateUserWebhookResponse, error) {
+	return mock.CreateWebhookContext(context.Background(), formValues)
+}
+
+// CreateWebhookContext implements ciolite.Client
+func (mock *Mock) CreateWebhookContext(ctx context.Context, formValues ciolite.CreateUserWebhookParams) (ciolite.CreateUserWebhookResponse, error) {
+	mock.record("CreateWebhook", formValues)
+	if mock.CreateWebhookFunc == nil {
+		return ciolite.CreateUserWebhookResponse{}, notConfigured("CreateWebhook")
+	}
+	return mock.CreateWebhookFunc(ctx, formValues)
+}
+
+// ModifyWebhook implements ciolite.Client
+func (mock *Mock) ModifyWebhook(webhookID string, formValues ciolite.ModifyUserWebhookParams) (ciolite.ModifyWebhookResponse, error) {
+	return mock.ModifyWebhookContext(context.Background(), webhookID, formValues)
+}
+
+// ModifyWebhookContext implements ciolite.Client
+func (mock *Mock) ModifyWebhookContext(ctx context.Context, webhookID string, formValues ciolite.ModifyUserWebhookParams) (ciolite.ModifyWebhookResponse, error) {
+	mock.record("ModifyWebhook", webhookID, formValues)
+	if mock.ModifyWebhookFunc == nil {
+		return ciolite.ModifyWebhookResponse{}, notConfigured("ModifyWebhook")
+	}
+	return mock.ModifyWebhookFunc(ctx, webhookID, formValues)
+}
+
+// DeleteWebhookAccount implements ciolite.Client
+func (mock *Mock) DeleteWebhookAccount(webhookID string) (ciolite.DeleteWebhookResponse, error) {
+	return mock.DeleteWebhookAccountContext(context.Background(), webhookID)
+}
+
+// DeleteWebhookAccountContext implements ciolite.Client
+func (mock *Mock) DeleteWebhookAccountContext(ctx context.Context, webhookID string) (ciolite.DeleteWebhookResponse, error) {
+	mock.record("DeleteWebhookAccount", webhookID)
+	if mock.DeleteWebhookAccountFunc == nil {
+		return ciolite.DeleteWebhookResponse{}, notConfigured("DeleteWebhookAccount")
+	}
+	return mock.DeleteWebhookAccountFunc(ctx, webhookID)
+}
+
+// GetUserWebhooks implements ciolite.Client
+func (mock *Mock) GetUserWebhooks(userID string) ([]ciolite.GetUsersWebhooksResponse, error) {
+	return mock.GetUserWebhooksContext(context.Background(), userID)
+}
+
+// GetUserWebhooksContext implements ciolite.Client
+func (mock *Mock) GetUserWebhooksContext(ctx context.Context, userID string) ([]ciolite.GetUsersWebhooksResponse, error) {
+	mock.record("GetUserWebhooks", userID)
+	if mock.GetUserWebhooksFunc == nil {
+		return nil, notConfigured("GetUserWebhooks")
+	}
+	return mock.GetUserWebhooksFunc(ctx, userID)
+}
+
+// GetUserWebhook implements ciolite.Client
+func (mock *Mock) GetUserWebhook(userID string, webhookID string) (ciolite.GetUsersWebhooksResponse, error) {
+	return mock.GetUserWebhookContext(context.Background(), userID, webhookID)
+}
+
+// GetUserWebhookContext implements ciolite.Client
+func (mock *Mock) GetUserWebhookContext(ctx context.Context, userID string, webhookID string) (ciolite.GetUsersWebhooksResponse, error) {
+	mock.record("GetUserWebhook", userID, webhookID)
+	if mock.GetUserWebhookFunc == nil {
+		return ciolite.GetUsersWebhooksResponse{}, notConfigured("GetUserWebhook")
+	}
+	return mock.GetUserWebhookFunc(ctx, userID, webhookID)
+}
+
+// CreateUserWebhook implements ciolite.Client
+func (mock *Mock) CreateUserWebhook(userID string, formValues ciolite.CreateUserWebhookParams) (ciolite.CreateUserWebhookResponse, error) {
+	return mock.CreateUserWebhookContext(context.Background(), userID, formValues)
+}
+
+// CreateUserWebhookContext implements ciolite.Client
+func (mock *Mock) CreateUserWebhookContext(ctx context.Context, userID string, formValues ciolite.CreateUserWebhookParams) (ciolite.CreateUserWebhookResponse, error) {
+	mock.record("CreateUserWebhook", userID, formValues)
+	if mock.CreateUserWebhookFunc == nil {
+		return ciolite.CreateUserWebhookResponse{}, notConfigured("CreateUserWebhook")
+	}
+	return mock.CreateUserWebhookFunc(ctx, userID, formValues)
+}
+
+// ModifyUserWebhook implements ciolite.Client
+func (mock *Mock) ModifyUserWebhook(userID string, webhookID string, formValues ciolite.ModifyUserWebhookParams) (ciolite.ModifyWebhookResponse, error) {
+	return mock.ModifyUserWebhookContext(context.Background(), userID, webhookID, formValues)
+}
+
+// ModifyUserWebhookContext implements ciolite.Client
+func (mock *Mock) ModifyUserWebhookContext(ctx context.Context, userID string, webhookID string, formValues ciolite.ModifyUserWebhookParams) (ciolite.ModifyWebhookResponse, error) {
+	mock.record("ModifyUserWebhook", userID, webhookID, formValues)
+	if mock.ModifyUserWebhookFunc == nil {
+		return ciolite.ModifyWebhookResponse{}, notConfigured("ModifyUserWebhook")
+	}
+	return mock.ModifyUserWebhookFunc(ctx, userID, webhookID, formValues)
+}
+
+// DeleteUserWebhookAccount implements ciolite.Client
+func (mock *Mock) DeleteUserWebhookAccount(userID string, webhookID string) (ciolite.DeleteWebhookResponse, error) {
+	return mock.DeleteUserWebhookAccountContext(context.Background(), userID, webhookID)
+}
+
+// DeleteUserWebhookAccountContext implements ciolite.Client
+func (mock *Mock) DeleteUserWebhookAccountContext(ctx context.Context, userID string, webhookID string) (ciolite.DeleteWebhookResponse, error) {
+	mock.record("DeleteUserWebhookAccount", userID, webhookID)
+	if mock.DeleteUserWebhookAccountFunc == nil {
+		return ciolite.DeleteWebhookResponse{}, notConfigured("DeleteUserWebhookAccount")
+	}
+	return mock.DeleteUserWebhookAccountFunc(ctx, userID, webhookID)
+}
+
+// GetConnectTokens implements ciolite.Client
+func (mock *Mock) GetConnectTokens() ([]ciolite.GetConnectTokenResponse, error) {
+	return mock.GetConnectTokensContext(context.Background())
+}
+
+// GetConnectTokensContext implements ciolite.Client
+func (mock *Mock) GetConnectTokensContext(ctx context.Context) ([]ciolite.GetConnectTokenResponse, error) {
+	mock.record("GetConnectTokens")
+	if mock.GetConnectTokensFunc == nil {
+		return nil, notConfigured("GetConnectTokens")
+	}
+	return mock.GetConnectTokensFunc(ctx)
+}
+
+// GetConnectToken implements ciolite.Client
+func (mock *Mock) GetConnectToken(token string) (ciolite.GetConnectTokenResponse, error) {
+	return mock.GetConnectTokenContext(context.Background(), token)
+}
+
+// GetConnectTokenContext implements ciolite.Client
+func (mock *Mock) GetConnectTokenContext(ctx context.Context, token string) (ciolite.GetConnectTokenResponse, error) {
+	mock.record("GetConnectToken", token)
+	if mock.GetConnectTokenFunc == nil {
+		return ciolite.GetConnectTokenResponse{}, notConfigured("GetConnectToken")
+	}
+	return mock.GetConnectTokenFunc(ctx, token)
+}
+
+// CreateConnectToken implements ciolite.Client
+func (mock *Mock) CreateConnectToken(formValues ciolite.CreateConnectTokenParams) (ciolite.CreateConnectTokenResponse, error) {
+	return mock.CreateConnectTokenContext(context.Background(), formValues)
+}
+
+// CreateConnectTokenContext implements ciolite.Client
+func (mock *Mock) CreateConnectTokenContext(ctx context.Context, formValues ciolite.CreateConnectTokenParams) (ciolite.CreateConnectTokenResponse, error) {
+	mock.record("CreateConnectToken", formValues)
+	if mock.CreateConnectTokenFunc == nil {
+		return ciolite.CreateConnectTokenResponse{}, notConfigured("CreateConnectToken")
+	}
+	return mock.CreateConnectTokenFunc(ctx, formValues)
+}
+
+// DeleteConnectToken implements ciolite.Client
+func (mock *Mock) DeleteConnectToken(token string) (ciolite.DeleteConnectTokenResponse, error) {
+	return mock.DeleteConnectTokenContext(context.Background(), token)
+}
+
+// DeleteConnectTokenContext implements ciolite.Client
+func (mock *Mock) DeleteConnectTokenContext(ctx context.Context, token string) (ciolite.DeleteConnectTokenResponse, error) {
+	mock.record("DeleteConnectToken", token)
+	if mock.DeleteConnectTokenFunc == nil {
+		return ciolite.DeleteConnectTokenResponse{}, notConfigured("DeleteConnectToken")
+	}
+	return mock.DeleteConnectTokenFunc(ctx, token)
+}
+
+// GetUserConnectTokens implements ciolite.Client
+func (mock *Mock) GetUserConnectTokens(userID string) ([]ciolite.GetConnectTokenResponse, error) {
+	return mock.GetUserConnectTokensContext(context.Background(), userID)
+}
+
+// GetUserConnectTokensContext implements ciolite.Client
+func (mock *Mock) GetUserConnectTokensContext(ctx context.Context, userID string) ([]ciolite.GetConnectTokenResponse, error) {
+	mock.record("GetUserConnectTokens", userID)
+	if mock.GetUserConnectTokensFunc == nil {
+		return nil, notConfigured("GetUserConnectTokens")
+	}
+	return mock.GetUserConnectTokensFunc(ctx, userID)
+}
+
+// GetUserConnectToken implements ciolite.Client
+func (mock *Mock) GetUserConnectToken(userID string, token string) (ciolite.GetConnectTokenResponse, error) {
+	return mock.GetUserConnectTokenContext(context.Background(), userID, token)
+}
+
+// GetUserConnectTokenContext implements ciolite.Client
+func (mock *Mock) GetUserConnectTokenContext(ctx context.Context, userID string, token string) (ciolite.GetConnectTokenResponse, error) {
+	mock.record("GetUserConnectToken", userID, token)
+	if mock.GetUserConnectTokenFunc == nil {
+		return ciolite.GetConnectTokenResponse{}, notConfigured("GetUserConnectToken")
+	}
+	return mock.GetUserConnectTokenFunc(ctx, userID, token)
+}
+
+// CreateUserConnectToken implements ciolite.Client
+func (mock *Mock) CreateUserConnectToken(userID string, formValues ciolite.CreateConnectTokenParams) (ciolite.CreateConnectTokenResponse, error) {
+	return mock.CreateUserConnectTokenContext(context.Background(), userID, formValues)
+}
+
+// CreateUserConnectTokenContext implements ciolite.Client
+func (mock *Mock) CreateUserConnectTokenContext(ctx context.Context, userID string, formValues ciolite.CreateConnectTokenParams) (ciolite.CreateConnectTokenResponse, error) {
+	mock.record("CreateUserConnectToken", userID, formValues)
+	if mock.CreateUserConnectTokenFunc == nil {
+		return ciolite.CreateConnectTokenResponse{}, notConfigured("CreateUserConnectToken")
+	}
+	return mock.CreateUserConnectTokenFunc(ctx, userID, formValues)
+}
+
+// DeleteUserConnectToken implements ciolite.Client
+func (mock *Mock) DeleteUserConnectToken(userID string, token string) (ciolite.DeleteConnectTokenResponse, error) {
+	return mock.DeleteUserConnectTokenContext(context.Background(), userID, token)
+}
+
+// DeleteUserConnectTokenContext implements ciolite.Client
+func (mock *Mock) DeleteUserConnectTokenContext(ctx context.Context, userID string, token string) (ciolite.DeleteConnectTokenResponse, error) {
+	mock.record("DeleteUserConnectToken", userID, token)
+	if mock.DeleteUserConnectTokenFunc == nil {
+		return ciolite.DeleteConnectTokenResponse{}, notConfigured("DeleteUserConnectToken")
+	}
+	return mock.DeleteUserConnectTokenFunc(ctx, userID, token)
+}
+
+// GetUserEmailAccountConnectTokens implements ciolite.Client
+func (mock *Mock) GetUserEmailAccountConnectTokens(userID string, label string) ([]ciolite.GetConnectTokenResponse, error) {
+	return mock.GetUserEmailAccountConnectTokensContext(context.Background(), userID, label)
+}
+
+// GetUserEmailAccountConnectTokensContext implements ciolite.Client
+func (mock *Mock) GetUserEmailAccountConnectTokensContext(ctx context.Context, userID string, label string) ([]ciolite.GetConnectTokenResponse, error) {
+	mock.record("GetUserEmailAccountConnectTokens", userID, label)
+	if mock.GetUserEmailAccountConnectTokensFunc == nil {
+		return nil, notConfigured("GetUserEmailAccountConnectTokens")
+	}
+	return mock.GetUserEmailAccountConnectTokensFunc(ctx, userID, label)
+}
+
+// GetUserEmailAccountConnectToken implements ciolite.Client
+func (mock *Mock) GetUserEmailAccountConnectToken(userID string, label string, token string) (ciolite.GetConnectTokenResponse, error) {
+	return mock.GetUserEmailAccountConnectTokenContext(context.Background(), userID, label, token)
+}
+
+// GetUserEmailAccountConnectTokenContext implements ciolite.Client
+func (mock *Mock) GetUserEmailAccountConnectTokenContext(ctx context.Context, userID string, label string, token string) (ciolite.GetConnectTokenResponse, error) {
+	mock.record("GetUserEmailAccountConnectToken", userID, label, token)
+	if mock.GetUserEmailAccountConnectTokenFunc == nil {
+		return ciolite.GetConnectTokenResponse{}, notConfigured("GetUserEmailAccountConnectToken")
+	}
+	return mock.GetUserEmailAccountConnectTokenFunc(ctx, userID, label, token)
+}
+
+// CreateUserEmailAccountConnectToken implements ciolite.Client
+func (mock *Mock) CreateUserEmailAccountConnectToken(userID string, label string, formValues ciolite.CreateConnectTokenParams) (ciolite.CreateConnectTokenResponse, error) {
+	return mock.CreateUserEmailAccountConnectTokenContext(context.Background(), userID, label, formValues)
+}
+
+// CreateUserEmailAccountConnectTokenContext implements ciolite.Client
+func (mock *Mock) CreateUserEmailAccountConnectTokenContext(ctx context.Context, userID string, label string, formValues ciolite.CreateConnectTokenParams) (ciolite.CreateConnectTokenResponse, error) {
+	mock.record("CreateUserEmailAccountConnectToken", userID, label, formValues)
+	if mock.CreateUserEmailAccountConnectTokenFunc == nil {
+		return ciolite.CreateConnectTokenResponse{}, notConfigured("CreateUserEmailAccountConnectToken")
+	}
+	return mock.CreateUserEmailAccountConnectTokenFunc(ctx, userID, label, formValues)
+}
+
+// DeleteUserEmailAccountConnectToken implements ciolite.Client
+func (mock *Mock) DeleteUserEmailAccountConnectToken(userID string, label string, token string) (ciolite.DeleteConnectTokenResponse, error) {
+	return mock.DeleteUserEmailAccountConnectTokenContext(context.Background(), userID, label, token)
+}
+
+// DeleteUserEmailAccountConnectTokenContext implements ciolite.Client
+func (mock *Mock) DeleteUserEmailAccountConnectTokenContext(ctx context.Context, userID string, label string, token string) (ciolite.DeleteConnectTokenResponse, error) {
+	mock.record("DeleteUserEmailAccountConnectToken", userID, label, token)
+	if mock.DeleteUserEmailAccountConnectTokenFunc == nil {
+		return ciolite.DeleteConnectTokenResponse{}, notConfigured("DeleteUserEmailAccountConnectToken")
+	}
+	return mock.DeleteUserEmailAccountConnectTokenFunc(ctx, userID, label, token)
+}
+
+// GetOAuthProviders implements ciolite.Client
+func (mock *Mock) GetOAuthProviders() ([]ciolite.GetOAuthProvidersResponse, error) {
+	return mock.GetOAuthProvidersContext(context.Background())
+}
+
+// GetOAuthProvidersContext implements ciolite.Client
+func (mock *Mock) GetOAuthProvidersContext(ctx context.Context) ([]ciolite.GetOAuthProvidersResponse, error) {
+	mock.record("GetOAuthProviders")
+	if mock.GetOAuthProvidersFunc == nil {
+		return nil, notConfigured("GetOAuthProviders")
+	}
+	return mock.GetOAuthProvidersFunc(ctx)
+}
+
+// GetOAuthProvider implements ciolite.Client
+func (mock *Mock) GetOAuthProvider(key string) (ciolite.GetOAuthProvidersResponse, error) {
+	return mock.GetOAuthProviderContext(context.Background(), key)
+}
+
+// GetOAuthProviderContext implements ciolite.Client
+func (mock *Mock) GetOAuthProviderContext(ctx context.Context, key string) (ciolite.GetOAuthProvidersResponse, error) {
+	mock.record("GetOAuthProvider", key)
+	if mock.GetOAuthProviderFunc == nil {
+		return ciolite.GetOAuthProvidersResponse{}, notConfigured("GetOAuthProvider")
+	}
+	return mock.GetOAuthProviderFunc(ctx, key)
+}
+
+// CreateOAuthProvider implements ciolite.Client
+func (mock *Mock) CreateOAuthProvider(formValues ciolite.CreateOAuthProviderParams) (ciolite.CreateOAuthProviderResponse, error) {
+	return mock.CreateOAuthProviderContext(context.Background(), formValues)
+}
+
+// CreateOAuthProviderContext implements ciolite.Client
+func (mock *Mock) CreateOAuthProviderContext(ctx context.Context, formValues ciolite.CreateOAuthProviderParams) (ciolite.CreateOAuthProviderResponse, error) {
+	mock.record("CreateOAuthProvider", formValues)
+	if mock.CreateOAuthProviderFunc == nil {
+		return ciolite.CreateOAuthProviderResponse{}, notConfigured("CreateOAuthProvider")
+	}
+	return mock.CreateOAuthProviderFunc(ctx, formValues)
+}
+
+// DeleteOAuthProvider implements ciolite.Client
+func (mock *Mock) DeleteOAuthProvider(key string) (ciolite.DeleteOAuthProviderResponse, error) {
+	return mock.DeleteOAuthProviderContext(context.Background(), key)
+}
+
+// DeleteOAuthProviderContext implements ciolite.Client
+func (mock *Mock) DeleteOAuthProviderContext(ctx context.Context, key string) (ciolite.DeleteOAuthProviderResponse, error) {
+	mock.record("DeleteOAuthProvider", key)
+	if mock.DeleteOAuthProviderFunc == nil {
+		return ciolite.DeleteOAuthProviderResponse{}, notConfigured("DeleteOAuthProvider")
+	}
+	return mock.DeleteOAuthProviderFunc(ctx, key)
+}
+
+// GetDiscovery implements ciolite.Client
+func (mock *Mock) GetDiscovery(queryValues ciolite.GetDiscoveryParams) (ciolite.GetDiscoveryResponse, error) {
+	return mock.GetDiscoveryContext(context.Background(), queryValues)
+}
+
+// GetDiscoveryContext implements ciolite.Client
+func (mock *Mock) GetDiscoveryContext(ctx context.Context, queryValues ciolite.GetDiscoveryParams) (ciolite.GetDiscoveryResponse, error) {
+	mock.record("GetDiscovery", queryValues)
+	if mock.GetDiscoveryFunc == nil {
+		return ciolite.GetDiscoveryResponse{}, notConfigured("GetDiscovery")
+	}
+	return mock.GetDiscoveryFunc(ctx, queryValues)
+}
+
+// GetStatusCallbackURL implements ciolite.Client
+func (mock *Mock) GetStatusCallbackURL() (ciolite.GetStatusCallbackURLResponse, error) {
+	return mock.GetStatusCallbackURLContext(context.Background())
+}
+
+// GetStatusCallbackURLContext implements ciolite.Client
+func (mock *Mock) GetStatusCallbackURLContext(ctx context.Context) (ciolite.GetStatusCallbackURLResponse, error) {
+	mock.record("GetStatusCallbackURL")
+	if mock.GetStatusCallbackURLFunc == nil {
+		return ciolite.GetStatusCallbackURLResponse{}, notConfigured("GetStatusCallbackURL")
+	}
+	return mock.GetStatusCallbackURLFunc(ctx)
+}
+
+// CreateStatusCallbackURL implements ciolite.Client
+func (mock *Mock) CreateStatusCallbackURL(formValues ciolite.CreateStatusCallbackURLParams) (ciolite.CreateDeleteStatusCallbackURLResponse, error) {
+	return mock.CreateStatusCallbackURLContext(context.Background(), formValues)
+}
+
+// CreateStatusCallbackURLContext implements ciolite.Client
+func (mock *Mock) CreateStatusCallbackURLContext(ctx context.Context, formValues ciolite.CreateStatusCallbackURLParams) (ciolite.CreateDeleteStatusCallbackURLResponse, error) {
+	mock.record("CreateStatusCallbackURL", formValues)
+	if mock.CreateStatusCallbackURLFunc == nil {
+		return ciolite.CreateDeleteStatusCallbackURLResponse{}, notConfigured("CreateStatusCallbackURL")
+	}
+	return mock.CreateStatusCallbackURLFunc(ctx, formValues)
+}
+
+// DeleteStatusCallbackURL implements ciolite.Client
+func (mock *Mock) DeleteStatusCallbackURL() (ciolite.CreateDeleteStatusCallbackURLResponse, error) {
+	return mock.DeleteStatusCallbackURLContext(context.Background())
+}
+
+// DeleteStatusCallbackURLContext implements ciolite.Client
+func (mock *Mock) DeleteStatusCallbackURLContext(ctx context.Context) (ciolite.CreateDeleteStatusCallbackURLResponse, error) {
+	mock.record("DeleteStatusCallbackURL")
+	if mock.DeleteStatusCallbackURLFunc == nil {
+		return ciolite.CreateDeleteStatusCallbackURLResponse{}, notConfigured("DeleteStatusCallbackURL")
+	}
+	return mock.DeleteStatusCallbackURLFunc(ctx)
+}
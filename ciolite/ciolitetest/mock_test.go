@@ -0,0 +1,66 @@
+package ciolitetest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/contextio/contextio-go/ciolite"
+	"github.com/pkg/errors"
+)
+
+// countUnseen is an example of code that depends on a ciolite.Client
+func countUnseen(client ciolite.Client, userID string, label string) (int, error) {
+	folders, err := client.GetUserEmailAccountsFolders(userID, label, ciolite.GetUserEmailAccountsFoldersParams{})
+	if err != nil {
+		return 0, err
+	}
+	unseen := 0
+	for _, folder := range folders {
+		unseen += folder.NbUnseenMessages
+	}
+	return unseen, nil
+}
+
+func TestMock(t *testing.T) {
+	t.Parallel()
+
+	mock := &Mock{
+		GetUserEmailAccountsFoldersFunc: func(ctx context.Context, userID string, label string, queryValues ciolite.GetUserEmailAccountsFoldersParams) ([]ciolite.GetUsersEmailAccountFoldersResponse, error) {
+			if ctx == nil {
+				t.Error("Expected a context")
+			}
+			return []ciolite.GetUsersEmailAccountFoldersResponse{{Name: "INBOX", NbUnseenMessages: 2}, {Name: "Sent", NbUnseenMessages: 1}}, nil
+		},
+	}
+
+	unseen, err := countUnseen(mock, "user", "label")
+	if err != nil || unseen != 3 {
+		t.Error("Expected: 3; Got: ", unseen, "; With error: ", err)
+	}
+
+	calls := mock.CallsTo("GetUserEmailAccountsFolders")
+	if len(calls) != 1 || len(calls[0].Args) != 3 || calls[0].Args[0] != "user" || calls[0].Args[1] != "label" {
+		t.Error("Got unexpected calls: ", mock.Calls())
+	}
+
+	mock.Reset()
+	if len(mock.Calls()) != 0 {
+		t.Error("Expected no calls after Reset; Got: ", mock.Calls())
+	}
+}
+
+func TestMockNotConfigured(t *testing.T) {
+	t.Parallel()
+
+	mock := &Mock{}
+	user, err := mock.GetUserContext(context.Background(), "user")
+	if errors.Cause(err) != ErrNotConfigured {
+		t.Error("Expected: ", ErrNotConfigured, "; Got: ", err)
+	}
+	if user.ID != "" {
+		t.Error("Expected the zero value; Got: ", user)
+	}
+	if len(mock.CallsTo("GetUser")) != 1 {
+		t.Error("Expected the call to be recorded; Got: ", mock.Calls())
+	}
+}
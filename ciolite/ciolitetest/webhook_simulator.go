@@ -0,0 +1,234 @@
+package ciolitetest
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/contextio/contextio-go/ciolite"
+	"github.com/pkg/errors"
+)
+
+// WebhookSimulator builds Webhook Callbacks and User Account Status Callbacks as CIO sends them,
+// signed with the api secret (so they pass ciolite.CioLite.ValidateCallback), and delivers them
+// to a url or directly to an http.Handler (such as a ciolite.WebhookHandler).
+// 	https://context.io/docs/lite/users/webhooks#callbacks
+type WebhookSimulator struct {
+	// UserID is the ID of the user the callbacks are about (sent as the AccountID and UserID)
+	UserID string
+
+	// Label is the label of the email account the callbacks are about
+	Label string
+
+	// Email is the email address of the email account the callbacks are about
+	Email string
+
+	// WebhookID is the ID of the webhook sending the Webhook Callbacks
+	WebhookID string
+
+	// HTTPClient is used by Post (http.DefaultClient if nil)
+	HTTPClient *http.Client
+
+	// Now returns the time of the callbacks' timestamps (time.Now if nil)
+	Now func() time.Time
+
+	cioLite ciolite.CioLite
+}
+
+// NewWebhookSimulator returns a new *WebhookSimulator that signs callbacks with the api secret
+func NewWebhookSimulator(secret string) *WebhookSimulator {
+	return &WebhookSimulator{cioLite: ciolite.NewCioLite("", secret)}
+}
+
+// Sign returns the signature of a callback with the token and timestamp
+func (simulator *WebhookSimulator) Sign(token string, timestamp int) string {
+	return simulator.cioLite.SignCallback(token, timestamp)
+}
+
+// MessageCallback returns a signed Webhook Callback for the message (as listed by CIO) in the folder.
+// The message's bodies are described, but their content is not included (see RawMessageCallback).
+func (simulator *WebhookSimulator) MessageCallback(folder string, message ciolite.GetUsersEmailAccountFolderMessagesResponse) ciolite.WebhookCallback {
+	account := ciolite.WebhookMessageDataAccount{Label: simulator.Label, Folder: folder}
+	data := ciolite.WebhookMessageData{
+		MessageID:      message.MessageID,
+		EmailMessageID: message.EmailMessageID,
+		Subject:        message.Subject,
+		References:     message.References,
+		Folders:        []string{folder},
+		Date:           message.SentAt,
+		DateReceived:   message.ReceivedAt,
+		Addresses: ciolite.WebhookMessageDataAddresses{
+			To:      message.Addresses.To,
+			Cc:      message.Addresses.Cc,
+			Bcc:     message.Addresses.Bcc,
+			Sender:  message.Addresses.Sender,
+			ReplyTo: message.Addresses.ReplyTo,
+		},
+		PersonInfo: message.PersonInfo,
+		Flags: ciolite.WebhookMessageDataFlags{
+			Flagged:  message.Flags.Flagged,
+			Answered: message.Flags.Answered,
+			Draft:    message.Flags.Draft,
+			Seen:     message.Flags.Read,
+		},
+		Sources:       []ciolite.WebhookMessageDataAccount{account},
+		EmailAccounts: []ciolite.WebhookMessageDataAccount{account},
+	}
+	if len(message.Addresses.From) > 0 {
+		data.Addresses.From = message.Addresses.From[0]
+	}
+	for _, attachment := range message.Attachments {
+		data.Files = append(data.Files, ciolite.WebhookMessageDataFile{
+			Type:               attachment.Type,
+			FileName:           attachment.FileName,
+			MainFileName:       attachment.FileName,
+			BodySection:        attachment.BodySection,
+			ContentDisposition: attachment.ContentDisposition,
+			AttachmentID:       attachment.AttachmentID,
+			Size:               attachment.Size,
+		})
+	}
+	for _, body := range message.Bodies {
+		data.Bodies = append(data.Bodies, ciolite.WebhookBody{Type: body.Type, BodySection: body.BodySection})
+	}
+
+	return simulator.webhookCallback(data, "")
+}
+
+// RawMessageCallback parses the raw RFC 822 message (ex: a fixture file), and returns a signed
+// Webhook Callback for it in the folder, including its headers and the content of its bodies
+// (as sent by a webhook with IncludeBody and IncludeHeader set).
+func (simulator *WebhookSimulator) RawMessageCallback(folder string, raw io.Reader) (ciolite.WebhookCallback, error) {
+	parsed, err := ciolite.ParseRawMessage(raw)
+	if err != nil {
+		return ciolite.WebhookCallback{}, err
+	}
+
+	callback := simulator.MessageCallback(folder, messageListing(newToken(), parsed))
+	callback.MessageData.Headers = parsed.Header
+	callback.MessageData.Bodies = nil
+	for _, body := range parsed.Bodies {
+		charset := body.Charset
+		if body.Converted {
+			charset = "UTF-8"
+		}
+		callback.MessageData.Bodies = append(callback.MessageData.Bodies, ciolite.WebhookBody{
+			Type:        body.Type,
+			Charset:     charset,
+			BodySection: body.BodySection,
+			Content:     body.Content,
+		})
+	}
+	return callback, nil
+}
+
+// FailureCallback returns a signed failure notification, as sent to a webhook's FailureNotifURL
+// when CIO stops being able to deliver its callbacks (ex: because the email account failed)
+func (simulator *WebhookSimulator) FailureCallback(data string) ciolite.WebhookCallback {
+	return simulator.webhookCallback(ciolite.WebhookMessageData{}, data)
+}
+
+// StatusCallback returns a signed User Account Status Callback, reporting the status of the email account.
+// A status other than ciolite.AccountStatusOK is reported as a failure (ex: invalid_credentials),
+// with the failure message.
+func (simulator *WebhookSimulator) StatusCallback(status ciolite.AccountStatus, failureMessage string) ciolite.StatusCallback {
+	callback := ciolite.StatusCallback{
+		AccountID:    simulator.UserID,
+		UserID:       simulator.UserID,
+		ServerLabel:  simulator.Label,
+		EmailAccount: simulator.Email,
+		Token:        newToken(),
+		Timestamp:    simulator.timestamp(),
+	}
+	if status != ciolite.AccountStatusOK {
+		callback.Failure = strings.ToLower(string(status))
+		callback.FailureMessage = failureMessage
+	}
+	callback.Signature = simulator.Sign(callback.Token, callback.Timestamp)
+	return callback
+}
+
+// Post sends the callback (a ciolite.WebhookCallback or ciolite.StatusCallback) to the url
+// as CIO does, and returns the status code of the response
+func (simulator *WebhookSimulator) Post(url string, callback interface{}) (int, error) {
+	return simulator.PostContext(context.Background(), url, callback)
+}
+
+// PostContext is the same as Post, but uses the provided context.Context
+// for cancellation and deadlines of the request.
+func (simulator *WebhookSimulator) PostContext(ctx context.Context, url string, callback interface{}) (int, error) {
+	body, err := json.Marshal(callback)
+	if err != nil {
+		return 0, errors.Wrap(err, "ciolitetest: Unable to marshal callback")
+	}
+	req, err := http.NewRequest("POST", url, bytes.NewReader(body))
+	if err != nil {
+		return 0, errors.Wrap(err, "ciolitetest: Unable to create callback request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := simulator.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	res, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return 0, errors.Wrap(err, "ciolitetest: Unable to post callback")
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(ioutil.Discard, res.Body)
+	return res.StatusCode, nil
+}
+
+// Deliver calls the handler with the callback (a ciolite.WebhookCallback or ciolite.StatusCallback)
+// as CIO would post it, and returns the recorded response
+func (simulator *WebhookSimulator) Deliver(handler http.Handler, callback interface{}) *httptest.ResponseRecorder {
+	body, err := json.Marshal(callback)
+	if err != nil {
+		panic("ciolitetest: Unable to marshal callback: " + err.Error())
+	}
+	req := httptest.NewRequest("POST", "/", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+	return recorder
+}
+
+// webhookCallback returns a signed Webhook Callback with the message data, or the failure data
+func (simulator *WebhookSimulator) webhookCallback(messageData ciolite.WebhookMessageData, data string) ciolite.WebhookCallback {
+	callback := ciolite.WebhookCallback{
+		AccountID:   simulator.UserID,
+		WebhookID:   simulator.WebhookID,
+		Token:       newToken(),
+		Timestamp:   simulator.timestamp(),
+		Data:        data,
+		MessageData: messageData,
+	}
+	callback.Signature = simulator.Sign(callback.Token, callback.Timestamp)
+	return callback
+}
+
+// timestamp returns the current Unix timestamp, according to Now
+func (simulator *WebhookSimulator) timestamp() int {
+	if simulator.Now != nil {
+		return int(simulator.Now().Unix())
+	}
+	return int(time.Now().Unix())
+}
+
+// newToken returns a new random token, so that callbacks are not mistaken for replays
+func newToken() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic("ciolitetest: Unable to read random bytes: " + err.Error())
+	}
+	return hex.EncodeToString(b)
+}
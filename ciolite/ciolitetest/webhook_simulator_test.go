@@ -0,0 +1,109 @@
+package ciolitetest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/contextio/contextio-go/ciolite"
+)
+
+func TestWebhookSimulatorMessages(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	var messages, failures []ciolite.WebhookCallback
+	handler := ciolite.NewWebhookHandler(ciolite.NewCioLite("key", "secret"), ciolite.WebhookHandlerOptions{
+		OnMessage: func(ctx context.Context, callback ciolite.WebhookCallback) error {
+			mu.Lock()
+			defer mu.Unlock()
+			messages = append(messages, callback)
+			return nil
+		},
+		OnFailure: func(ctx context.Context, callback ciolite.WebhookCallback) error {
+			mu.Lock()
+			defer mu.Unlock()
+			failures = append(failures, callback)
+			return nil
+		},
+	})
+
+	simulator := NewWebhookSimulator("secret")
+	simulator.UserID = "user1"
+	simulator.Label = "0::imap.example.com"
+	simulator.WebhookID = "webhook1"
+
+	callback, err := simulator.RawMessageCallback("INBOX", strings.NewReader(fakeServerTestMessage))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if recorder := simulator.Deliver(handler, callback); recorder.Code != http.StatusOK {
+		t.Error("Expected: ", http.StatusOK, "; Got: ", recorder.Code)
+	}
+
+	// Delivered over http, along with a failure notification
+	server := httptest.NewServer(handler)
+	defer server.Close()
+	if statusCode, err := simulator.Post(server.URL, simulator.FailureCallback("Account failed")); err != nil || statusCode != http.StatusOK {
+		t.Error("Expected: ", http.StatusOK, "; Got: ", statusCode, "; With Error: ", err)
+	}
+
+	// Signed with the wrong secret
+	wrong := NewWebhookSimulator("wrong")
+	if statusCode, err := wrong.Post(server.URL, wrong.FailureCallback("Account failed")); err != nil || statusCode != http.StatusUnauthorized {
+		t.Error("Expected: ", http.StatusUnauthorized, "; Got: ", statusCode, "; With Error: ", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(messages) != 1 || len(failures) != 1 {
+		t.Fatal("Expected 1 message and 1 failure; Got: ", messages, failures)
+	}
+	data := messages[0].MessageData
+	if messages[0].AccountID != "user1" || messages[0].WebhookID != "webhook1" || data.Subject != "Lunch" ||
+		data.Addresses.From.Email != "alice@example.com" || data.Folders[0] != "INBOX" || data.EmailAccounts[0].Label != "0::imap.example.com" {
+		t.Error("Expected the message's callback; Got: ", messages[0])
+	}
+	if len(data.Bodies) != 1 || data.Bodies[0].Content != "Noon?" || len(data.Files) != 1 || data.Files[0].FileName != "menu.txt" {
+		t.Error("Expected the message's body and file; Got: ", data.Bodies, data.Files)
+	}
+	if !failures[0].IsFailure() || failures[0].Data != "Account failed" {
+		t.Error("Expected a failure notification; Got: ", failures[0])
+	}
+}
+
+func TestWebhookSimulatorStatus(t *testing.T) {
+	t.Parallel()
+
+	var statuses []ciolite.StatusCallback
+	handler := ciolite.NewStatusCallbackHandler(ciolite.NewCioLite("key", "secret"), ciolite.StatusCallbackHandlerOptions{
+		OnStatus: func(ctx context.Context, callback ciolite.StatusCallback) error {
+			statuses = append(statuses, callback)
+			return nil
+		},
+	})
+
+	simulator := NewWebhookSimulator("secret")
+	simulator.UserID = "user1"
+	simulator.Label = "0::imap.example.com"
+	simulator.Email = "bob@example.com"
+
+	for _, status := range []ciolite.AccountStatus{ciolite.AccountStatusInvalidCredentials, ciolite.AccountStatusOK} {
+		if recorder := simulator.Deliver(handler, simulator.StatusCallback(status, "Bad password")); recorder.Code != http.StatusOK {
+			t.Error("Expected: ", http.StatusOK, "; Got: ", recorder.Code)
+		}
+	}
+
+	if len(statuses) != 2 {
+		t.Fatal("Expected 2 status callbacks; Got: ", statuses)
+	}
+	if statuses[0].FailureReason() != ciolite.FailureInvalidCredentials || statuses[0].FailureMessage != "Bad password" || statuses[0].EmailAccount != "bob@example.com" {
+		t.Error("Expected an invalid credentials failure; Got: ", statuses[0])
+	}
+	if statuses[1].IsFailure() {
+		t.Error("Expected no failure; Got: ", statuses[1])
+	}
+}
@@ -0,0 +1,149 @@
+package ciolite
+
+import "context"
+
+// Client is the interface of the CIO Lite endpoints, implemented by CioLite.
+// Code that depends on a Client rather than a CioLite can be unit tested with a fake or mock,
+// such as the ciolitetest.Mock.
+type Client interface {
+	// Users
+	GetUsers(queryValues GetUsersParams) ([]GetUsersResponse, error)
+	GetUsersContext(ctx context.Context, queryValues GetUsersParams) ([]GetUsersResponse, error)
+	GetUser(userID string) (GetUsersResponse, error)
+	GetUserContext(ctx context.Context, userID string) (GetUsersResponse, error)
+	CreateUser(formValues CreateUserParams) (CreateUserResponse, error)
+	CreateUserContext(ctx context.Context, formValues CreateUserParams) (CreateUserResponse, error)
+	ModifyUser(userID string, formValues ModifyUserParams) (ModifyUserResponse, error)
+	ModifyUserContext(ctx context.Context, userID string, formValues ModifyUserParams) (ModifyUserResponse, error)
+	DeleteUser(userID string) (DeleteUserResponse, error)
+	DeleteUserContext(ctx context.Context, userID string) (DeleteUserResponse, error)
+
+	// Email accounts
+	GetUserEmailAccounts(userID string, queryValues GetUserEmailAccountsParams) ([]GetUsersEmailAccountsResponse, error)
+	GetUserEmailAccountsContext(ctx context.Context, userID string, queryValues GetUserEmailAccountsParams) ([]GetUsersEmailAccountsResponse, error)
+	GetUserEmailAccount(userID string, label string) (GetUsersEmailAccountsResponse, error)
+	GetUserEmailAccountContext(ctx context.Context, userID string, label string) (GetUsersEmailAccountsResponse, error)
+	CreateUserEmailAccount(userID string, formValues CreateUserParams) (CreateEmailAccountResponse, error)
+	CreateUserEmailAccountContext(ctx context.Context, userID string, formValues CreateUserParams) (CreateEmailAccountResponse, error)
+	ModifyUserEmailAccount(userID string, label string, formValues ModifyUserEmailAccountParams) (ModifyEmailAccountResponse, error)
+	ModifyUserEmailAccountContext(ctx context.Context, userID string, label string, formValues ModifyUserEmailAccountParams) (ModifyEmailAccountResponse, error)
+	DeleteUserEmailAccount(userID string, label string) (DeleteEmailAccountResponse, error)
+	DeleteUserEmailAccountContext(ctx context.Context, userID string, label string) (DeleteEmailAccountResponse, error)
+
+	// Folders
+	GetUserEmailAccountsFolders(userID string, label string, queryValues GetUserEmailAccountsFoldersParams) ([]GetUsersEmailAccountFoldersResponse, error)
+	GetUserEmailAccountsFoldersContext(ctx context.Context, userID string, label string, queryValues GetUserEmailAccountsFoldersParams) ([]GetUsersEmailAccountFoldersResponse, error)
+	GetUserEmailAccountFolder(userID string, label string, folder string, queryValues EmailAccountFolderDelimiterParam) (GetUsersEmailAccountFoldersResponse, error)
+	GetUserEmailAccountFolderContext(ctx context.Context, userID string, label string, folder string, queryValues EmailAccountFolderDelimiterParam) (GetUsersEmailAccountFoldersResponse, error)
+	CreateUserEmailAccountFolder(userID string, label string, folder string, formValues EmailAccountFolderDelimiterParam) (CreateEmailAccountFolderResponse, error)
+	CreateUserEmailAccountFolderContext(ctx context.Context, userID string, label string, folder string, formValues EmailAccountFolderDelimiterParam) (CreateEmailAccountFolderResponse, error)
+	DeleteUserEmailAccountFolder(userID string, label string, folder string, formValues EmailAccountFolderDelimiterParam) (DeleteEmailAccountFolderResponse, error)
+	DeleteUserEmailAccountFolderContext(ctx context.Context, userID string, label string, folder string, formValues EmailAccountFolderDelimiterParam) (DeleteEmailAccountFolderResponse, error)
+	RenameUserEmailAccountFolder(userID string, label string, folder string, formValues RenameUserEmailAccountFolderParams) (RenameEmailAccountFolderResponse, error)
+	RenameUserEmailAccountFolderContext(ctx context.Context, userID string, label string, folder string, formValues RenameUserEmailAccountFolderParams) (RenameEmailAccountFolderResponse, error)
+
+	// Messages
+	GetUserEmailAccountsFolderMessages(userID string, label string, folder string, queryValues GetUserEmailAccountsFolderMessageParams) ([]GetUsersEmailAccountFolderMessagesResponse, error)
+	GetUserEmailAccountsFolderMessagesContext(ctx context.Context, userID string, label string, folder string, queryValues GetUserEmailAccountsFolderMessageParams) ([]GetUsersEmailAccountFolderMessagesResponse, error)
+	GetUserEmailAccountFolderMessage(userID string, label string, folder string, messageID string, queryValues GetUserEmailAccountsFolderMessageParams) (GetUsersEmailAccountFolderMessagesResponse, error)
+	GetUserEmailAccountFolderMessageContext(ctx context.Context, userID string, label string, folder string, messageID string, queryValues GetUserEmailAccountsFolderMessageParams) (GetUsersEmailAccountFolderMessagesResponse, error)
+	MoveUserEmailAccountFolderMessage(userID string, label string, folder string, messageID string, queryValues MoveUserEmailAccountFolderMessageParams) (MoveUserEmailAccountFolderMessageResponse, error)
+	MoveUserEmailAccountFolderMessageContext(ctx context.Context, userID string, label string, folder string, messageID string, queryValues MoveUserEmailAccountFolderMessageParams) (MoveUserEmailAccountFolderMessageResponse, error)
+	MoveUserEmailAccountFolderMessage2(userID string, label string, folder string, messageID string, queryValues MoveUserEmailAccountFolderMessageParams) (MoveUserEmailAccountFolderMessageResponse, error)
+	MoveUserEmailAccountFolderMessage2Context(ctx context.Context, userID string, label string, folder string, messageID string, queryValues MoveUserEmailAccountFolderMessageParams) (MoveUserEmailAccountFolderMessageResponse, error)
+	GetUserEmailAccountsFolderMessageAttachments(userID string, label string, folder string, messageID string, queryValues EmailAccountFolderDelimiterParam) ([]GetUserEmailAccountsFolderMessageAttachmentsResponse, error)
+	GetUserEmailAccountsFolderMessageAttachmentsContext(ctx context.Context, userID string, label string, folder string, messageID string, queryValues EmailAccountFolderDelimiterParam) ([]GetUserEmailAccountsFolderMessageAttachmentsResponse, error)
+	GetUserEmailAccountsFolderMessageAttachment(userID string, label string, folder string, messageID string, attachmentID string, queryValues EmailAccountFolderDelimiterParam) (GetUserEmailAccountsFolderMessageAttachmentsResponse, error)
+	GetUserEmailAccountsFolderMessageAttachmentContext(ctx context.Context, userID string, label string, folder string, messageID string, attachmentID string, queryValues EmailAccountFolderDelimiterParam) (GetUserEmailAccountsFolderMessageAttachmentsResponse, error)
+	GetUserEmailAccountsFolderMessageAttachmentContent(userID string, label string, folder string, messageID string, attachmentID string, queryValues EmailAccountFolderDelimiterParam) (*AttachmentContent, error)
+	GetUserEmailAccountsFolderMessageAttachmentContentContext(ctx context.Context, userID string, label string, folder string, messageID string, attachmentID string, queryValues EmailAccountFolderDelimiterParam) (*AttachmentContent, error)
+	GetUserEmailAccountsFolderMessageBody(userID string, label string, folder string, messageID string, queryValues GetUserEmailAccountsFolderMessageBodyParams) ([]GetUserEmailAccountsFolderMessageBodyResponse, error)
+	GetUserEmailAccountsFolderMessageBodyContext(ctx context.Context, userID string, label string, folder string, messageID string, queryValues GetUserEmailAccountsFolderMessageBodyParams) ([]GetUserEmailAccountsFolderMessageBodyResponse, error)
+	GetUserEmailAccountsFolderMessageFlags(userID string, label string, folder string, messageID string, queryValues EmailAccountFolderDelimiterParam) (GetUserEmailAccountsFolderMessageFlagsResponse, error)
+	GetUserEmailAccountsFolderMessageFlagsContext(ctx context.Context, userID string, label string, folder string, messageID string, queryValues EmailAccountFolderDelimiterParam) (GetUserEmailAccountsFolderMessageFlagsResponse, error)
+	SetUserEmailAccountsFolderMessageFlags(userID string, label string, folder string, messageID string, formValues SetUserEmailAccountsFolderMessageFlagsParams) (SetUserEmailAccountsFolderMessageFlagsResponse, error)
+	SetUserEmailAccountsFolderMessageFlagsContext(ctx context.Context, userID string, label string, folder string, messageID string, formValues SetUserEmailAccountsFolderMessageFlagsParams) (SetUserEmailAccountsFolderMessageFlagsResponse, error)
+	GetUserEmailAccountsFolderMessageHeaders(userID string, label string, folder string, messageID string, queryValues GetUserEmailAccountsFolderMessageHeadersParams) (GetUserEmailAccountsFolderMessageHeadersResponse, error)
+	GetUserEmailAccountsFolderMessageHeadersContext(ctx context.Context, userID string, label string, folder string, messageID string, queryValues GetUserEmailAccountsFolderMessageHeadersParams) (GetUserEmailAccountsFolderMessageHeadersResponse, error)
+	GetUserEmailAccountsFolderMessageRaw(userID string, label string, folder string, messageID string, queryValues EmailAccountFolderDelimiterParam) (GetUserEmailAccountsFolderMessageRawResponse, error)
+	GetUserEmailAccountsFolderMessageRawContext(ctx context.Context, userID string, label string, folder string, messageID string, queryValues EmailAccountFolderDelimiterParam) (GetUserEmailAccountsFolderMessageRawResponse, error)
+	StreamUserEmailAccountsFolderMessageRaw(userID string, label string, folder string, messageID string, queryValues EmailAccountFolderDelimiterParam) (*RawMessageStream, error)
+	StreamUserEmailAccountsFolderMessageRawContext(ctx context.Context, userID string, label string, folder string, messageID string, queryValues EmailAccountFolderDelimiterParam) (*RawMessageStream, error)
+	MarkUserEmailAccountsFolderMessageRead(userID string, label string, folder string, messageID string, formValues EmailAccountFolderDelimiterParam) (UserEmailAccountsFolderMessageReadResponse, error)
+	MarkUserEmailAccountsFolderMessageReadContext(ctx context.Context, userID string, label string, folder string, messageID string, formValues EmailAccountFolderDelimiterParam) (UserEmailAccountsFolderMessageReadResponse, error)
+	MarkUserEmailAccountsFolderMessageUnRead(userID string, label string, folder string, messageID string, formValues EmailAccountFolderDelimiterParam) (UserEmailAccountsFolderMessageReadResponse, error)
+	MarkUserEmailAccountsFolderMessageUnReadContext(ctx context.Context, userID string, label string, folder string, messageID string, formValues EmailAccountFolderDelimiterParam) (UserEmailAccountsFolderMessageReadResponse, error)
+
+	// Webhooks
+	GetWebhooks() ([]GetUsersWebhooksResponse, error)
+	GetWebhooksContext(ctx context.Context) ([]GetUsersWebhooksResponse, error)
+	GetWebhook(webhookID string) (GetUsersWebhooksResponse, error)
+	GetWebhookContext(ctx context.Context, webhookID string) (GetUsersWebhooksResponse, error)
+	CreateWebhook(formValues CreateUserWebhookParams) (CreateUserWebhookResponse, error)
+	CreateWebhookContext(ctx context.Context, formValues CreateUserWebhookParams) (CreateUserWebhookResponse, error)
+	ModifyWebhook(webhookID string, formValues ModifyUserWebhookParams) (ModifyWebhookResponse, error)
+	ModifyWebhookContext(ctx context.Context, webhookID string, formValues ModifyUserWebhookParams) (ModifyWebhookResponse, error)
+	DeleteWebhookAccount(webhookID string) (DeleteWebhookResponse, error)
+	DeleteWebhookAccountContext(ctx context.Context, webhookID string) (DeleteWebhookResponse, error)
+	GetUserWebhooks(userID string) ([]GetUsersWebhooksResponse, error)
+	GetUserWebhooksContext(ctx context.Context, userID string) ([]GetUsersWebhooksResponse, error)
+	GetUserWebhook(userID string, webhookID string) (GetUsersWebhooksResponse, error)
+	GetUserWebhookContext(ctx context.Context, userID string, webhookID string) (GetUsersWebhooksResponse, error)
+	CreateUserWebhook(userID string, formValues CreateUserWebhookParams) (CreateUserWebhookResponse, error)
+	CreateUserWebhookContext(ctx context.Context, userID string, formValues CreateUserWebhookParams) (CreateUserWebhookResponse, error)
+	ModifyUserWebhook(userID string, webhookID string, formValues ModifyUserWebhookParams) (ModifyWebhookResponse, error)
+	ModifyUserWebhookContext(ctx context.Context, userID string, webhookID string, formValues ModifyUserWebhookParams) (ModifyWebhookResponse, error)
+	DeleteUserWebhookAccount(userID string, webhookID string) (DeleteWebhookResponse, error)
+	DeleteUserWebhookAccountContext(ctx context.Context, userID string, webhookID string) (DeleteWebhookResponse, error)
+
+	// Connect tokens
+	GetConnectTokens() ([]GetConnectTokenResponse, error)
+	GetConnectTokensContext(ctx context.Context) ([]GetConnectTokenResponse, error)
+	GetConnectToken(token string) (GetConnectTokenResponse, error)
+	GetConnectTokenContext(ctx context.Context, token string) (GetConnectTokenResponse, error)
+	CreateConnectToken(formValues CreateConnectTokenParams) (CreateConnectTokenResponse, error)
+	CreateConnectTokenContext(ctx context.Context, formValues CreateConnectTokenParams) (CreateConnectTokenResponse, error)
+	DeleteConnectToken(token string) (DeleteConnectTokenResponse, error)
+	DeleteConnectTokenContext(ctx context.Context, token string) (DeleteConnectTokenResponse, error)
+	GetUserConnectTokens(userID string) ([]GetConnectTokenResponse, error)
+	GetUserConnectTokensContext(ctx context.Context, userID string) ([]GetConnectTokenResponse, error)
+	GetUserConnectToken(userID string, token string) (GetConnectTokenResponse, error)
+	GetUserConnectTokenContext(ctx context.Context, userID string, token string) (GetConnectTokenResponse, error)
+	CreateUserConnectToken(userID string, formValues CreateConnectTokenParams) (CreateConnectTokenResponse, error)
+	CreateUserConnectTokenContext(ctx context.Context, userID string, formValues CreateConnectTokenParams) (CreateConnectTokenResponse, error)
+	DeleteUserConnectToken(userID string, token string) (DeleteConnectTokenResponse, error)
+	DeleteUserConnectTokenContext(ctx context.Context, userID string, token string) (DeleteConnectTokenResponse, error)
+	GetUserEmailAccountConnectTokens(userID string, label string) ([]GetConnectTokenResponse, error)
+	GetUserEmailAccountConnectTokensContext(ctx context.Context, userID string, label string) ([]GetConnectTokenResponse, error)
+	GetUserEmailAccountConnectToken(userID string, label string, token string) (GetConnectTokenResponse, error)
+	GetUserEmailAccountConnectTokenContext(ctx context.Context, userID string, label string, token string) (GetConnectTokenResponse, error)
+	CreateUserEmailAccountConnectToken(userID string, label string, formValues CreateConnectTokenParams) (CreateConnectTokenResponse, error)
+	CreateUserEmailAccountConnectTokenContext(ctx context.Context, userID string, label string, formValues CreateConnectTokenParams) (CreateConnectTokenResponse, error)
+	DeleteUserEmailAccountConnectToken(userID string, label string, token string) (DeleteConnectTokenResponse, error)
+	DeleteUserEmailAccountConnectTokenContext(ctx context.Context, userID string, label string, token string) (DeleteConnectTokenResponse, error)
+
+	// OAuth providers
+	GetOAuthProviders() ([]GetOAuthProvidersResponse, error)
+	GetOAuthProvidersContext(ctx context.Context) ([]GetOAuthProvidersResponse, error)
+	GetOAuthProvider(key string) (GetOAuthProvidersResponse, error)
+	GetOAuthProviderContext(ctx context.Context, key string) (GetOAuthProvidersResponse, error)
+	CreateOAuthProvider(formValues CreateOAuthProviderParams) (CreateOAuthProviderResponse, error)
+	CreateOAuthProviderContext(ctx context.Context, formValues CreateOAuthProviderParams) (CreateOAuthProviderResponse, error)
+	DeleteOAuthProvider(key string) (DeleteOAuthProviderResponse, error)
+	DeleteOAuthProviderContext(ctx context.Context, key string) (DeleteOAuthProviderResponse, error)
+
+	// Discovery
+	GetDiscovery(queryValues GetDiscoveryParams) (GetDiscoveryResponse, error)
+	GetDiscoveryContext(ctx context.Context, queryValues GetDiscoveryParams) (GetDiscoveryResponse, error)
+
+	// Status callback URL
+	GetStatusCallbackURL() (GetStatusCallbackURLResponse, error)
+	GetStatusCallbackURLContext(ctx context.Context) (GetStatusCallbackURLResponse, error)
+	CreateStatusCallbackURL(formValues CreateStatusCallbackURLParams) (CreateDeleteStatusCallbackURLResponse, error)
+	CreateStatusCallbackURLContext(ctx context.Context, formValues CreateStatusCallbackURLParams) (CreateDeleteStatusCallbackURLResponse, error)
+	DeleteStatusCallbackURL() (CreateDeleteStatusCallbackURLResponse, error)
+	DeleteStatusCallbackURLContext(ctx context.Context) (CreateDeleteStatusCallbackURLResponse, error)
+}
+
+// CioLite must implement Client
+var _ Client = CioLite{}
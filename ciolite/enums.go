@@ -0,0 +1,122 @@
+package ciolite
+
+// Typed values of string fields in the params and responses. The fields themselves remain strings,
+// so convert between them, ex: AccountStatus(account.Status).Valid(), or Type: string(ProviderGmailOAuth)
+
+// AccountStatus is the status of an email account
+// 	https://context.io/docs/lite/users/email_accounts#get
+type AccountStatus string
+
+// AccountStatus values
+const (
+	AccountStatusOK                   AccountStatus = "OK"
+	AccountStatusInvalidCredentials   AccountStatus = "INVALID_CREDENTIALS"
+	AccountStatusConnectionImpossible AccountStatus = "CONNECTION_IMPOSSIBLE"
+	AccountStatusNoAccessToAllMail    AccountStatus = "NO_ACCESS_TO_ALL_MAIL"
+	AccountStatusTempDisabled         AccountStatus = "TEMP_DISABLED"
+	AccountStatusDisabled             AccountStatus = "DISABLED"
+)
+
+// Valid returns true if the AccountStatus is one of the known values
+func (status AccountStatus) Valid() bool {
+	switch status {
+	case AccountStatusOK, AccountStatusInvalidCredentials, AccountStatusConnectionImpossible,
+		AccountStatusNoAccessToAllMail, AccountStatusTempDisabled, AccountStatusDisabled:
+		return true
+	}
+	return false
+}
+
+// FailureReason returns the FailureReason matching the AccountStatus,
+// which is FailureNone for AccountStatusOK, and FailureUnknown for unknown values
+func (status AccountStatus) FailureReason() FailureReason {
+	if status == AccountStatusOK {
+		return FailureNone
+	}
+	return StatusCallback{Failure: string(status)}.FailureReason()
+}
+
+// AuthenticationType is how CIO authenticates with an email account
+// 	https://context.io/docs/lite/users/email_accounts#get
+type AuthenticationType string
+
+// AuthenticationType values
+const (
+	AuthenticationTypePassword AuthenticationType = "password"
+	AuthenticationTypeOAuth1   AuthenticationType = "oauth1"
+	AuthenticationTypeOAuth2   AuthenticationType = "oauth2"
+)
+
+// Valid returns true if the AuthenticationType is one of the known values
+func (authType AuthenticationType) Valid() bool {
+	switch authType {
+	case AuthenticationTypePassword, AuthenticationTypeOAuth1, AuthenticationTypeOAuth2:
+		return true
+	}
+	return false
+}
+
+// SourceType is the protocol of an email account
+// 	https://context.io/docs/lite/discovery#get
+// 	https://context.io/docs/lite/users#post
+type SourceType string
+
+// SourceType values
+const (
+	SourceTypeIMAP SourceType = "IMAP"
+)
+
+// Valid returns true if the SourceType is one of the known values
+func (sourceType SourceType) Valid() bool {
+	return sourceType == SourceTypeIMAP
+}
+
+// OAuthProviderType identifies an OAuth provider
+// 	https://context.io/docs/lite/oauth_providers#post
+type OAuthProviderType string
+
+// OAuthProviderType values
+const (
+	ProviderGmailOAuth    OAuthProviderType = "GMAIL_OAUTH"
+	ProviderMSLiveConnect OAuthProviderType = "MSLIVECONNECT"
+)
+
+// Valid returns true if the OAuthProviderType is one of the known values
+func (providerType OAuthProviderType) Valid() bool {
+	return providerType == ProviderGmailOAuth || providerType == ProviderMSLiveConnect
+}
+
+// IncludeHeaders is whether (and how) message headers are included in a response
+// 	https://context.io/docs/lite/users/email_accounts/folders/messages#get
+type IncludeHeaders string
+
+// IncludeHeaders values
+const (
+	IncludeHeadersNone   IncludeHeaders = "0"
+	IncludeHeadersParsed IncludeHeaders = "1"
+	IncludeHeadersRaw    IncludeHeaders = "raw"
+)
+
+// Valid returns true if the IncludeHeaders is one of the known values (or empty, for the default)
+func (includeHeaders IncludeHeaders) Valid() bool {
+	switch includeHeaders {
+	case "", IncludeHeadersNone, IncludeHeadersParsed, IncludeHeadersRaw:
+		return true
+	}
+	return false
+}
+
+// BodyType is the MIME type of the message bodies to include in a response
+// 	https://context.io/docs/lite/users/email_accounts/folders/messages#get
+type BodyType string
+
+// BodyType values
+const (
+	BodyTypePlain BodyType = "text/plain"
+	BodyTypeHTML  BodyType = "text/html"
+)
+
+// Valid returns true if the BodyType is one of the known values (or empty, for all types)
+func (bodyType BodyType) Valid() bool {
+	return bodyType == "" || bodyType == BodyTypePlain || bodyType == BodyTypeHTML
+}
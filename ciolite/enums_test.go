@@ -0,0 +1,57 @@
+package ciolite
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestAccountStatus(t *testing.T) {
+	t.Parallel()
+
+	if !AccountStatusInvalidCredentials.Valid() || AccountStatus("INVALID_CREDENTAILS").Valid() {
+		t.Error("Expected only known account statuses to be valid")
+	}
+	if AccountStatusOK.FailureReason() != FailureNone {
+		t.Error("Expected: ", FailureNone, "; Got: ", AccountStatusOK.FailureReason())
+	}
+	if AccountStatusInvalidCredentials.FailureReason() != FailureInvalidCredentials {
+		t.Error("Expected: ", FailureInvalidCredentials, "; Got: ", AccountStatusInvalidCredentials.FailureReason())
+	}
+
+	var account GetUsersEmailAccountsResponse
+	if err := json.Unmarshal([]byte(`{"status":"TEMP_DISABLED","type":"IMAP","authentication_type":"oauth2"}`), &account); err != nil {
+		t.Error("Expected no error; Got: ", err)
+	}
+	if AccountStatus(account.Status) != AccountStatusTempDisabled || SourceType(account.Type) != SourceTypeIMAP || AuthenticationType(account.AuthenticationType) != AuthenticationTypeOAuth2 {
+		t.Error("Got unexpected account: ", account)
+	}
+}
+
+func TestEnumsValid(t *testing.T) {
+	t.Parallel()
+
+	if !AuthenticationTypePassword.Valid() || AuthenticationType("oauth3").Valid() {
+		t.Error("Expected only known authentication types to be valid")
+	}
+	if !SourceTypeIMAP.Valid() || SourceType("POP3").Valid() {
+		t.Error("Expected only known source types to be valid")
+	}
+	if !ProviderGmailOAuth.Valid() || !ProviderMSLiveConnect.Valid() || OAuthProviderType("GMAIL").Valid() {
+		t.Error("Expected only known OAuth provider types to be valid")
+	}
+	if !IncludeHeaders("").Valid() || !IncludeHeadersRaw.Valid() || IncludeHeaders("true").Valid() {
+		t.Error("Expected only known include headers values to be valid")
+	}
+	if !BodyType("").Valid() || !BodyTypeHTML.Valid() || BodyType("text/xml").Valid() {
+		t.Error("Expected only known body types to be valid")
+	}
+}
+
+func TestEnumsQueryString(t *testing.T) {
+	t.Parallel()
+
+	query := queryString(GetUserEmailAccountsFolderMessageParams{BodyType: string(BodyTypePlain), IncludeHeaders: string(IncludeHeadersRaw)})
+	if query != "?body_type=text%2Fplain&include_headers=raw" {
+		t.Error("Expected: ?body_type=text%2Fplain&include_headers=raw; Got: ", query)
+	}
+}
@@ -0,0 +1,241 @@
+package ciolite
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// FolderSyncEventType is the kind of change reported by a FolderSyncEvent
+type FolderSyncEventType int
+
+// FolderSyncEventType values
+const (
+	// FolderSyncAdded means the message is new to the folder (or this is the first sync)
+	FolderSyncAdded FolderSyncEventType = iota + 1
+	// FolderSyncRemoved means the message is no longer in the folder (deleted, or moved elsewhere)
+	FolderSyncRemoved
+	// FolderSyncFlagsChanged means the message's flags are different from the last sync
+	FolderSyncFlagsChanged
+)
+
+// String returns the name of the FolderSyncEventType
+func (eventType FolderSyncEventType) String() string {
+	switch eventType {
+	case FolderSyncAdded:
+		return "Added"
+	case FolderSyncRemoved:
+		return "Removed"
+	case FolderSyncFlagsChanged:
+		return "FlagsChanged"
+	default:
+		return "Unknown"
+	}
+}
+
+// FolderSyncEvent is a change to a folder found by FolderSyncer.Sync
+type FolderSyncEvent struct {
+	Type FolderSyncEventType
+
+	// Message is the checkpointed state of the message: the current state if Added or FlagsChanged,
+	// or the last known state if Removed
+	Message SyncedMessage
+
+	// PreviousFlags are the flags from the last sync, if FlagsChanged
+	PreviousFlags UserEmailAccountsFolderMessageFlags
+
+	// Listing is the message as listed from the folder, if Added or FlagsChanged
+	Listing GetUsersEmailAccountFolderMessagesResponse
+}
+
+// SyncedMessage is the checkpointed state of a message in a FolderSyncState
+type SyncedMessage struct {
+	EmailMessageID string                              `json:"email_message_id,omitempty"`
+	MessageID      string                              `json:"message_id,omitempty"`
+	ReceivedAt     int                                 `json:"received_at,omitempty"`
+	Flags          UserEmailAccountsFolderMessageFlags `json:"flags,omitempty"`
+}
+
+// FolderSyncState is the checkpoint of a folder saved after each successful sync.
+// It only holds the messages in the folder at the time of the sync, so messages that are removed are pruned from it.
+// It is json encodable, so that FolderSyncStateStores can easily persist it.
+type FolderSyncState struct {
+	// Messages are keyed by both EmailMessageID and MessageID, so that distinct messages
+	// with the same (or no) Message-ID header are kept apart
+	Messages map[string]SyncedMessage `json:"messages"`
+
+	NbMessages       int `json:"nb_messages,omitempty"`
+	NbUnseenMessages int `json:"nb_unseen_messages,omitempty"`
+
+	SyncedAt time.Time `json:"synced_at"`
+}
+
+// FolderSyncStateStore persists the FolderSyncState of each folder between syncs.
+// Implementations must be safe for concurrent use if shared by concurrent FolderSyncers.
+type FolderSyncStateStore interface {
+	// Load returns the saved state of the folder, or nil (and no error) if it has never been synced
+	Load(ctx context.Context, userID string, label string, folder string) (*FolderSyncState, error)
+
+	// Save replaces the saved state of the folder
+	Save(ctx context.Context, userID string, label string, folder string, state *FolderSyncState) error
+}
+
+// MemoryFolderSyncStateStore is an in-memory FolderSyncStateStore
+type MemoryFolderSyncStateStore struct {
+	mu     sync.Mutex
+	states map[string]*FolderSyncState
+}
+
+// NewMemoryFolderSyncStateStore returns a new, empty, *MemoryFolderSyncStateStore
+func NewMemoryFolderSyncStateStore() *MemoryFolderSyncStateStore {
+	return &MemoryFolderSyncStateStore{states: make(map[string]*FolderSyncState)}
+}
+
+// Load implements FolderSyncStateStore
+func (store *MemoryFolderSyncStateStore) Load(ctx context.Context, userID string, label string, folder string) (*FolderSyncState, error) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	return store.states[userID+"/"+label+"/"+folder], nil
+}
+
+// Save implements FolderSyncStateStore
+func (store *MemoryFolderSyncStateStore) Save(ctx context.Context, userID string, label string, folder string, state *FolderSyncState) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	store.states[userID+"/"+label+"/"+folder] = state
+	return nil
+}
+
+// FolderSyncOptions configures a FolderSyncer.
+type FolderSyncOptions struct {
+	// PageSize is the number of messages listed per request. Defaults to DefaultPageSize.
+	PageSize int
+
+	// Delimiter is the folder delimiter, if not the account's default
+	Delimiter string
+
+	// QuickCheck skips listing the messages if the folder's NbMessages and NbUnseenMessages
+	// are unchanged since the last sync. This saves requests when polling often, but misses
+	// changes that do not affect the counts (ex: a message replaced by another, or a flag other than read).
+	QuickCheck bool
+}
+
+// FolderSyncer syncs a folder, finding the messages that were added, removed,
+// or had their flags changed since the last sync, and checkpointing its state in a FolderSyncStateStore.
+// It can be used to mirror a folder by polling, when webhooks are unavailable.
+// Every sync lists all of the folder's messages (unless skipped by QuickCheck), as CIO can not list
+// only the messages changed since a date, and removals can only be found by listing every message.
+type FolderSyncer struct {
+	cioLite CioLite
+	store   FolderSyncStateStore
+	userID  string
+	label   string
+	folder  string
+	options FolderSyncOptions
+}
+
+// NewFolderSyncer returns a *FolderSyncer for the folder, that keeps its state in the store
+func (cioLite CioLite) NewFolderSyncer(store FolderSyncStateStore, userID string, label string, folder string, options FolderSyncOptions) *FolderSyncer {
+	return &FolderSyncer{
+		cioLite: cioLite,
+		store:   store,
+		userID:  userID,
+		label:   label,
+		folder:  folder,
+		options: options,
+	}
+}
+
+// Sync lists the folder and calls handleEvent with each change since the last sync (on the first sync,
+// every message is Added), then saves the new state. If handleEvent returns an error, Sync stops and
+// returns it without saving, so that the same changes are found again by the next sync.
+func (syncer *FolderSyncer) Sync(ctx context.Context, handleEvent func(FolderSyncEvent) error) error {
+	previous, err := syncer.store.Load(ctx, syncer.userID, syncer.label, syncer.folder)
+	if err != nil {
+		return errors.Wrap(err, "CIO: Could not load folder sync state")
+	}
+
+	folderInfo, err := syncer.cioLite.GetUserEmailAccountFolderContext(ctx, syncer.userID, syncer.label, syncer.folder, EmailAccountFolderDelimiterParam{Delimiter: syncer.options.Delimiter})
+	if err != nil {
+		return err
+	}
+
+	if syncer.options.QuickCheck && previous != nil &&
+		previous.NbMessages == folderInfo.NbMessages && previous.NbUnseenMessages == folderInfo.NbUnseenMessages {
+		return nil
+	}
+
+	state := &FolderSyncState{
+		Messages:         make(map[string]SyncedMessage, folderInfo.NbMessages),
+		NbMessages:       folderInfo.NbMessages,
+		NbUnseenMessages: folderInfo.NbUnseenMessages,
+	}
+	if previous == nil {
+		previous = &FolderSyncState{}
+	}
+
+	// List every message, to find removals as well as additions
+	var events []FolderSyncEvent
+	iter := syncer.cioLite.NewFolderMessagesIterator(ctx, syncer.userID, syncer.label, syncer.folder, GetUserEmailAccountsFolderMessageParams{
+		Delimiter:    syncer.options.Delimiter,
+		IncludeFlags: true,
+		Limit:        syncer.options.PageSize,
+	})
+	for iter.Next() {
+		listing := iter.Value()
+		message := SyncedMessage{
+			EmailMessageID: listing.EmailMessageID,
+			MessageID:      listing.MessageID,
+			ReceivedAt:     listing.ReceivedAt,
+			Flags:          listing.Flags,
+		}
+		key := syncedMessageKey(message)
+		if _, ok := state.Messages[key]; ok {
+			// Seen already on an earlier page (the folder changed while paging)
+			continue
+		}
+		state.Messages[key] = message
+
+		previousMessage, ok := previous.Messages[key]
+		switch {
+		case !ok:
+			events = append(events, FolderSyncEvent{Type: FolderSyncAdded, Message: message, Listing: listing})
+		case previousMessage.Flags != message.Flags:
+			events = append(events, FolderSyncEvent{Type: FolderSyncFlagsChanged, Message: message, PreviousFlags: previousMessage.Flags, Listing: listing})
+		}
+	}
+	if err = iter.Err(); err != nil {
+		return err
+	}
+
+	var removed []FolderSyncEvent
+	for key, previousMessage := range previous.Messages {
+		if _, ok := state.Messages[key]; !ok {
+			removed = append(removed, FolderSyncEvent{Type: FolderSyncRemoved, Message: previousMessage})
+		}
+	}
+	sort.Slice(removed, func(i, j int) bool {
+		return syncedMessageKey(removed[i].Message) < syncedMessageKey(removed[j].Message)
+	})
+	events = append(events, removed...)
+
+	for _, event := range events {
+		if err = handleEvent(event); err != nil {
+			return err
+		}
+	}
+
+	state.SyncedAt = time.Now()
+	if err = syncer.store.Save(ctx, syncer.userID, syncer.label, syncer.folder, state); err != nil {
+		return errors.Wrap(err, "CIO: Could not save folder sync state")
+	}
+	return nil
+}
+
+// syncedMessageKey returns the key of the message in FolderSyncState.Messages
+func syncedMessageKey(message SyncedMessage) string {
+	return message.EmailMessageID + " " + message.MessageID
+}
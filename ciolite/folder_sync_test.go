@@ -0,0 +1,95 @@
+package ciolite
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+// TestSimulatedFolderSyncer tests that each sync reports the changes since the last successful one
+func TestSimulatedFolderSyncer(t *testing.T) {
+	t.Parallel()
+
+	cioLite, logger, testServer, mux := NewTestCioLiteWithLoggerAndTestServer(t)
+	defer testServer.Close()
+
+	messages := []GetUsersEmailAccountFolderMessagesResponse{
+		{EmailMessageID: "<m1@example.com>", MessageID: "m1", ReceivedAt: 100},
+		{EmailMessageID: "<m2@example.com>", MessageID: "m2", ReceivedAt: 200},
+		{EmailMessageID: "<m3@example.com>", MessageID: "m3", ReceivedAt: 300},
+	}
+	listings := 0
+	mux.HandleFunc("/lite/users/123abc/email_accounts/0/folders/INBOX", func(w http.ResponseWriter, r *http.Request) {
+		Must(json.NewEncoder(w).Encode(GetUsersEmailAccountFoldersResponse{Name: "INBOX", NbMessages: len(messages)}))
+	})
+	mux.HandleFunc("/lite/users/123abc/email_accounts/0/folders/INBOX/messages", func(w http.ResponseWriter, r *http.Request) {
+		listings++
+		if r.URL.Query().Get("include_flags") != "1" {
+			t.Error("Expected include_flags")
+		}
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		end := offset + limit
+		if end > len(messages) {
+			end = len(messages)
+		}
+		Must(json.NewEncoder(w).Encode(messages[offset:end]))
+	})
+
+	store := NewMemoryFolderSyncStateStore()
+	syncer := cioLite.NewFolderSyncer(store, "123abc", "0", "INBOX", FolderSyncOptions{PageSize: 2, QuickCheck: true})
+
+	sync := func() []string {
+		var events []string
+		err := syncer.Sync(context.Background(), func(event FolderSyncEvent) error {
+			events = append(events, event.Type.String()+":"+event.Message.MessageID)
+			return nil
+		})
+		if err != nil {
+			t.Error("Expected no error; Got: ", err, "; With Log: ", logger.String())
+		}
+		return events
+	}
+
+	// First sync adds everything
+	if events := sync(); strings.Join(events, " ") != "Added:m1 Added:m2 Added:m3" {
+		t.Error("Expected: ", "Added:m1 Added:m2 Added:m3", "; Got: ", events)
+	}
+
+	state, err := store.Load(context.Background(), "123abc", "0", "INBOX")
+	if err != nil || state == nil || len(state.Messages) != 3 || state.NbMessages != 3 {
+		t.Error("Unexpected state: ", state, "; With Error: ", err)
+	}
+
+	// Nothing changed, and the quick check skips the listing
+	if events := sync(); len(events) != 0 || listings != 2 {
+		t.Error("Expected no events and ", 2, " listings; Got: ", events, listings)
+	}
+
+	// One removed, one flagged, and added ones, including with the same or no Message-ID header
+	messages = []GetUsersEmailAccountFolderMessagesResponse{
+		{EmailMessageID: "<m1@example.com>", MessageID: "m1", ReceivedAt: 100, Flags: UserEmailAccountsFolderMessageFlags{Flagged: true}},
+		{EmailMessageID: "<m3@example.com>", MessageID: "m3", ReceivedAt: 300},
+		{EmailMessageID: "<m4@example.com>", MessageID: "m4", ReceivedAt: 400},
+		{EmailMessageID: "<m5@example.com>", MessageID: "m5", ReceivedAt: 500},
+		{EmailMessageID: "<m5@example.com>", MessageID: "m5b", ReceivedAt: 500},
+		{MessageID: "m6", ReceivedAt: 600},
+	}
+
+	// A failing handler should not save the state, so the same changes are found again
+	failure := errors.New("failed")
+	if err = syncer.Sync(context.Background(), func(FolderSyncEvent) error { return failure }); err != failure {
+		t.Error("Expected: ", failure, "; Got: ", err)
+	}
+
+	expected := []string{"FlagsChanged:m1", "Added:m4", "Added:m5", "Added:m5b", "Added:m6", "Removed:m2"}
+	if events := sync(); !reflect.DeepEqual(events, expected) {
+		t.Error("Expected: ", expected, "; Got: ", events)
+	}
+}
@@ -0,0 +1,196 @@
+package ciolite
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// SkipFolder can be returned by the function passed to FolderTree.Walk to skip the folder's children
+var SkipFolder = errors.New("CIO: Skip this folder")
+
+// FolderNode is a folder within a FolderTree
+type FolderNode struct {
+	// Name is the leaf name (ex: Q3), and Path is the full folder name (ex: Archive/2026/Q3)
+	Name string
+	Path string
+
+	Delimiter    string
+	SymbolicName string
+
+	// Exists is false for parent folders that were not in the listing (ex: non-selectable IMAP folders)
+	Exists bool
+
+	// NbMessages and NbUnseenMessages are for this folder only,
+	// and the Total ones include all of its descendants
+	NbMessages            int
+	NbUnseenMessages      int
+	TotalNbMessages       int
+	TotalNbUnseenMessages int
+
+	// Folder is the folder as listed by GetUserEmailAccountsFolders (the zero value if not Exists)
+	Folder GetUsersEmailAccountFoldersResponse
+
+	// Parent is nil for the top level folders, and Children are sorted by Name
+	Parent   *FolderNode
+	Children []*FolderNode
+}
+
+// Depth returns 0 for top level folders, 1 for their children, and so on
+func (node *FolderNode) Depth() int {
+	depth := 0
+	for parent := node.Parent; parent != nil; parent = parent.Parent {
+		depth++
+	}
+	return depth
+}
+
+// FolderTree is the nested structure of the folders of an email account,
+// built from the flat listing using each folder's Delimiter
+type FolderTree struct {
+	// Folders are the top level folders, sorted by Name
+	Folders []*FolderNode
+
+	byPath     map[string]*FolderNode
+	bySymbolic map[string]*FolderNode
+}
+
+// NewFolderTree builds a *FolderTree from a listing of folders (ex: from GetUserEmailAccountsFolders),
+// creating any missing parent folders
+func NewFolderTree(folders []GetUsersEmailAccountFoldersResponse) *FolderTree {
+	tree := &FolderTree{
+		byPath:     make(map[string]*FolderNode, len(folders)),
+		bySymbolic: make(map[string]*FolderNode),
+	}
+
+	for _, folder := range folders {
+		node := tree.node(folder.Name, folder.Delimiter)
+		node.Exists = true
+		node.Folder = folder
+		node.SymbolicName = folder.SymbolicName
+		node.NbMessages = folder.NbMessages
+		node.NbUnseenMessages = folder.NbUnseenMessages
+		if key := symbolicKey(folder.SymbolicName); len(key) > 0 {
+			if _, ok := tree.bySymbolic[key]; !ok {
+				tree.bySymbolic[key] = node
+			}
+		}
+	}
+
+	sortFolderNodes(tree.Folders)
+	for _, node := range tree.Folders {
+		aggregateFolderNode(node)
+	}
+
+	return tree
+}
+
+// node returns the node for the path, creating it and any missing parents
+func (tree *FolderTree) node(path string, delimiter string) *FolderNode {
+	if node, ok := tree.byPath[path]; ok {
+		if len(node.Delimiter) == 0 {
+			node.Delimiter = delimiter
+		}
+		return node
+	}
+
+	node := &FolderNode{Name: path, Path: path, Delimiter: delimiter}
+	tree.byPath[path] = node
+
+	if len(delimiter) > 0 {
+		if i := strings.LastIndex(path, delimiter); i > 0 && i+len(delimiter) < len(path) {
+			node.Name = path[i+len(delimiter):]
+			node.Parent = tree.node(path[:i], delimiter)
+			node.Parent.Children = append(node.Parent.Children, node)
+			return node
+		}
+	}
+
+	tree.Folders = append(tree.Folders, node)
+	return node
+}
+
+// Lookup returns the folder with the full path (ex: Archive/2026/Q3)
+func (tree *FolderTree) Lookup(path string) (*FolderNode, bool) {
+	node, ok := tree.byPath[path]
+	return node, ok
+}
+
+// LookupSymbolicName returns the folder with the symbolic (IMAP special-use) name, ex: \Sent.
+// The match is case-insensitive, and the leading backslash is optional.
+func (tree *FolderTree) LookupSymbolicName(symbolicName string) (*FolderNode, bool) {
+	node, ok := tree.bySymbolic[symbolicKey(symbolicName)]
+	return node, ok
+}
+
+// Walk calls the function for every folder, parents before their children,
+// stopping at the first error (other than SkipFolder, which skips the folder's children).
+func (tree *FolderTree) Walk(walkFunc func(node *FolderNode) error) error {
+	return walkFolderNodes(tree.Folders, walkFunc)
+}
+
+// walkFolderNodes walks the nodes and their children depth-first
+func walkFolderNodes(nodes []*FolderNode, walkFunc func(node *FolderNode) error) error {
+	for _, node := range nodes {
+		err := walkFunc(node)
+		if err == SkipFolder {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if err = walkFolderNodes(node.Children, walkFunc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sortFolderNodes sorts the nodes and all of their children by Name
+func sortFolderNodes(nodes []*FolderNode) {
+	sort.Slice(nodes, func(i, j int) bool {
+		return strings.ToLower(nodes[i].Name) < strings.ToLower(nodes[j].Name)
+	})
+	for _, node := range nodes {
+		sortFolderNodes(node.Children)
+	}
+}
+
+// aggregateFolderNode sums the message counts of the node and its descendants
+func aggregateFolderNode(node *FolderNode) {
+	node.TotalNbMessages = node.NbMessages
+	node.TotalNbUnseenMessages = node.NbUnseenMessages
+	for _, child := range node.Children {
+		aggregateFolderNode(child)
+		node.TotalNbMessages += child.TotalNbMessages
+		node.TotalNbUnseenMessages += child.TotalNbUnseenMessages
+	}
+}
+
+// symbolicKey normalizes a symbolic name (ex: \Sent, sent) for lookups,
+// including the non-standard aliases used by some providers (ex: \Spam for \Junk)
+func symbolicKey(symbolicName string) string {
+	key := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(symbolicName), `\`))
+	if alias, ok := symbolicAliases[key]; ok {
+		return alias
+	}
+	return key
+}
+
+// GetUserEmailAccountsFolderTree gets the folders in an email account as a *FolderTree.
+// 	https://context.io/docs/lite/users/email_accounts/folders#get
+func (cioLite CioLite) GetUserEmailAccountsFolderTree(userID string, label string) (*FolderTree, error) {
+	return cioLite.GetUserEmailAccountsFolderTreeContext(context.Background(), userID, label)
+}
+
+// GetUserEmailAccountsFolderTreeContext is the same as GetUserEmailAccountsFolderTree, but uses the provided context.Context
+// for cancellation and deadlines of the request (including any retries).
+func (cioLite CioLite) GetUserEmailAccountsFolderTreeContext(ctx context.Context, userID string, label string) (*FolderTree, error) {
+	folders, err := cioLite.GetUserEmailAccountsFoldersContext(ctx, userID, label, GetUserEmailAccountsFoldersParams{})
+	if err != nil {
+		return nil, err
+	}
+	return NewFolderTree(folders), nil
+}
@@ -0,0 +1,72 @@
+package ciolite
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// TestSimulatedGetUserEmailAccountsFolderTree tests building a folder tree from a folder listing
+func TestSimulatedGetUserEmailAccountsFolderTree(t *testing.T) {
+	t.Parallel()
+
+	cioLite, logger, testServer, mux := NewTestCioLiteWithLoggerAndTestServer(t)
+	defer testServer.Close()
+
+	mux.HandleFunc("/lite/users/123abc/email_accounts/0/folders", func(w http.ResponseWriter, r *http.Request) {
+		_, err := io.WriteString(w, `[
+			{"name":"INBOX","symbolic_name":"\\Inbox","nb_messages":10,"nb_unseen_messages":3,"delimiter":"/"},
+			{"name":"[Gmail]/Sent Mail","symbolic_name":"\\Sent","nb_messages":5,"delimiter":"/"},
+			{"name":"[Gmail]/Trash","symbolic_name":"\\Trash","nb_messages":1,"delimiter":"/"},
+			{"name":"Archive/2026/Q3","nb_messages":7,"nb_unseen_messages":2,"delimiter":"/"},
+			{"name":"Archive","nb_messages":4,"delimiter":"/"},
+			{"name":"Notes.Work","nb_messages":1,"delimiter":"."}
+		]`)
+		Must(err)
+	})
+
+	tree, err := cioLite.GetUserEmailAccountsFolderTree("123abc", "0")
+	if err != nil {
+		t.Fatal("Expected no error; Got: ", err, "; With Log: ", logger.String())
+	}
+
+	var paths []string
+	Must(tree.Walk(func(node *FolderNode) error {
+		paths = append(paths, strings.Repeat("-", node.Depth())+node.Name)
+		return nil
+	}))
+	expectedPaths := "[Gmail] -Sent Mail -Trash Archive -2026 --Q3 INBOX Notes -Work"
+	if strings.Join(paths, " ") != expectedPaths {
+		t.Error("Expected: ", expectedPaths, "; Got: ", strings.Join(paths, " "))
+	}
+
+	archive, ok := tree.Lookup("Archive")
+	if !ok || !archive.Exists || archive.NbMessages != 4 || archive.TotalNbMessages != 11 || archive.TotalNbUnseenMessages != 2 {
+		t.Error("Unexpected Archive folder: ", archive)
+	}
+
+	year, ok := tree.Lookup("Archive/2026")
+	if !ok || year.Exists || year.Path != "Archive/2026" || year.Parent != archive || len(year.Children) != 1 || year.Children[0].Path != "Archive/2026/Q3" {
+		t.Error("Unexpected Archive/2026 folder: ", year)
+	}
+
+	for symbolicName, expectedPath := range map[string]string{`\Sent`: "[Gmail]/Sent Mail", "trash": "[Gmail]/Trash", `\INBOX`: "INBOX"} {
+		if node, ok := tree.LookupSymbolicName(symbolicName); !ok || node.Path != expectedPath {
+			t.Error("Expected: ", expectedPath, "; Got: ", node, "; For: ", symbolicName)
+		}
+	}
+	if _, ok := tree.LookupSymbolicName(`\Junk`); ok {
+		t.Error("Expected no Junk folder")
+	}
+
+	// SkipFolder should skip only the children
+	var topLevel []string
+	Must(tree.Walk(func(node *FolderNode) error {
+		topLevel = append(topLevel, node.Path)
+		return SkipFolder
+	}))
+	if len(topLevel) != len(tree.Folders) {
+		t.Error("Expected: ", len(tree.Folders), "; Got: ", topLevel)
+	}
+}
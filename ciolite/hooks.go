@@ -0,0 +1,113 @@
+package ciolite
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// RequestInfo describes a request to CIO, for the RequestHook and ResponseHook.
+// New fields may be added, so hooks should not construct or compare whole RequestInfos.
+type RequestInfo struct {
+	// RequestID uniquely identifies the request (and is the same for all of its attempts),
+	// to correlate the calls of the RequestHook and ResponseHook
+	RequestID string
+
+	// Method is the http method (GET/POST/etc)
+	Method string
+
+	// URL is the full url of the request, including its query string
+	URL string
+
+	// PathTemplate is the path with its IDs replaced by placeholders
+	// (ex: /lite/users/:user_id/email_accounts/:label), to group requests to the same endpoint
+	PathTemplate string
+
+	// UserID is the ID of the user of the request (if present)
+	UserID string
+
+	// AccountLabel is the label of the email account of the request (if present)
+	AccountLabel string
+
+	// BodyValues are the redacted body values
+	BodyValues url.Values
+
+	// PayloadSize is the size of the request body in bytes
+	PayloadSize int
+}
+
+// ResponseInfo describes the outcome of one attempt of a request to CIO, for the ResponseHook.
+// An attempt that was never sent (refused by the RateLimiter, or with the context done before the retry)
+// has a StatusCode of 0 and the Err explaining why, and is the last attempt.
+// New fields may be added, so hooks should not construct or compare whole ResponseInfos.
+type ResponseInfo struct {
+	Request RequestInfo
+
+	// Attempt is the number of this attempt (starts at 1)
+	Attempt int
+
+	// StatusCode of the response, or 0 if no response was received
+	StatusCode int
+
+	// Payload is the response body, which is empty for a successful streamed response (ex: raw message)
+	Payload string
+
+	// PayloadSize is the size of the response body in bytes (0 for a successful streamed response)
+	PayloadSize int
+
+	// AttemptDuration is how long this attempt took, and TotalDuration is how long all attempts have taken
+	AttemptDuration time.Duration
+	TotalDuration   time.Duration
+
+	// Err is any error received while attempting this request
+	Err error
+
+	// WillRetry is whether the request will be retried (after RetryDelay), unless its context is done first
+	WillRetry  bool
+	RetryDelay time.Duration
+}
+
+// RequestHook is a function (mostly for logging) that is called with the RequestInfo
+// before a request is made (once, however many attempts are made).
+type RequestHook func(context.Context, RequestInfo)
+
+// ResponseHook is a function (mostly for logging or metrics) that is called with
+// the ResponseInfo after each attempt of a request is made. Every request passed to
+// the RequestHook ends with a ResponseInfo whose WillRetry is false.
+type ResponseHook func(context.Context, ResponseInfo)
+
+// pathPlaceholders are the placeholders of the IDs that follow each collection in a path
+var pathPlaceholders = map[string]string{
+	"users":           ":user_id",
+	"email_accounts":  ":label",
+	"folders":         ":folder",
+	"messages":        ":message_id",
+	"messages2":       ":message_id",
+	"attachments":     ":attachment_id",
+	"webhooks":        ":webhook_id",
+	"connect_tokens":  ":token",
+	"oauth_providers": ":key",
+}
+
+// pathTemplate returns the path with the IDs following each collection replaced by placeholders
+func pathTemplate(path string) string {
+	segments := strings.Split(path, "/")
+	for i := 1; i < len(segments); i++ {
+		if placeholder, ok := pathPlaceholders[segments[i-1]]; ok && len(segments[i]) > 0 {
+			segments[i] = placeholder
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// newRequestID returns a new random request ID
+func newRequestID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(b)
+}
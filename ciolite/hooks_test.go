@@ -0,0 +1,182 @@
+package ciolite
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// TestPathTemplate tests that IDs in paths are replaced by placeholders
+func TestPathTemplate(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"/lite/users":        "/lite/users",
+		"/lite/users/123abc": "/lite/users/:user_id",
+		"/lite/users/123abc/email_accounts/0::imap.example.com":                  "/lite/users/:user_id/email_accounts/:label",
+		"/lite/users/1/email_accounts/2/folders/messages/messages/m1":            "/lite/users/:user_id/email_accounts/:label/folders/:folder/messages/:message_id",
+		"/lite/users/1/email_accounts/2/folders/a%2Fb/messages/m1/attachments/3": "/lite/users/:user_id/email_accounts/:label/folders/:folder/messages/:message_id/attachments/:attachment_id",
+		"/lite/webhooks/w1":         "/lite/webhooks/:webhook_id",
+		"/lite/connect_tokens/t1":   "/lite/connect_tokens/:token",
+		"/lite/oauth_providers/k1":  "/lite/oauth_providers/:key",
+		"/app/status_callback_url":  "/app/status_callback_url",
+		"/lite/discovery":           "/lite/discovery",
+		"/lite/users/1/webhooks/w1": "/lite/users/:user_id/webhooks/:webhook_id",
+	}
+
+	for path, expected := range tests {
+		if template := pathTemplate(path); template != expected {
+			t.Error("Expected: ", expected, "; Got: ", template)
+		}
+	}
+}
+
+// TestSimulatedRequestAndResponseHooks tests that the RequestHook is called once per request,
+// and the ResponseHook once per attempt, alongside the positional hooks
+func TestSimulatedRequestAndResponseHooks(t *testing.T) {
+	t.Parallel()
+
+	cioLite, logger, testServer, mux := NewTestCioLiteWithLoggerAndTestServer(t)
+	defer testServer.Close()
+
+	cioLite.RetryPolicy = &RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond, RetryNonIdempotent: true}
+
+	var (
+		mu        sync.Mutex
+		requests  []RequestInfo
+		responses []ResponseInfo
+	)
+	cioLite.RequestHook = func(ctx context.Context, info RequestInfo) {
+		mu.Lock()
+		defer mu.Unlock()
+		requests = append(requests, info)
+	}
+	cioLite.ResponseHook = func(ctx context.Context, info ResponseInfo) {
+		mu.Lock()
+		defer mu.Unlock()
+		responses = append(responses, info)
+	}
+
+	calls := 0
+	mux.HandleFunc("/lite/users/123abc/email_accounts/label1", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, err := io.WriteString(w, `{"success": true}`)
+		Must(err)
+	})
+
+	_, err := cioLite.ModifyUserEmailAccount("123abc", "label1", ModifyUserEmailAccountParams{Password: "hunter2"})
+	if err != nil {
+		t.Error("Expected no error; Got: ", err, "; With Log: ", logger.String())
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if len(requests) != 1 || len(responses) != 2 {
+		t.Fatal("Expected 1 request and 2 responses; Got: ", requests, responses)
+	}
+	request := requests[0]
+	if len(request.RequestID) == 0 || request.Method != "POST" || request.PathTemplate != "/lite/users/:user_id/email_accounts/:label" ||
+		request.UserID != "123abc" || request.AccountLabel != "label1" || request.URL != testServer.URL+"/lite/users/123abc/email_accounts/label1" {
+		t.Error("Expected the request's info; Got: ", request)
+	}
+	if request.BodyValues.Get("password") != "redacted" || request.PayloadSize != len("password=hunter2") {
+		t.Error("Expected redacted body values and the payload size; Got: ", request.BodyValues, request.PayloadSize)
+	}
+
+	first, second := responses[0], responses[1]
+	if first.Request.RequestID != request.RequestID || second.Request.RequestID != request.RequestID {
+		t.Error("Expected the responses to have the request's ID; Got: ", first.Request.RequestID, second.Request.RequestID)
+	}
+	if first.Attempt != 1 || first.StatusCode != http.StatusServiceUnavailable || first.Err == nil || !first.WillRetry {
+		t.Error("Expected a failed attempt to be retried; Got: ", first)
+	}
+	if second.Attempt != 2 || second.StatusCode != http.StatusOK || second.Err != nil || second.WillRetry ||
+		second.PayloadSize != len(`{"success": true}`) || second.TotalDuration < first.AttemptDuration {
+		t.Error("Expected a successful attempt; Got: ", second)
+	}
+
+	// The positional hooks are still called
+	if len(logger.String()) < 20 {
+		t.Error("Expected some output from logger; Got: ", logger.String())
+	}
+}
+
+// TestSimulatedResponseHookNotSent tests that the ResponseHook is called for attempts that are never sent,
+// both when the RateLimiter refuses them and when the context is done before the retry
+func TestSimulatedResponseHookNotSent(t *testing.T) {
+	t.Parallel()
+
+	cioLite, logger, testServer, mux := NewTestCioLiteWithLoggerAndTestServer(t)
+	defer testServer.Close()
+
+	var (
+		mu        sync.Mutex
+		responses []ResponseInfo
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cioLite.ResponseHook = func(hookCtx context.Context, info ResponseInfo) {
+		mu.Lock()
+		defer mu.Unlock()
+		responses = append(responses, info)
+		if info.WillRetry {
+			cancel()
+		}
+	}
+
+	mux.HandleFunc("/lite/users/123abc", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	// Context done while waiting to retry
+	retrying := cioLite
+	retrying.RetryPolicy = &RetryPolicy{MaxAttempts: 3, BaseDelay: time.Hour}
+	_, err := retrying.GetUserContext(ctx, "123abc")
+	if errors.Cause(err) != context.Canceled {
+		t.Error("Expected: ", context.Canceled, "; Got: ", err, "; With Log: ", logger.String())
+	}
+
+	mu.Lock()
+	if len(responses) != 2 {
+		mu.Unlock()
+		t.Fatal("Expected 2 responses; Got: ", responses)
+	}
+	first, last := responses[0], responses[1]
+	if first.Attempt != 1 || first.StatusCode != http.StatusServiceUnavailable || !first.WillRetry {
+		t.Error("Expected a failed attempt to be retried; Got: ", first)
+	}
+	if last.Attempt != 2 || last.StatusCode != 0 || errors.Cause(last.Err) != context.Canceled || last.WillRetry ||
+		last.Request.RequestID != first.Request.RequestID {
+		t.Error("Expected a final attempt that was not sent; Got: ", last)
+	}
+	responses = nil
+	mu.Unlock()
+
+	// Refused by the RateLimiter
+	limited := cioLite
+	limited.RateLimiter = NewRateLimiter(RateLimiterOptions{Global: RateLimit{Requests: 1, Interval: time.Hour}, FailFast: true})
+	Must(limited.RateLimiter.Wait(context.Background(), "", ""))
+	_, err = limited.GetUser("123abc")
+	if errors.Cause(err) != ErrRateLimited {
+		t.Error("Expected: ", ErrRateLimited, "; Got: ", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(responses) != 1 {
+		t.Fatal("Expected 1 response; Got: ", responses)
+	}
+	if refused := responses[0]; refused.Attempt != 1 || refused.StatusCode != 0 || errors.Cause(refused.Err) != ErrRateLimited || refused.WillRetry {
+		t.Error("Expected an attempt refused by the rate limiter; Got: ", refused)
+	}
+}
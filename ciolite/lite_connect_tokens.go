@@ -3,6 +3,7 @@ package ciolite
 // Api functions that support: https://context.io/docs/lite/connect_tokens
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"strings"
@@ -84,6 +85,12 @@ type DeleteConnectTokenResponse struct {
 // GetConnectTokens get a list of connect tokens created with your API key.
 // 	https://context.io/docs/lite/connect_tokens#get
 func (cioLite CioLite) GetConnectTokens() ([]GetConnectTokenResponse, error) {
+	return cioLite.GetConnectTokensContext(context.Background())
+}
+
+// GetConnectTokensContext is the same as GetConnectTokens, but uses the provided context.Context
+// for cancellation and deadlines of the request (including any retries).
+func (cioLite CioLite) GetConnectTokensContext(ctx context.Context) ([]GetConnectTokenResponse, error) {
 
 	// Make request
 	request := clientRequest{
@@ -95,7 +102,7 @@ func (cioLite CioLite) GetConnectTokens() ([]GetConnectTokenResponse, error) {
 	var response []GetConnectTokenResponse
 
 	// Request
-	err := cioLite.doFormRequest(request, &response)
+	err := cioLite.doFormRequest(ctx, request, &response)
 
 	return response, err
 }
@@ -103,6 +110,12 @@ func (cioLite CioLite) GetConnectTokens() ([]GetConnectTokenResponse, error) {
 // GetConnectToken gets information about a given connect token.
 // 	https://context.io/docs/lite/connect_tokens#id-get
 func (cioLite CioLite) GetConnectToken(token string) (GetConnectTokenResponse, error) {
+	return cioLite.GetConnectTokenContext(context.Background(), token)
+}
+
+// GetConnectTokenContext is the same as GetConnectToken, but uses the provided context.Context
+// for cancellation and deadlines of the request (including any retries).
+func (cioLite CioLite) GetConnectTokenContext(ctx context.Context, token string) (GetConnectTokenResponse, error) {
 
 	// Make request
 	request := clientRequest{
@@ -114,7 +127,7 @@ func (cioLite CioLite) GetConnectToken(token string) (GetConnectTokenResponse, e
 	var response GetConnectTokenResponse
 
 	// Request
-	err := cioLite.doFormRequest(request, &response)
+	err := cioLite.doFormRequest(ctx, request, &response)
 
 	return response, err
 }
@@ -124,6 +137,12 @@ func (cioLite CioLite) GetConnectToken(token string) (GetConnectTokenResponse, e
 // Email, FirstName, LastName, StatusCallbackURL
 // 	https://context.io/docs/lite/connect_tokens#post
 func (cioLite CioLite) CreateConnectToken(formValues CreateConnectTokenParams) (CreateConnectTokenResponse, error) {
+	return cioLite.CreateConnectTokenContext(context.Background(), formValues)
+}
+
+// CreateConnectTokenContext is the same as CreateConnectToken, but uses the provided context.Context
+// for cancellation and deadlines of the request (including any retries).
+func (cioLite CioLite) CreateConnectTokenContext(ctx context.Context, formValues CreateConnectTokenParams) (CreateConnectTokenResponse, error) {
 
 	// Make request
 	request := clientRequest{
@@ -136,7 +155,7 @@ func (cioLite CioLite) CreateConnectToken(formValues CreateConnectTokenParams) (
 	var response CreateConnectTokenResponse
 
 	// Request
-	err := cioLite.doFormRequest(request, &response)
+	err := cioLite.doFormRequest(ctx, request, &response)
 
 	return response, err
 }
@@ -144,6 +163,12 @@ func (cioLite CioLite) CreateConnectToken(formValues CreateConnectTokenParams) (
 // DeleteConnectToken removes a given connect token
 // 	https://context.io/docs/lite/connect_tokens#id-delete
 func (cioLite CioLite) DeleteConnectToken(token string) (DeleteConnectTokenResponse, error) {
+	return cioLite.DeleteConnectTokenContext(context.Background(), token)
+}
+
+// DeleteConnectTokenContext is the same as DeleteConnectToken, but uses the provided context.Context
+// for cancellation and deadlines of the request (including any retries).
+func (cioLite CioLite) DeleteConnectTokenContext(ctx context.Context, token string) (DeleteConnectTokenResponse, error) {
 
 	// Make request
 	request := clientRequest{
@@ -155,7 +180,7 @@ func (cioLite CioLite) DeleteConnectToken(token string) (DeleteConnectTokenRespo
 	var response DeleteConnectTokenResponse
 
 	// Request
-	err := cioLite.doFormRequest(request, &response)
+	err := cioLite.doFormRequest(ctx, request, &response)
 
 	return response, err
 }
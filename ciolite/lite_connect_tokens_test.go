@@ -9,14 +9,16 @@ import (
 	"testing"
 )
 
-// TestActualConnectTokenRequestToCioForGoogle tests actual CreateConnectToken,
-// GetConnectToken, GetConnectTokens, and DeleteConnectToken requests to CIO.
-// (internet connection required, real CIO key/secret required,
-// gmail provider key setup previously required).
+// TestActualConnectTokenRequestToCio tests actual CreateConnectToken,
+// GetConnectToken, GetConnectTokens, and DeleteConnectToken requests to CIO
+// (internet connection and real CIO key/secret required, or replays a recorded cassette;
+// see NewTestCioLiteWithCassette. Gmail provider key setup previously required).
 func TestActualConnectTokenRequestToCio(t *testing.T) {
 	t.Parallel()
 
-	cioLite, logger := NewTestCioLiteWithLogger(t)
+	cioLite, saveCassette := NewTestCioLiteWithCassette(t, "connect_tokens")
+	defer saveCassette()
+	logger := addLogging(&cioLite)
 
 	// create
 	connectToken, err := cioLite.CreateConnectToken(CreateConnectTokenParams{
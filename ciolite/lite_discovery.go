@@ -2,6 +2,10 @@ package ciolite
 
 // Api functions that support: https://context.io/docs/lite/discovery
 
+import (
+	"context"
+)
+
 // GetDiscoveryParams query values data struct.
 // Requires SourceType and Email.
 // 	https://context.io/docs/lite/discovery#get
@@ -43,6 +47,12 @@ type GetDiscoveryIMAPResponse struct {
 // queryValues requires SourceType and Email to be set.
 // 	https://context.io/docs/lite/discovery#get
 func (cioLite CioLite) GetDiscovery(queryValues GetDiscoveryParams) (GetDiscoveryResponse, error) {
+	return cioLite.GetDiscoveryContext(context.Background(), queryValues)
+}
+
+// GetDiscoveryContext is the same as GetDiscovery, but uses the provided context.Context
+// for cancellation and deadlines of the request (including any retries).
+func (cioLite CioLite) GetDiscoveryContext(ctx context.Context, queryValues GetDiscoveryParams) (GetDiscoveryResponse, error) {
 
 	// Make request
 	request := clientRequest{
@@ -55,7 +65,7 @@ func (cioLite CioLite) GetDiscovery(queryValues GetDiscoveryParams) (GetDiscover
 	var response GetDiscoveryResponse
 
 	// Request
-	err := cioLite.doFormRequest(request, &response)
+	err := cioLite.doFormRequest(ctx, request, &response)
 
 	return response, err
 }
@@ -7,11 +7,13 @@ import (
 
 // TestActualDiscoveryRequestToCioForGoogle tests sending an actual
 // GetDiscovery request to CIO, for gmail and googleapps accounts
-// (internet connection required, real CIO key/secret required).
+// (internet connection and real CIO key/secret required, or replays a recorded cassette; see NewTestCioLiteWithCassette).
 func TestActualDiscoveryRequestToCioForGoogle(t *testing.T) {
 	t.Parallel()
 
-	cioLite, logger := NewTestCioLiteWithLogger(t)
+	cioLite, saveCassette := NewTestCioLiteWithCassette(t, "discovery_google")
+	defer saveCassette()
+	logger := addLogging(&cioLite)
 
 	expected := GetDiscoveryResponse{
 		Email: "test@gmail.com",
@@ -54,11 +56,12 @@ func TestActualDiscoveryRequestToCioForGoogle(t *testing.T) {
 
 // TestActualDiscoveryRequestToCioForMicrosoft tests sending an actual
 // GetDiscovery request to CIO, for outlook and hotmail accounts
-// (internet connection required, real CIO key/secret required).
+// (internet connection and real CIO key/secret required, or replays a recorded cassette; see NewTestCioLiteWithCassette).
 func TestActualDiscoveryRequestToCioForMicrosoft(t *testing.T) {
 	t.Parallel()
 
-	cioLite := NewTestCioLite(t)
+	cioLite, saveCassette := NewTestCioLiteWithCassette(t, "discovery_microsoft")
+	defer saveCassette()
 
 	expected := GetDiscoveryResponse{
 		Email: "test@hotmail.com",
@@ -95,11 +98,12 @@ func TestActualDiscoveryRequestToCioForMicrosoft(t *testing.T) {
 
 // TestActualDiscoveryRequestToCioForYahoo tests sending an actual
 // GetDiscovery request to CIO, for yahoo accounts
-// (internet connection required, real CIO key/secret required).
+// (internet connection and real CIO key/secret required, or replays a recorded cassette; see NewTestCioLiteWithCassette).
 func TestActualDiscoveryRequestToCioForYahoo(t *testing.T) {
 	t.Parallel()
 
-	cioLite := NewTestCioLite(t)
+	cioLite, saveCassette := NewTestCioLiteWithCassette(t, "discovery_yahoo")
+	defer saveCassette()
 
 	expected := GetDiscoveryResponse{
 		Email: "test@yahoo.com",
@@ -154,11 +158,12 @@ func TestActualDiscoveryRequestToCioForYahoo(t *testing.T) {
 
 // TestActualDiscoveryRequestToCioForAol tests sending an actual
 // GetDiscovery request to CIO, for an AOL account
-// (internet connection required, real CIO key/secret required).
+// (internet connection and real CIO key/secret required, or replays a recorded cassette; see NewTestCioLiteWithCassette).
 func TestActualDiscoveryRequestToCioForAol(t *testing.T) {
 	t.Parallel()
 
-	cioLite := NewTestCioLite(t)
+	cioLite, saveCassette := NewTestCioLiteWithCassette(t, "discovery_aol")
+	defer saveCassette()
 
 	expected := GetDiscoveryResponse{
 		Email: "test@aol.com",
@@ -184,11 +189,12 @@ func TestActualDiscoveryRequestToCioForAol(t *testing.T) {
 
 // TestActualDiscoveryRequestToCioForNonExistent tests sending an actual
 // GetDiscovery request to CIO, for a non-existent email service provider
-// (internet connection required, real CIO key/secret required).
+// (internet connection and real CIO key/secret required, or replays a recorded cassette; see NewTestCioLiteWithCassette).
 func TestActualDiscoveryRequestToCioForNonExistent(t *testing.T) {
 	t.Parallel()
 
-	cioLite := NewTestCioLite(t)
+	cioLite, saveCassette := NewTestCioLiteWithCassette(t, "discovery_nonexistent")
+	defer saveCassette()
 
 	expected := GetDiscoveryResponse{
 		Email: "test@bogusblahblahfoobar.com",
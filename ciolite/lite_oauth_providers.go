@@ -3,6 +3,7 @@ package ciolite
 // Api functions that support: https://context.io/docs/lite/connect_tokens
 
 import (
+	"context"
 	"fmt"
 )
 
@@ -43,6 +44,12 @@ type DeleteOAuthProviderResponse struct {
 // GetOAuthProviders get the list of OAuth providers configured.
 // 	https://context.io/docs/lite/oauth_providers#get
 func (cioLite CioLite) GetOAuthProviders() ([]GetOAuthProvidersResponse, error) {
+	return cioLite.GetOAuthProvidersContext(context.Background())
+}
+
+// GetOAuthProvidersContext is the same as GetOAuthProviders, but uses the provided context.Context
+// for cancellation and deadlines of the request (including any retries).
+func (cioLite CioLite) GetOAuthProvidersContext(ctx context.Context) ([]GetOAuthProvidersResponse, error) {
 
 	// Make request
 	request := clientRequest{
@@ -54,7 +61,7 @@ func (cioLite CioLite) GetOAuthProviders() ([]GetOAuthProvidersResponse, error)
 	var response []GetOAuthProvidersResponse
 
 	// Request
-	err := cioLite.doFormRequest(request, &response)
+	err := cioLite.doFormRequest(ctx, request, &response)
 
 	return response, err
 }
@@ -62,6 +69,12 @@ func (cioLite CioLite) GetOAuthProviders() ([]GetOAuthProvidersResponse, error)
 // GetOAuthProvider gets information about a given OAuth provider.
 // 	https://context.io/docs/lite/oauth_providers#id-get
 func (cioLite CioLite) GetOAuthProvider(key string) (GetOAuthProvidersResponse, error) {
+	return cioLite.GetOAuthProviderContext(context.Background(), key)
+}
+
+// GetOAuthProviderContext is the same as GetOAuthProvider, but uses the provided context.Context
+// for cancellation and deadlines of the request (including any retries).
+func (cioLite CioLite) GetOAuthProviderContext(ctx context.Context, key string) (GetOAuthProvidersResponse, error) {
 
 	// Make request
 	request := clientRequest{
@@ -73,7 +86,7 @@ func (cioLite CioLite) GetOAuthProvider(key string) (GetOAuthProvidersResponse,
 	var response GetOAuthProvidersResponse
 
 	// Request
-	err := cioLite.doFormRequest(request, &response)
+	err := cioLite.doFormRequest(ctx, request, &response)
 
 	return response, err
 }
@@ -82,6 +95,12 @@ func (cioLite CioLite) GetOAuthProvider(key string) (GetOAuthProvidersResponse,
 // formValues requires Type, ProviderConsumerKey, and ProviderConsumerSecret
 // 	https://context.io/docs/lite/oauth_providers#post
 func (cioLite CioLite) CreateOAuthProvider(formValues CreateOAuthProviderParams) (CreateOAuthProviderResponse, error) {
+	return cioLite.CreateOAuthProviderContext(context.Background(), formValues)
+}
+
+// CreateOAuthProviderContext is the same as CreateOAuthProvider, but uses the provided context.Context
+// for cancellation and deadlines of the request (including any retries).
+func (cioLite CioLite) CreateOAuthProviderContext(ctx context.Context, formValues CreateOAuthProviderParams) (CreateOAuthProviderResponse, error) {
 
 	// Make request
 	request := clientRequest{
@@ -94,7 +113,7 @@ func (cioLite CioLite) CreateOAuthProvider(formValues CreateOAuthProviderParams)
 	var response CreateOAuthProviderResponse
 
 	// Request
-	err := cioLite.doFormRequest(request, &response)
+	err := cioLite.doFormRequest(ctx, request, &response)
 
 	return response, err
 }
@@ -102,6 +121,12 @@ func (cioLite CioLite) CreateOAuthProvider(formValues CreateOAuthProviderParams)
 // DeleteOAuthProvider removes a given OAuth provider.
 // 	https://context.io/docs/lite/oauth_providers#id-delete
 func (cioLite CioLite) DeleteOAuthProvider(key string) (DeleteOAuthProviderResponse, error) {
+	return cioLite.DeleteOAuthProviderContext(context.Background(), key)
+}
+
+// DeleteOAuthProviderContext is the same as DeleteOAuthProvider, but uses the provided context.Context
+// for cancellation and deadlines of the request (including any retries).
+func (cioLite CioLite) DeleteOAuthProviderContext(ctx context.Context, key string) (DeleteOAuthProviderResponse, error) {
 
 	// Make request
 	request := clientRequest{
@@ -113,7 +138,7 @@ func (cioLite CioLite) DeleteOAuthProvider(key string) (DeleteOAuthProviderRespo
 	var response DeleteOAuthProviderResponse
 
 	// Request
-	err := cioLite.doFormRequest(request, &response)
+	err := cioLite.doFormRequest(ctx, request, &response)
 
 	return response, err
 }
@@ -3,6 +3,7 @@ package ciolite
 // Api functions that support: https://context.io/docs/lite/users
 
 import (
+	"context"
 	"fmt"
 )
 
@@ -114,6 +115,12 @@ type DeleteUserResponse struct {
 // queryValues may optionally contain Email, Status, StatusOK, Limit, Offset
 // 	https://context.io/docs/lite/users#get
 func (cioLite CioLite) GetUsers(queryValues GetUsersParams) ([]GetUsersResponse, error) {
+	return cioLite.GetUsersContext(context.Background(), queryValues)
+}
+
+// GetUsersContext is the same as GetUsers, but uses the provided context.Context
+// for cancellation and deadlines of the request (including any retries).
+func (cioLite CioLite) GetUsersContext(ctx context.Context, queryValues GetUsersParams) ([]GetUsersResponse, error) {
 
 	// Make request
 	request := clientRequest{
@@ -126,7 +133,7 @@ func (cioLite CioLite) GetUsers(queryValues GetUsersParams) ([]GetUsersResponse,
 	var response []GetUsersResponse
 
 	// Request
-	err := cioLite.doFormRequest(request, &response)
+	err := cioLite.doFormRequest(ctx, request, &response)
 
 	return response, err
 }
@@ -134,6 +141,12 @@ func (cioLite CioLite) GetUsers(queryValues GetUsersParams) ([]GetUsersResponse,
 // GetUser get details about a given user.
 // 	https://context.io/docs/lite/users#id-get
 func (cioLite CioLite) GetUser(userID string) (GetUsersResponse, error) {
+	return cioLite.GetUserContext(context.Background(), userID)
+}
+
+// GetUserContext is the same as GetUser, but uses the provided context.Context
+// for cancellation and deadlines of the request (including any retries).
+func (cioLite CioLite) GetUserContext(ctx context.Context, userID string) (GetUsersResponse, error) {
 
 	// Make request
 	request := clientRequest{
@@ -146,7 +159,7 @@ func (cioLite CioLite) GetUser(userID string) (GetUsersResponse, error) {
 	var response GetUsersResponse
 
 	// Request
-	err := cioLite.doFormRequest(request, &response)
+	err := cioLite.doFormRequest(ctx, request, &response)
 
 	return response, err
 }
@@ -160,6 +173,12 @@ func (cioLite CioLite) GetUser(userID string) (GetUsersResponse, error) {
 // FirstName, LastName, StatusCallbackURL
 // 	https://context.io/docs/lite/users#post
 func (cioLite CioLite) CreateUser(formValues CreateUserParams) (CreateUserResponse, error) {
+	return cioLite.CreateUserContext(context.Background(), formValues)
+}
+
+// CreateUserContext is the same as CreateUser, but uses the provided context.Context
+// for cancellation and deadlines of the request (including any retries).
+func (cioLite CioLite) CreateUserContext(ctx context.Context, formValues CreateUserParams) (CreateUserResponse, error) {
 
 	// Make request
 	request := clientRequest{
@@ -172,7 +191,7 @@ func (cioLite CioLite) CreateUser(formValues CreateUserParams) (CreateUserRespon
 	var response CreateUserResponse
 
 	// Request
-	err := cioLite.doFormRequest(request, &response)
+	err := cioLite.doFormRequest(ctx, request, &response)
 
 	return response, err
 }
@@ -181,6 +200,12 @@ func (cioLite CioLite) CreateUser(formValues CreateUserParams) (CreateUserRespon
 // formValues requires FirstName, LastName
 // 	https://context.io/docs/lite/users#id-post
 func (cioLite CioLite) ModifyUser(userID string, formValues ModifyUserParams) (ModifyUserResponse, error) {
+	return cioLite.ModifyUserContext(context.Background(), userID, formValues)
+}
+
+// ModifyUserContext is the same as ModifyUser, but uses the provided context.Context
+// for cancellation and deadlines of the request (including any retries).
+func (cioLite CioLite) ModifyUserContext(ctx context.Context, userID string, formValues ModifyUserParams) (ModifyUserResponse, error) {
 
 	// Make request
 	request := clientRequest{
@@ -194,7 +219,7 @@ func (cioLite CioLite) ModifyUser(userID string, formValues ModifyUserParams) (M
 	var response ModifyUserResponse
 
 	// Request
-	err := cioLite.doFormRequest(request, &response)
+	err := cioLite.doFormRequest(ctx, request, &response)
 
 	return response, err
 }
@@ -202,6 +227,12 @@ func (cioLite CioLite) ModifyUser(userID string, formValues ModifyUserParams) (M
 // DeleteUser removes a given user.
 // 	https://context.io/docs/lite/users#id-delete
 func (cioLite CioLite) DeleteUser(userID string) (DeleteUserResponse, error) {
+	return cioLite.DeleteUserContext(context.Background(), userID)
+}
+
+// DeleteUserContext is the same as DeleteUser, but uses the provided context.Context
+// for cancellation and deadlines of the request (including any retries).
+func (cioLite CioLite) DeleteUserContext(ctx context.Context, userID string) (DeleteUserResponse, error) {
 
 	// Make request
 	request := clientRequest{
@@ -214,7 +245,7 @@ func (cioLite CioLite) DeleteUser(userID string) (DeleteUserResponse, error) {
 	var response DeleteUserResponse
 
 	// Request
-	err := cioLite.doFormRequest(request, &response)
+	err := cioLite.doFormRequest(ctx, request, &response)
 
 	return response, err
 }
@@ -3,12 +3,19 @@ package ciolite
 // Api functions that support: https://context.io/docs/lite/users/connect_tokens
 
 import (
+	"context"
 	"fmt"
 )
 
 // GetUserConnectTokens gets a list of connect tokens created for a user.
 // 	https://context.io/docs/lite/users/connect_tokens#get
 func (cioLite CioLite) GetUserConnectTokens(userID string) ([]GetConnectTokenResponse, error) {
+	return cioLite.GetUserConnectTokensContext(context.Background(), userID)
+}
+
+// GetUserConnectTokensContext is the same as GetUserConnectTokens, but uses the provided context.Context
+// for cancellation and deadlines of the request (including any retries).
+func (cioLite CioLite) GetUserConnectTokensContext(ctx context.Context, userID string) ([]GetConnectTokenResponse, error) {
 
 	// Make request
 	request := clientRequest{
@@ -21,7 +28,7 @@ func (cioLite CioLite) GetUserConnectTokens(userID string) ([]GetConnectTokenRes
 	var response []GetConnectTokenResponse
 
 	// Request
-	err := cioLite.doFormRequest(request, &response)
+	err := cioLite.doFormRequest(ctx, request, &response)
 
 	return response, err
 }
@@ -29,6 +36,12 @@ func (cioLite CioLite) GetUserConnectTokens(userID string) ([]GetConnectTokenRes
 // GetUserConnectToken gets information about a given connect token for a specific user.
 // 	https://context.io/docs/lite/users/connect_tokens#id-get
 func (cioLite CioLite) GetUserConnectToken(userID string, token string) (GetConnectTokenResponse, error) {
+	return cioLite.GetUserConnectTokenContext(context.Background(), userID, token)
+}
+
+// GetUserConnectTokenContext is the same as GetUserConnectToken, but uses the provided context.Context
+// for cancellation and deadlines of the request (including any retries).
+func (cioLite CioLite) GetUserConnectTokenContext(ctx context.Context, userID string, token string) (GetConnectTokenResponse, error) {
 
 	// Make request
 	request := clientRequest{
@@ -41,7 +54,7 @@ func (cioLite CioLite) GetUserConnectToken(userID string, token string) (GetConn
 	var response GetConnectTokenResponse
 
 	// Request
-	err := cioLite.doFormRequest(request, &response)
+	err := cioLite.doFormRequest(ctx, request, &response)
 
 	return response, err
 }
@@ -51,6 +64,12 @@ func (cioLite CioLite) GetUserConnectToken(userID string, token string) (GetConn
 // Email, FirstName, LastName, StatusCallbackURL
 // 	https://context.io/docs/lite/users/connect_tokens#post
 func (cioLite CioLite) CreateUserConnectToken(userID string, formValues CreateConnectTokenParams) (CreateConnectTokenResponse, error) {
+	return cioLite.CreateUserConnectTokenContext(context.Background(), userID, formValues)
+}
+
+// CreateUserConnectTokenContext is the same as CreateUserConnectToken, but uses the provided context.Context
+// for cancellation and deadlines of the request (including any retries).
+func (cioLite CioLite) CreateUserConnectTokenContext(ctx context.Context, userID string, formValues CreateConnectTokenParams) (CreateConnectTokenResponse, error) {
 
 	// Make request
 	request := clientRequest{
@@ -64,7 +83,7 @@ func (cioLite CioLite) CreateUserConnectToken(userID string, formValues CreateCo
 	var response CreateConnectTokenResponse
 
 	// Request
-	err := cioLite.doFormRequest(request, &response)
+	err := cioLite.doFormRequest(ctx, request, &response)
 
 	return response, err
 }
@@ -72,6 +91,12 @@ func (cioLite CioLite) CreateUserConnectToken(userID string, formValues CreateCo
 // DeleteUserConnectToken removes a given connect token for a specific user.
 // 	https://context.io/docs/lite/users/connect_tokens#id-delete
 func (cioLite CioLite) DeleteUserConnectToken(userID string, token string) (DeleteConnectTokenResponse, error) {
+	return cioLite.DeleteUserConnectTokenContext(context.Background(), userID, token)
+}
+
+// DeleteUserConnectTokenContext is the same as DeleteUserConnectToken, but uses the provided context.Context
+// for cancellation and deadlines of the request (including any retries).
+func (cioLite CioLite) DeleteUserConnectTokenContext(ctx context.Context, userID string, token string) (DeleteConnectTokenResponse, error) {
 
 	// Make request
 	request := clientRequest{
@@ -84,7 +109,7 @@ func (cioLite CioLite) DeleteUserConnectToken(userID string, token string) (Dele
 	var response DeleteConnectTokenResponse
 
 	// Request
-	err := cioLite.doFormRequest(request, &response)
+	err := cioLite.doFormRequest(ctx, request, &response)
 
 	return response, err
 }
@@ -3,6 +3,7 @@ package ciolite
 // Api functions that support: https://context.io/docs/lite/users/email_accounts
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -95,6 +96,12 @@ type StatusCallback struct {
 // queryValues may optionally contain Status, StatusOK
 // 	https://context.io/docs/lite/users/email_accounts#get
 func (cioLite CioLite) GetUserEmailAccounts(userID string, queryValues GetUserEmailAccountsParams) ([]GetUsersEmailAccountsResponse, error) {
+	return cioLite.GetUserEmailAccountsContext(context.Background(), userID, queryValues)
+}
+
+// GetUserEmailAccountsContext is the same as GetUserEmailAccounts, but uses the provided context.Context
+// for cancellation and deadlines of the request (including any retries).
+func (cioLite CioLite) GetUserEmailAccountsContext(ctx context.Context, userID string, queryValues GetUserEmailAccountsParams) ([]GetUsersEmailAccountsResponse, error) {
 
 	// Make request
 	request := clientRequest{
@@ -108,7 +115,7 @@ func (cioLite CioLite) GetUserEmailAccounts(userID string, queryValues GetUserEm
 	var response []GetUsersEmailAccountsResponse
 
 	// Request
-	err := cioLite.doFormRequest(request, &response)
+	err := cioLite.doFormRequest(ctx, request, &response)
 
 	return response, err
 }
@@ -117,6 +124,12 @@ func (cioLite CioLite) GetUserEmailAccounts(userID string, queryValues GetUserEm
 // 	https://context.io/docs/lite/users/email_accounts#id-get
 // Status can be one of: OK, CONNECTION_IMPOSSIBLE, INVALID_CREDENTIALS, TEMP_DISABLED, DISABLED
 func (cioLite CioLite) GetUserEmailAccount(userID string, label string) (GetUsersEmailAccountsResponse, error) {
+	return cioLite.GetUserEmailAccountContext(context.Background(), userID, label)
+}
+
+// GetUserEmailAccountContext is the same as GetUserEmailAccount, but uses the provided context.Context
+// for cancellation and deadlines of the request (including any retries).
+func (cioLite CioLite) GetUserEmailAccountContext(ctx context.Context, userID string, label string) (GetUsersEmailAccountsResponse, error) {
 
 	// Make request
 	request := clientRequest{
@@ -130,7 +143,7 @@ func (cioLite CioLite) GetUserEmailAccount(userID string, label string) (GetUser
 	var response GetUsersEmailAccountsResponse
 
 	// Request
-	err := cioLite.doFormRequest(request, &response)
+	err := cioLite.doFormRequest(ctx, request, &response)
 
 	return response, err
 }
@@ -142,6 +155,12 @@ func (cioLite CioLite) GetUserEmailAccount(userID string, label string) (GetUser
 // and may optionally contain StatusCallbackURL
 // 	https://context.io/docs/lite/users/email_accounts#post
 func (cioLite CioLite) CreateUserEmailAccount(userID string, formValues CreateUserParams) (CreateEmailAccountResponse, error) {
+	return cioLite.CreateUserEmailAccountContext(context.Background(), userID, formValues)
+}
+
+// CreateUserEmailAccountContext is the same as CreateUserEmailAccount, but uses the provided context.Context
+// for cancellation and deadlines of the request (including any retries).
+func (cioLite CioLite) CreateUserEmailAccountContext(ctx context.Context, userID string, formValues CreateUserParams) (CreateEmailAccountResponse, error) {
 
 	// Make request
 	request := clientRequest{
@@ -155,7 +174,7 @@ func (cioLite CioLite) CreateUserEmailAccount(userID string, formValues CreateUs
 	var response CreateEmailAccountResponse
 
 	// Request
-	err := cioLite.doFormRequest(request, &response)
+	err := cioLite.doFormRequest(ctx, request, &response)
 
 	return response, err
 }
@@ -165,6 +184,12 @@ func (cioLite CioLite) CreateUserEmailAccount(userID string, formValues CreateUs
 // ProviderRefreshToken, ProviderConsumerKey, StatusCallbackURL
 // 	https://context.io/docs/lite/users/email_accounts#id-post
 func (cioLite CioLite) ModifyUserEmailAccount(userID string, label string, formValues ModifyUserEmailAccountParams) (ModifyEmailAccountResponse, error) {
+	return cioLite.ModifyUserEmailAccountContext(context.Background(), userID, label, formValues)
+}
+
+// ModifyUserEmailAccountContext is the same as ModifyUserEmailAccount, but uses the provided context.Context
+// for cancellation and deadlines of the request (including any retries).
+func (cioLite CioLite) ModifyUserEmailAccountContext(ctx context.Context, userID string, label string, formValues ModifyUserEmailAccountParams) (ModifyEmailAccountResponse, error) {
 
 	// Make request
 	request := clientRequest{
@@ -179,7 +204,7 @@ func (cioLite CioLite) ModifyUserEmailAccount(userID string, label string, formV
 	var response ModifyEmailAccountResponse
 
 	// Request
-	err := cioLite.doFormRequest(request, &response)
+	err := cioLite.doFormRequest(ctx, request, &response)
 
 	return response, err
 }
@@ -187,6 +212,12 @@ func (cioLite CioLite) ModifyUserEmailAccount(userID string, label string, formV
 // DeleteUserEmailAccount deletes an email account of a user.
 // 	https://context.io/docs/lite/users/email_accounts#id-delete
 func (cioLite CioLite) DeleteUserEmailAccount(userID string, label string) (DeleteEmailAccountResponse, error) {
+	return cioLite.DeleteUserEmailAccountContext(context.Background(), userID, label)
+}
+
+// DeleteUserEmailAccountContext is the same as DeleteUserEmailAccount, but uses the provided context.Context
+// for cancellation and deadlines of the request (including any retries).
+func (cioLite CioLite) DeleteUserEmailAccountContext(ctx context.Context, userID string, label string) (DeleteEmailAccountResponse, error) {
 
 	// Make request
 	request := clientRequest{
@@ -200,7 +231,7 @@ func (cioLite CioLite) DeleteUserEmailAccount(userID string, label string) (Dele
 	var response DeleteEmailAccountResponse
 
 	// Request
-	err := cioLite.doFormRequest(request, &response)
+	err := cioLite.doFormRequest(ctx, request, &response)
 
 	return response, err
 }
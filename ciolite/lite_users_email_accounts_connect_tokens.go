@@ -3,12 +3,19 @@ package ciolite
 // Api functions that support: https://context.io/docs/lite/users/email_accounts/connect_tokens
 
 import (
+	"context"
 	"fmt"
 )
 
 // GetUserEmailAccountConnectTokens gets a list of connect tokens created for a user email account.
 // 	https://context.io/docs/lite/users/email_accounts/connect_tokens#get
 func (cioLite CioLite) GetUserEmailAccountConnectTokens(userID string, label string) ([]GetConnectTokenResponse, error) {
+	return cioLite.GetUserEmailAccountConnectTokensContext(context.Background(), userID, label)
+}
+
+// GetUserEmailAccountConnectTokensContext is the same as GetUserEmailAccountConnectTokens, but uses the provided context.Context
+// for cancellation and deadlines of the request (including any retries).
+func (cioLite CioLite) GetUserEmailAccountConnectTokensContext(ctx context.Context, userID string, label string) ([]GetConnectTokenResponse, error) {
 
 	// Make request
 	request := clientRequest{
@@ -21,7 +28,7 @@ func (cioLite CioLite) GetUserEmailAccountConnectTokens(userID string, label str
 	var response []GetConnectTokenResponse
 
 	// Request
-	err := cioLite.doFormRequest(request, &response)
+	err := cioLite.doFormRequest(ctx, request, &response)
 
 	return response, err
 }
@@ -29,6 +36,12 @@ func (cioLite CioLite) GetUserEmailAccountConnectTokens(userID string, label str
 // GetUserEmailAccountConnectToken gets information about a given connect token for a specific user email account.
 // 	https://context.io/docs/lite/users/email_accounts/connect_tokens#id-get
 func (cioLite CioLite) GetUserEmailAccountConnectToken(userID string, label string, token string) (GetConnectTokenResponse, error) {
+	return cioLite.GetUserEmailAccountConnectTokenContext(context.Background(), userID, label, token)
+}
+
+// GetUserEmailAccountConnectTokenContext is the same as GetUserEmailAccountConnectToken, but uses the provided context.Context
+// for cancellation and deadlines of the request (including any retries).
+func (cioLite CioLite) GetUserEmailAccountConnectTokenContext(ctx context.Context, userID string, label string, token string) (GetConnectTokenResponse, error) {
 
 	// Make request
 	request := clientRequest{
@@ -41,7 +54,7 @@ func (cioLite CioLite) GetUserEmailAccountConnectToken(userID string, label stri
 	var response GetConnectTokenResponse
 
 	// Request
-	err := cioLite.doFormRequest(request, &response)
+	err := cioLite.doFormRequest(ctx, request, &response)
 
 	return response, err
 }
@@ -50,6 +63,12 @@ func (cioLite CioLite) GetUserEmailAccountConnectToken(userID string, label stri
 // formValues requires CallbackURL
 // 	https://context.io/docs/lite/users/email_accounts/connect_tokens#post
 func (cioLite CioLite) CreateUserEmailAccountConnectToken(userID string, label string, formValues CreateConnectTokenParams) (CreateConnectTokenResponse, error) {
+	return cioLite.CreateUserEmailAccountConnectTokenContext(context.Background(), userID, label, formValues)
+}
+
+// CreateUserEmailAccountConnectTokenContext is the same as CreateUserEmailAccountConnectToken, but uses the provided context.Context
+// for cancellation and deadlines of the request (including any retries).
+func (cioLite CioLite) CreateUserEmailAccountConnectTokenContext(ctx context.Context, userID string, label string, formValues CreateConnectTokenParams) (CreateConnectTokenResponse, error) {
 
 	// Make request
 	request := clientRequest{
@@ -63,7 +82,7 @@ func (cioLite CioLite) CreateUserEmailAccountConnectToken(userID string, label s
 	var response CreateConnectTokenResponse
 
 	// Request
-	err := cioLite.doFormRequest(request, &response)
+	err := cioLite.doFormRequest(ctx, request, &response)
 
 	return response, err
 }
@@ -71,6 +90,12 @@ func (cioLite CioLite) CreateUserEmailAccountConnectToken(userID string, label s
 // DeleteUserEmailAccountConnectToken removes a given connect token for a specific user email account.
 // 	https://context.io/docs/lite/users/email_accounts/connect_tokens#id-delete
 func (cioLite CioLite) DeleteUserEmailAccountConnectToken(userID string, label string, token string) (DeleteConnectTokenResponse, error) {
+	return cioLite.DeleteUserEmailAccountConnectTokenContext(context.Background(), userID, label, token)
+}
+
+// DeleteUserEmailAccountConnectTokenContext is the same as DeleteUserEmailAccountConnectToken, but uses the provided context.Context
+// for cancellation and deadlines of the request (including any retries).
+func (cioLite CioLite) DeleteUserEmailAccountConnectTokenContext(ctx context.Context, userID string, label string, token string) (DeleteConnectTokenResponse, error) {
 
 	// Make request
 	request := clientRequest{
@@ -83,7 +108,7 @@ func (cioLite CioLite) DeleteUserEmailAccountConnectToken(userID string, label s
 	var response DeleteConnectTokenResponse
 
 	// Request
-	err := cioLite.doFormRequest(request, &response)
+	err := cioLite.doFormRequest(ctx, request, &response)
 
 	return response, err
 }
@@ -3,8 +3,10 @@ package ciolite
 // Api functions that support: https://context.io/docs/lite/users/email_accounts/folders
 
 import (
+	"context"
 	"fmt"
 	"net/url"
+	"strings"
 
 	"github.com/pkg/errors"
 )
@@ -50,10 +52,38 @@ type CreateEmailAccountFolderResponse struct {
 	Success bool `json:"success,omitempty"`
 }
 
+// DeleteEmailAccountFolderResponse data struct
+// 	https://context.io/docs/lite/users/email_accounts/folders#id-delete
+type DeleteEmailAccountFolderResponse struct {
+	Success bool `json:"success,omitempty"`
+}
+
+// RenameUserEmailAccountFolderParams form values data struct.
+// Requires: NewFolderID, Optional: Delimiter
+// 	https://context.io/docs/lite/users/email_accounts/folders#id-put
+type RenameUserEmailAccountFolderParams struct {
+	// Required:
+	NewFolderID string `json:"new_folder_id"`
+	// Optional:
+	Delimiter string `json:"delimiter,omitempty"`
+}
+
+// RenameEmailAccountFolderResponse data struct
+// 	https://context.io/docs/lite/users/email_accounts/folders#id-put
+type RenameEmailAccountFolderResponse struct {
+	Success bool `json:"success,omitempty"`
+}
+
 // GetUserEmailAccountsFolders gets a list of folders in an email account.
 // queryValues may optionally contain IncludeNamesOnly
 // 	https://context.io/docs/lite/users/email_accounts/folders#get
 func (cioLite CioLite) GetUserEmailAccountsFolders(userID string, label string, queryValues GetUserEmailAccountsFoldersParams) ([]GetUsersEmailAccountFoldersResponse, error) {
+	return cioLite.GetUserEmailAccountsFoldersContext(context.Background(), userID, label, queryValues)
+}
+
+// GetUserEmailAccountsFoldersContext is the same as GetUserEmailAccountsFolders, but uses the provided context.Context
+// for cancellation and deadlines of the request (including any retries).
+func (cioLite CioLite) GetUserEmailAccountsFoldersContext(ctx context.Context, userID string, label string, queryValues GetUserEmailAccountsFoldersParams) ([]GetUsersEmailAccountFoldersResponse, error) {
 
 	// Make request
 	request := clientRequest{
@@ -68,7 +98,7 @@ func (cioLite CioLite) GetUserEmailAccountsFolders(userID string, label string,
 	var response []GetUsersEmailAccountFoldersResponse
 
 	// Request
-	err := cioLite.doFormRequest(request, &response)
+	err := cioLite.doFormRequest(ctx, request, &response)
 
 	return response, err
 }
@@ -77,6 +107,12 @@ func (cioLite CioLite) GetUserEmailAccountsFolders(userID string, label string,
 // queryValues may optionally contain Delimiter
 // 	https://context.io/docs/lite/users/email_accounts/folders#id-get
 func (cioLite CioLite) GetUserEmailAccountFolder(userID string, label string, folder string, queryValues EmailAccountFolderDelimiterParam) (GetUsersEmailAccountFoldersResponse, error) {
+	return cioLite.GetUserEmailAccountFolderContext(context.Background(), userID, label, folder, queryValues)
+}
+
+// GetUserEmailAccountFolderContext is the same as GetUserEmailAccountFolder, but uses the provided context.Context
+// for cancellation and deadlines of the request (including any retries).
+func (cioLite CioLite) GetUserEmailAccountFolderContext(ctx context.Context, userID string, label string, folder string, queryValues EmailAccountFolderDelimiterParam) (GetUsersEmailAccountFoldersResponse, error) {
 
 	// Make request
 	request := clientRequest{
@@ -91,7 +127,7 @@ func (cioLite CioLite) GetUserEmailAccountFolder(userID string, label string, fo
 	var response GetUsersEmailAccountFoldersResponse
 
 	// Request
-	err := cioLite.doFormRequest(request, &response)
+	err := cioLite.doFormRequest(ctx, request, &response)
 
 	return response, err
 }
@@ -101,6 +137,12 @@ func (cioLite CioLite) GetUserEmailAccountFolder(userID string, label string, fo
 // queryValues may optionally contain Delimiter
 // 	https://context.io/docs/lite/users/email_accounts/folders#id-post
 func (cioLite CioLite) CreateUserEmailAccountFolder(userID string, label string, folder string, formValues EmailAccountFolderDelimiterParam) (CreateEmailAccountFolderResponse, error) {
+	return cioLite.CreateUserEmailAccountFolderContext(context.Background(), userID, label, folder, formValues)
+}
+
+// CreateUserEmailAccountFolderContext is the same as CreateUserEmailAccountFolder, but uses the provided context.Context
+// for cancellation and deadlines of the request (including any retries).
+func (cioLite CioLite) CreateUserEmailAccountFolderContext(ctx context.Context, userID string, label string, folder string, formValues EmailAccountFolderDelimiterParam) (CreateEmailAccountFolderResponse, error) {
 
 	// Make request
 	request := clientRequest{
@@ -115,7 +157,10 @@ func (cioLite CioLite) CreateUserEmailAccountFolder(userID string, label string,
 	var response CreateEmailAccountFolderResponse
 
 	// Request
-	err := cioLite.doFormRequest(request, &response)
+	err := cioLite.doFormRequest(ctx, request, &response)
+	if err == nil {
+		cioLite.invalidateFolderCache(userID, label)
+	}
 
 	return response, err
 }
@@ -124,15 +169,21 @@ func (cioLite CioLite) CreateUserEmailAccountFolder(userID string, label string,
 // This function returns a bool representing whether it had to create a folder, and any errors it received.
 // queryValues may optionally contain Delimiter
 func (cioLite CioLite) SafeCreateUserEmailAccountFolder(userID string, label string, folder string, formValues EmailAccountFolderDelimiterParam) (bool, error) {
+	return cioLite.SafeCreateUserEmailAccountFolderContext(context.Background(), userID, label, folder, formValues)
+}
 
-	existsResponse, err := cioLite.GetUserEmailAccountFolder(userID, label, folder, formValues)
+// SafeCreateUserEmailAccountFolderContext is the same as SafeCreateUserEmailAccountFolder, but uses the provided
+// context.Context for cancellation and deadlines of all requests made (including any retries).
+func (cioLite CioLite) SafeCreateUserEmailAccountFolderContext(ctx context.Context, userID string, label string, folder string, formValues EmailAccountFolderDelimiterParam) (bool, error) {
+
+	existsResponse, err := cioLite.GetUserEmailAccountFolderContext(ctx, userID, label, folder, formValues)
 	if err == nil && existsResponse.Name == folder {
 		// It exists already, so return false and no error
 		return false, nil
 	}
 
 	// CIO seems to have issues Getting a single specific folder, and Posting a new folder always gives an error if it already exists, so try getting the folder list and see if it is there already
-	allFolders, err := cioLite.GetUserEmailAccountsFolders(userID, label, GetUserEmailAccountsFoldersParams{IncludeNamesOnly: true})
+	allFolders, err := cioLite.GetUserEmailAccountsFoldersContext(ctx, userID, label, GetUserEmailAccountsFoldersParams{IncludeNamesOnly: true})
 	if err == nil {
 		for _, singleFolder := range allFolders {
 			if singleFolder.Name == folder {
@@ -141,7 +192,7 @@ func (cioLite CioLite) SafeCreateUserEmailAccountFolder(userID string, label str
 		}
 	}
 
-	createResponse, err := cioLite.CreateUserEmailAccountFolder(userID, label, folder, formValues)
+	createResponse, err := cioLite.CreateUserEmailAccountFolderContext(ctx, userID, label, folder, formValues)
 	if err != nil {
 		return true, err
 	}
@@ -151,3 +202,134 @@ func (cioLite CioLite) SafeCreateUserEmailAccountFolder(userID string, label str
 	// Created successfully
 	return true, nil
 }
+
+// CreateUserEmailAccountFolderAll creates the folder along with any missing parent folders (like os.MkdirAll),
+// ex: Archive/2026/Q3 creates Archive, Archive/2026, and Archive/2026/Q3 as needed.
+// Folders that already exist (with INBOX matched case-insensitively, as in IMAP) are left alone, so it can be called repeatedly.
+// This function returns the folders that it created, and any error it received.
+// formValues may optionally contain Delimiter, which otherwise is the account's delimiter
+// (an error is returned if the account's folders do not say what their delimiter is)
+func (cioLite CioLite) CreateUserEmailAccountFolderAll(userID string, label string, folder string, formValues EmailAccountFolderDelimiterParam) ([]string, error) {
+	return cioLite.CreateUserEmailAccountFolderAllContext(context.Background(), userID, label, folder, formValues)
+}
+
+// CreateUserEmailAccountFolderAllContext is the same as CreateUserEmailAccountFolderAll, but uses the provided
+// context.Context for cancellation and deadlines of all requests made (including any retries).
+func (cioLite CioLite) CreateUserEmailAccountFolderAllContext(ctx context.Context, userID string, label string, folder string, formValues EmailAccountFolderDelimiterParam) ([]string, error) {
+
+	// Listing all folders is more reliable than getting each one (see SafeCreateUserEmailAccountFolder),
+	// and only the full folder info includes the delimiter
+	allFolders, err := cioLite.GetUserEmailAccountsFoldersContext(ctx, userID, label, GetUserEmailAccountsFoldersParams{})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, singleFolder := range allFolders {
+		if len(formValues.Delimiter) == 0 {
+			formValues.Delimiter = singleFolder.Delimiter
+		}
+	}
+	if len(formValues.Delimiter) == 0 {
+		return nil, errors.New("Unable to create folder " + folder + ". Could not determine the folder delimiter of the account")
+	}
+
+	existing := make(map[string]bool, len(allFolders))
+	for _, singleFolder := range allFolders {
+		existing[inboxCaseInsensitive(singleFolder.Name, formValues.Delimiter)] = true
+	}
+
+	// Create each missing folder, parents first
+	var created []string
+	parts := strings.Split(folder, formValues.Delimiter)
+	for i := range parts {
+		path := strings.Join(parts[:i+1], formValues.Delimiter)
+		if len(parts[i]) == 0 || existing[inboxCaseInsensitive(path, formValues.Delimiter)] {
+			continue
+		}
+
+		createResponse, err := cioLite.CreateUserEmailAccountFolderContext(ctx, userID, label, path, formValues)
+		if err != nil {
+			return created, err
+		}
+		if !createResponse.Success {
+			return created, errors.New("Unable to create folder " + path + ". CIO returned 200 but with Success=false")
+		}
+		created = append(created, path)
+	}
+
+	return created, nil
+}
+
+// inboxCaseInsensitive returns the folder name with its top-level INBOX (if any) in upper case,
+// as IMAP names INBOX case-insensitively (ex: Inbox/Foo is the same folder as INBOX/Foo)
+func inboxCaseInsensitive(name string, delimiter string) string {
+	parts := strings.SplitN(name, delimiter, 2)
+	if strings.EqualFold(parts[0], "INBOX") {
+		parts[0] = "INBOX"
+	}
+	return strings.Join(parts, delimiter)
+}
+
+// DeleteUserEmailAccountFolder deletes a folder on an email account.
+// formValues may optionally contain Delimiter
+// 	https://context.io/docs/lite/users/email_accounts/folders#id-delete
+func (cioLite CioLite) DeleteUserEmailAccountFolder(userID string, label string, folder string, formValues EmailAccountFolderDelimiterParam) (DeleteEmailAccountFolderResponse, error) {
+	return cioLite.DeleteUserEmailAccountFolderContext(context.Background(), userID, label, folder, formValues)
+}
+
+// DeleteUserEmailAccountFolderContext is the same as DeleteUserEmailAccountFolder, but uses the provided context.Context
+// for cancellation and deadlines of the request (including any retries).
+func (cioLite CioLite) DeleteUserEmailAccountFolderContext(ctx context.Context, userID string, label string, folder string, formValues EmailAccountFolderDelimiterParam) (DeleteEmailAccountFolderResponse, error) {
+
+	// Make request
+	request := clientRequest{
+		Method:       "DELETE",
+		Path:         fmt.Sprintf("/lite/users/%s/email_accounts/%s/folders/%s", userID, label, url.QueryEscape(folder)),
+		FormValues:   formValues,
+		UserID:       userID,
+		AccountLabel: label,
+	}
+
+	// Make response
+	var response DeleteEmailAccountFolderResponse
+
+	// Request
+	err := cioLite.doFormRequest(ctx, request, &response)
+	if err == nil {
+		cioLite.invalidateFolderCache(userID, label)
+	}
+
+	return response, err
+}
+
+// RenameUserEmailAccountFolder renames (or moves, if the parent differs) a folder on an email account.
+// formValues requires NewFolderID, and may optionally contain Delimiter
+// 	https://context.io/docs/lite/users/email_accounts/folders#id-put
+func (cioLite CioLite) RenameUserEmailAccountFolder(userID string, label string, folder string, formValues RenameUserEmailAccountFolderParams) (RenameEmailAccountFolderResponse, error) {
+	return cioLite.RenameUserEmailAccountFolderContext(context.Background(), userID, label, folder, formValues)
+}
+
+// RenameUserEmailAccountFolderContext is the same as RenameUserEmailAccountFolder, but uses the provided context.Context
+// for cancellation and deadlines of the request (including any retries).
+func (cioLite CioLite) RenameUserEmailAccountFolderContext(ctx context.Context, userID string, label string, folder string, formValues RenameUserEmailAccountFolderParams) (RenameEmailAccountFolderResponse, error) {
+
+	// Make request
+	request := clientRequest{
+		Method:       "PUT",
+		Path:         fmt.Sprintf("/lite/users/%s/email_accounts/%s/folders/%s", userID, label, url.QueryEscape(folder)),
+		FormValues:   formValues,
+		UserID:       userID,
+		AccountLabel: label,
+	}
+
+	// Make response
+	var response RenameEmailAccountFolderResponse
+
+	// Request
+	err := cioLite.doFormRequest(ctx, request, &response)
+	if err == nil {
+		cioLite.invalidateFolderCache(userID, label)
+	}
+
+	return response, err
+}
@@ -3,6 +3,7 @@ package ciolite
 // Api functions that support: https://context.io/docs/lite/users/email_accounts/folders/messages
 
 import (
+	"context"
 	"bytes"
 	"encoding/json"
 	"fmt"
@@ -21,7 +22,7 @@ type GetUserEmailAccountsFolderMessageParams struct {
 	IncludeBody  bool   `json:"include_body,omitempty"`
 	IncludeFlags bool   `json:"include_flags,omitempty"`
 
-	// IncludeHeaders can be "0", "1", or "raw"
+	// IncludeHeaders can be "0", "1", or "raw" (see IncludeHeadersNone, IncludeHeadersParsed, IncludeHeadersRaw)
 	IncludeHeaders string `json:"include_headers,omitempty"`
 
 	// Optional for GetUserEmailAccountsFolderMessages (not used by GetUserEmailAccountFolderMessage):
@@ -49,6 +50,9 @@ type GetUsersEmailAccountFolderMessagesResponse struct {
 
 	PersonInfo PersonInfo `json:"person_info,omitempty"`
 
+	// Flags are only included if IncludeFlags is set
+	Flags UserEmailAccountsFolderMessageFlags `json:"flags,omitempty"`
+
 	Attachments []UsersEmailAccountFolderMessageAttachment `json:"attachments,omitempty"`
 
 	Bodies []UsersEmailAccountFolderMessageBody `json:"bodies,omitempty"`
@@ -203,6 +207,12 @@ type MoveUserEmailAccountFolderMessageResponse struct {
 // IncludeHeaders, IncludeFlags, Limit, Offset
 // 	https://context.io/docs/lite/users/email_accounts/folders/messages#get
 func (cioLite CioLite) GetUserEmailAccountsFolderMessages(userID string, label string, folder string, queryValues GetUserEmailAccountsFolderMessageParams) ([]GetUsersEmailAccountFolderMessagesResponse, error) {
+	return cioLite.GetUserEmailAccountsFolderMessagesContext(context.Background(), userID, label, folder, queryValues)
+}
+
+// GetUserEmailAccountsFolderMessagesContext is the same as GetUserEmailAccountsFolderMessages, but uses the provided context.Context
+// for cancellation and deadlines of the request (including any retries).
+func (cioLite CioLite) GetUserEmailAccountsFolderMessagesContext(ctx context.Context, userID string, label string, folder string, queryValues GetUserEmailAccountsFolderMessageParams) ([]GetUsersEmailAccountFolderMessagesResponse, error) {
 
 	// Make request
 	request := clientRequest{
@@ -217,7 +227,7 @@ func (cioLite CioLite) GetUserEmailAccountsFolderMessages(userID string, label s
 	var response []GetUsersEmailAccountFolderMessagesResponse
 
 	// Request
-	err := cioLite.doFormRequest(request, &response)
+	err := cioLite.doFormRequest(ctx, request, &response)
 
 	return response, err
 }
@@ -226,6 +236,12 @@ func (cioLite CioLite) GetUserEmailAccountsFolderMessages(userID string, label s
 // queryValues may optionally contain Delimiter, IncludeBody, BodyType, IncludeHeaders, IncludeFlags
 // 	https://context.io/docs/lite/users/email_accounts/folders/messages#id-get
 func (cioLite CioLite) GetUserEmailAccountFolderMessage(userID string, label string, folder string, messageID string, queryValues GetUserEmailAccountsFolderMessageParams) (GetUsersEmailAccountFolderMessagesResponse, error) {
+	return cioLite.GetUserEmailAccountFolderMessageContext(context.Background(), userID, label, folder, messageID, queryValues)
+}
+
+// GetUserEmailAccountFolderMessageContext is the same as GetUserEmailAccountFolderMessage, but uses the provided context.Context
+// for cancellation and deadlines of the request (including any retries).
+func (cioLite CioLite) GetUserEmailAccountFolderMessageContext(ctx context.Context, userID string, label string, folder string, messageID string, queryValues GetUserEmailAccountsFolderMessageParams) (GetUsersEmailAccountFolderMessagesResponse, error) {
 
 	// Make request
 	request := clientRequest{
@@ -240,7 +256,7 @@ func (cioLite CioLite) GetUserEmailAccountFolderMessage(userID string, label str
 	var response GetUsersEmailAccountFolderMessagesResponse
 
 	// Request
-	err := cioLite.doFormRequest(request, &response)
+	err := cioLite.doFormRequest(ctx, request, &response)
 
 	return response, err
 }
@@ -249,6 +265,12 @@ func (cioLite CioLite) GetUserEmailAccountFolderMessage(userID string, label str
 // formValues requires NewFolderID, and may optionally contain Delimiter
 // 	https://context.io/docs/lite/users/email_accounts/folders/messages#id-put
 func (cioLite CioLite) MoveUserEmailAccountFolderMessage(userID string, label string, folder string, messageID string, queryValues MoveUserEmailAccountFolderMessageParams) (MoveUserEmailAccountFolderMessageResponse, error) {
+	return cioLite.MoveUserEmailAccountFolderMessageContext(context.Background(), userID, label, folder, messageID, queryValues)
+}
+
+// MoveUserEmailAccountFolderMessageContext is the same as MoveUserEmailAccountFolderMessage, but uses the provided context.Context
+// for cancellation and deadlines of the request (including any retries).
+func (cioLite CioLite) MoveUserEmailAccountFolderMessageContext(ctx context.Context, userID string, label string, folder string, messageID string, queryValues MoveUserEmailAccountFolderMessageParams) (MoveUserEmailAccountFolderMessageResponse, error) {
 
 	// Make request
 	request := clientRequest{
@@ -263,7 +285,7 @@ func (cioLite CioLite) MoveUserEmailAccountFolderMessage(userID string, label st
 	var response MoveUserEmailAccountFolderMessageResponse
 
 	// Request
-	err := cioLite.doFormRequest(request, &response)
+	err := cioLite.doFormRequest(ctx, request, &response)
 
 	return response, err
 }
@@ -272,6 +294,12 @@ func (cioLite CioLite) MoveUserEmailAccountFolderMessage(userID string, label st
 // formValues requires NewFolderID, and may optionally contain Delimiter
 // 	https://context.io/docs/lite/users/email_accounts/folders/messages#id-put
 func (cioLite CioLite) MoveUserEmailAccountFolderMessage2(userID string, label string, folder string, messageID string, queryValues MoveUserEmailAccountFolderMessageParams) (MoveUserEmailAccountFolderMessageResponse, error) {
+	return cioLite.MoveUserEmailAccountFolderMessage2Context(context.Background(), userID, label, folder, messageID, queryValues)
+}
+
+// MoveUserEmailAccountFolderMessage2Context is the same as MoveUserEmailAccountFolderMessage2, but uses the provided context.Context
+// for cancellation and deadlines of the request (including any retries).
+func (cioLite CioLite) MoveUserEmailAccountFolderMessage2Context(ctx context.Context, userID string, label string, folder string, messageID string, queryValues MoveUserEmailAccountFolderMessageParams) (MoveUserEmailAccountFolderMessageResponse, error) {
 
 	// Make request
 	request := clientRequest{
@@ -286,7 +314,7 @@ func (cioLite CioLite) MoveUserEmailAccountFolderMessage2(userID string, label s
 	var response MoveUserEmailAccountFolderMessageResponse
 
 	// Request
-	err := cioLite.doFormRequest(request, &response)
+	err := cioLite.doFormRequest(ctx, request, &response)
 
 	return response, err
 }
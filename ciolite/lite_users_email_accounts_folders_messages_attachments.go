@@ -3,8 +3,17 @@ package ciolite
 // Api functions that support: https://context.io/docs/lite/users/email_accounts/folders/messages/attachments
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"mime"
 	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
 )
 
 // GetUserEmailAccountsFolderMessageAttachmentsResponse data struct
@@ -26,6 +35,12 @@ type GetUserEmailAccountsFolderMessageAttachmentsResponse struct {
 // queryValues may optionally contain Delimiter
 // 	https://context.io/docs/lite/users/email_accounts/folders/messages/attachments#get
 func (cioLite CioLite) GetUserEmailAccountsFolderMessageAttachments(userID string, label string, folder string, messageID string, queryValues EmailAccountFolderDelimiterParam) ([]GetUserEmailAccountsFolderMessageAttachmentsResponse, error) {
+	return cioLite.GetUserEmailAccountsFolderMessageAttachmentsContext(context.Background(), userID, label, folder, messageID, queryValues)
+}
+
+// GetUserEmailAccountsFolderMessageAttachmentsContext is the same as GetUserEmailAccountsFolderMessageAttachments, but uses the provided context.Context
+// for cancellation and deadlines of the request (including any retries).
+func (cioLite CioLite) GetUserEmailAccountsFolderMessageAttachmentsContext(ctx context.Context, userID string, label string, folder string, messageID string, queryValues EmailAccountFolderDelimiterParam) ([]GetUserEmailAccountsFolderMessageAttachmentsResponse, error) {
 
 	// Make request
 	request := clientRequest{
@@ -40,7 +55,7 @@ func (cioLite CioLite) GetUserEmailAccountsFolderMessageAttachments(userID strin
 	var response []GetUserEmailAccountsFolderMessageAttachmentsResponse
 
 	// Request
-	err := cioLite.doFormRequest(request, &response)
+	err := cioLite.doFormRequest(ctx, request, &response)
 
 	return response, err
 }
@@ -49,6 +64,12 @@ func (cioLite CioLite) GetUserEmailAccountsFolderMessageAttachments(userID strin
 // queryValues may optionally contain Delimiter
 // 	https://context.io/docs/lite/users/email_accounts/folders/messages/attachments#id-get
 func (cioLite CioLite) GetUserEmailAccountsFolderMessageAttachment(userID string, label string, folder string, messageID string, attachmentID string, queryValues EmailAccountFolderDelimiterParam) (GetUserEmailAccountsFolderMessageAttachmentsResponse, error) {
+	return cioLite.GetUserEmailAccountsFolderMessageAttachmentContext(context.Background(), userID, label, folder, messageID, attachmentID, queryValues)
+}
+
+// GetUserEmailAccountsFolderMessageAttachmentContext is the same as GetUserEmailAccountsFolderMessageAttachment, but uses the provided context.Context
+// for cancellation and deadlines of the request (including any retries).
+func (cioLite CioLite) GetUserEmailAccountsFolderMessageAttachmentContext(ctx context.Context, userID string, label string, folder string, messageID string, attachmentID string, queryValues EmailAccountFolderDelimiterParam) (GetUserEmailAccountsFolderMessageAttachmentsResponse, error) {
 
 	// Make request
 	request := clientRequest{
@@ -63,7 +84,158 @@ func (cioLite CioLite) GetUserEmailAccountsFolderMessageAttachment(userID string
 	var response GetUserEmailAccountsFolderMessageAttachmentsResponse
 
 	// Request
-	err := cioLite.doFormRequest(request, &response)
+	err := cioLite.doFormRequest(ctx, request, &response)
 
 	return response, err
 }
+
+// AttachmentContent is the streamed content of an email attachment, which must be closed once read.
+type AttachmentContent struct {
+	io.ReadCloser
+
+	// ContentType is the MIME type of the attachment (ex: application/pdf), if known
+	ContentType string
+
+	// FileName is the file name from the Content-Disposition header, if any
+	FileName string
+
+	// Size is the length of the content in bytes, or -1 if unknown
+	Size int64
+}
+
+// GetUserEmailAccountsFolderMessageAttachmentContent downloads the content of an email attachment,
+// without reading it into memory. The caller must close the returned *AttachmentContent.
+// queryValues may optionally contain Delimiter
+// 	https://context.io/docs/lite/users/email_accounts/folders/messages/attachments#id-get
+func (cioLite CioLite) GetUserEmailAccountsFolderMessageAttachmentContent(userID string, label string, folder string, messageID string, attachmentID string, queryValues EmailAccountFolderDelimiterParam) (*AttachmentContent, error) {
+	return cioLite.GetUserEmailAccountsFolderMessageAttachmentContentContext(context.Background(), userID, label, folder, messageID, attachmentID, queryValues)
+}
+
+// GetUserEmailAccountsFolderMessageAttachmentContentContext is the same as GetUserEmailAccountsFolderMessageAttachmentContent, but uses the provided context.Context
+// for cancellation and deadlines of the request (including any retries), and of reading the content.
+func (cioLite CioLite) GetUserEmailAccountsFolderMessageAttachmentContentContext(ctx context.Context, userID string, label string, folder string, messageID string, attachmentID string, queryValues EmailAccountFolderDelimiterParam) (*AttachmentContent, error) {
+
+	// Make request
+	request := clientRequest{
+		Method:       "GET",
+		Path:         fmt.Sprintf("/lite/users/%s/email_accounts/%s/folders/%s/messages/%s/attachments/%s", userID, label, url.QueryEscape(folder), url.QueryEscape(messageID), attachmentID),
+		QueryValues:  queryValues,
+		UserID:       userID,
+		AccountLabel: label,
+		Accept:       "*/*",
+	}
+
+	// Request
+	res, err := cioLite.doStreamRequest(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	// Make response
+	content := &AttachmentContent{
+		ReadCloser:  res.Body,
+		ContentType: res.Header.Get("Content-Type"),
+		Size:        res.ContentLength,
+	}
+	if _, params, err := mime.ParseMediaType(res.Header.Get("Content-Disposition")); err == nil {
+		content.FileName = params["filename"]
+	}
+
+	return content, nil
+}
+
+// SaveUserEmailAccountsFolderMessageAttachments downloads all attachments of an email into the directory
+// (which must already exist), and returns the paths of the files written. Files are named after the
+// attachment's file name (made unique within the email), and existing files with the same name are overwritten.
+// queryValues may optionally contain Delimiter
+func (cioLite CioLite) SaveUserEmailAccountsFolderMessageAttachments(userID string, label string, folder string, messageID string, dir string, queryValues EmailAccountFolderDelimiterParam) ([]string, error) {
+	return cioLite.SaveUserEmailAccountsFolderMessageAttachmentsContext(context.Background(), userID, label, folder, messageID, dir, queryValues)
+}
+
+// SaveUserEmailAccountsFolderMessageAttachmentsContext is the same as SaveUserEmailAccountsFolderMessageAttachments, but uses the provided context.Context
+// for cancellation and deadlines of the requests (including any retries).
+// If an error occurs, the paths of the files already written are returned with it.
+func (cioLite CioLite) SaveUserEmailAccountsFolderMessageAttachmentsContext(ctx context.Context, userID string, label string, folder string, messageID string, dir string, queryValues EmailAccountFolderDelimiterParam) ([]string, error) {
+	attachments, err := cioLite.GetUserEmailAccountsFolderMessageAttachmentsContext(ctx, userID, label, folder, messageID, queryValues)
+	if err != nil {
+		return nil, err
+	}
+
+	var paths []string
+	usedNames := make(map[string]bool, len(attachments))
+	for _, attachment := range attachments {
+		attachmentID := strconv.Itoa(attachment.AttachmentID)
+
+		content, err := cioLite.GetUserEmailAccountsFolderMessageAttachmentContentContext(ctx, userID, label, folder, messageID, attachmentID, queryValues)
+		if err != nil {
+			return paths, err
+		}
+
+		name := attachment.FileName
+		if len(name) == 0 {
+			name = content.FileName
+		}
+		path := filepath.Join(dir, uniqueFileName(safeFileName(name, "attachment-"+attachmentID), usedNames))
+
+		if err = writeFile(path, content); err != nil {
+			return paths, errors.Wrap(err, "CIO: Could not save attachment "+attachmentID)
+		}
+		paths = append(paths, path)
+	}
+
+	return paths, nil
+}
+
+// safeFileName strips any directories from the name, so that it can not be used to write outside
+// of the directory, and returns the fallback if nothing usable is left
+func safeFileName(name string, fallback string) string {
+	name = filepath.Base(strings.Replace(name, "\\", "/", -1))
+	name = strings.TrimSpace(strings.Map(func(r rune) rune {
+		if r < ' ' || r == '/' || r == 0x7f {
+			return -1
+		}
+		return r
+	}, name))
+	if len(name) == 0 || name == "." || name == ".." {
+		return fallback
+	}
+	return name
+}
+
+// uniqueFileName returns the name, or the name with a numbered suffix (ex: file (2).pdf)
+// if it has already been used, and records it as used
+func uniqueFileName(name string, usedNames map[string]bool) string {
+	unique := name
+	ext := filepath.Ext(name)
+	for i := 2; usedNames[strings.ToLower(unique)]; i++ {
+		unique = fmt.Sprintf("%s (%d)%s", strings.TrimSuffix(name, ext), i, ext)
+	}
+	usedNames[strings.ToLower(unique)] = true
+	return unique
+}
+
+// writeFile copies the reader to the file at path and closes the reader,
+// removing the partially written file on error
+func writeFile(path string, reader io.ReadCloser) (err error) {
+	defer func() {
+		if closeErr := reader.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if _, err = io.Copy(file, reader); err != nil {
+		_ = file.Close()
+		_ = os.Remove(path)
+		return err
+	}
+	if err = file.Close(); err != nil {
+		_ = os.Remove(path)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package ciolite
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestSimulatedGetUserEmailAccountsFolderMessageAttachmentContent tests downloading an attachment's content
+func TestSimulatedGetUserEmailAccountsFolderMessageAttachmentContent(t *testing.T) {
+	t.Parallel()
+
+	cioLite, logger, testServer, mux := NewTestCioLiteWithLoggerAndTestServer(t)
+	defer testServer.Close()
+
+	mux.HandleFunc("/lite/users/123abc/email_accounts/0/folders/INBOX/messages/msg1/attachments/1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept") == "application/json" {
+			t.Error("Expected a non-json Accept header")
+		}
+		w.Header().Set("Content-Type", "application/pdf")
+		w.Header().Set("Content-Disposition", `attachment; filename="report.pdf"`)
+		w.Header().Set("Content-Length", "11")
+		_, err := io.WriteString(w, "%PDF-1.4...")
+		Must(err)
+	})
+
+	content, err := cioLite.GetUserEmailAccountsFolderMessageAttachmentContent("123abc", "0", "INBOX", "msg1", "1", EmailAccountFolderDelimiterParam{})
+	if err != nil {
+		t.Fatal("Expected no error; Got: ", err, "; With Log: ", logger.String())
+	}
+	defer content.Close()
+
+	if content.ContentType != "application/pdf" || content.FileName != "report.pdf" || content.Size != 11 {
+		t.Error("Expected: ", "application/pdf report.pdf 11", "; Got: ", content.ContentType, content.FileName, content.Size)
+	}
+
+	body, err := ioutil.ReadAll(content)
+	if err != nil || string(body) != "%PDF-1.4..." {
+		t.Error("Expected: ", "%PDF-1.4...", "; Got: ", string(body), "; With Error: ", err)
+	}
+}
+
+// TestSimulatedSaveUserEmailAccountsFolderMessageAttachments tests saving all attachments of a message to a directory
+func TestSimulatedSaveUserEmailAccountsFolderMessageAttachments(t *testing.T) {
+	t.Parallel()
+
+	cioLite, logger, testServer, mux := NewTestCioLiteWithLoggerAndTestServer(t)
+	defer testServer.Close()
+
+	mux.HandleFunc("/lite/users/123abc/email_accounts/0/folders/INBOX/messages/msg1/attachments", func(w http.ResponseWriter, r *http.Request) {
+		_, err := io.WriteString(w, `[{"file_name":"notes.txt","attachment_id":1},{"file_name":"notes.txt","attachment_id":2},{"file_name":"../../evil.txt","attachment_id":3},{"attachment_id":4}]`)
+		Must(err)
+	})
+	mux.HandleFunc("/lite/users/123abc/email_accounts/0/folders/INBOX/messages/msg1/attachments/", func(w http.ResponseWriter, r *http.Request) {
+		_, err := io.WriteString(w, "content "+filepath.Base(r.URL.Path))
+		Must(err)
+	})
+
+	dir, err := ioutil.TempDir("", "ciolite")
+	Must(err)
+	defer os.RemoveAll(dir)
+
+	paths, err := cioLite.SaveUserEmailAccountsFolderMessageAttachments("123abc", "0", "INBOX", "msg1", dir, EmailAccountFolderDelimiterParam{})
+	if err != nil {
+		t.Fatal("Expected no error; Got: ", err, "; With Log: ", logger.String())
+	}
+
+	expected := map[string]string{
+		"notes.txt":     "content 1",
+		"notes (2).txt": "content 2",
+		"evil.txt":      "content 3",
+		"attachment-4":  "content 4",
+	}
+	if len(paths) != len(expected) {
+		t.Fatal("Expected paths: ", len(expected), "; Got: ", paths)
+	}
+	for _, path := range paths {
+		if filepath.Dir(path) != dir {
+			t.Error("Expected path in: ", dir, "; Got: ", path)
+		}
+		body, err := ioutil.ReadFile(path)
+		if err != nil || string(body) != expected[filepath.Base(path)] {
+			t.Error("Expected: ", expected[filepath.Base(path)], "; Got: ", string(body), "; For: ", path, "; With Error: ", err)
+		}
+	}
+
+	files, err := ioutil.ReadDir(dir)
+	Must(err)
+	if len(files) != len(expected) {
+		names := make([]string, 0, len(files))
+		for _, file := range files {
+			names = append(names, file.Name())
+		}
+		t.Error("Expected files: ", len(expected), "; Got: ", strings.Join(names, ", "))
+	}
+}
@@ -3,6 +3,7 @@ package ciolite
 // Api functions that support: https://context.io/docs/lite/users/email_accounts/folders/messages/body
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 )
@@ -29,6 +30,12 @@ type GetUserEmailAccountsFolderMessageBodyResponse struct {
 // queryValues may optionally contain Delimiter, Type
 // 	https://context.io/docs/lite/users/email_accounts/folders/messages/body#get
 func (cioLite CioLite) GetUserEmailAccountsFolderMessageBody(userID string, label string, folder string, messageID string, queryValues GetUserEmailAccountsFolderMessageBodyParams) ([]GetUserEmailAccountsFolderMessageBodyResponse, error) {
+	return cioLite.GetUserEmailAccountsFolderMessageBodyContext(context.Background(), userID, label, folder, messageID, queryValues)
+}
+
+// GetUserEmailAccountsFolderMessageBodyContext is the same as GetUserEmailAccountsFolderMessageBody, but uses the provided context.Context
+// for cancellation and deadlines of the request (including any retries).
+func (cioLite CioLite) GetUserEmailAccountsFolderMessageBodyContext(ctx context.Context, userID string, label string, folder string, messageID string, queryValues GetUserEmailAccountsFolderMessageBodyParams) ([]GetUserEmailAccountsFolderMessageBodyResponse, error) {
 
 	// Make request
 	request := clientRequest{
@@ -43,7 +50,7 @@ func (cioLite CioLite) GetUserEmailAccountsFolderMessageBody(userID string, labe
 	var response []GetUserEmailAccountsFolderMessageBodyResponse
 
 	// Request
-	err := cioLite.doFormRequest(request, &response)
+	err := cioLite.doFormRequest(ctx, request, &response)
 
 	return response, err
 }
@@ -3,6 +3,7 @@ package ciolite
 // Api functions that support: https://context.io/docs/lite/users/email_accounts/folders/messages/flags
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 )
@@ -22,12 +23,51 @@ type UserEmailAccountsFolderMessageFlags struct {
 	Answered bool `json:"answered,omitempty"`
 	Flagged  bool `json:"flagged,omitempty"`
 	Draft    bool `json:"draft,omitempty"`
+	Deleted  bool `json:"deleted,omitempty"`
+}
+
+// Values for the flags of SetUserEmailAccountsFolderMessageFlagsParams
+const (
+	// FlagSet sets the flag
+	FlagSet = "1"
+	// FlagClear clears the flag
+	FlagClear = "0"
+	// FlagUnchanged leaves the flag as it is
+	FlagUnchanged = ""
+)
+
+// SetUserEmailAccountsFolderMessageFlagsParams form values data struct.
+// Each flag can be FlagSet, FlagClear, or FlagUnchanged (the default).
+// Optional: Delimiter, Seen, Answered, Flagged, Draft, Deleted.
+// 	https://context.io/docs/lite/users/email_accounts/folders/messages/flags#post
+type SetUserEmailAccountsFolderMessageFlagsParams struct {
+	// Optional:
+	Delimiter string `json:"delimiter,omitempty"`
+	Seen      string `json:"seen,omitempty"`
+	Answered  string `json:"answered,omitempty"`
+	Flagged   string `json:"flagged,omitempty"`
+	Draft     string `json:"draft,omitempty"`
+	Deleted   string `json:"deleted,omitempty"`
+}
+
+// SetUserEmailAccountsFolderMessageFlagsResponse data struct
+// 	https://context.io/docs/lite/users/email_accounts/folders/messages/flags#post
+type SetUserEmailAccountsFolderMessageFlagsResponse struct {
+	Success bool `json:"success,omitempty"`
+
+	Flags UserEmailAccountsFolderMessageFlags `json:"flags,omitempty"`
 }
 
 // GetUserEmailAccountsFolderMessageFlags returns the message flags.
 // queryValues may optionally contain Delimiter
 // 	https://context.io/docs/lite/users/email_accounts/folders/messages/flags#get
 func (cioLite CioLite) GetUserEmailAccountsFolderMessageFlags(userID string, label string, folder string, messageID string, queryValues EmailAccountFolderDelimiterParam) (GetUserEmailAccountsFolderMessageFlagsResponse, error) {
+	return cioLite.GetUserEmailAccountsFolderMessageFlagsContext(context.Background(), userID, label, folder, messageID, queryValues)
+}
+
+// GetUserEmailAccountsFolderMessageFlagsContext is the same as GetUserEmailAccountsFolderMessageFlags, but uses the provided context.Context
+// for cancellation and deadlines of the request (including any retries).
+func (cioLite CioLite) GetUserEmailAccountsFolderMessageFlagsContext(ctx context.Context, userID string, label string, folder string, messageID string, queryValues EmailAccountFolderDelimiterParam) (GetUserEmailAccountsFolderMessageFlagsResponse, error) {
 
 	// Make request
 	request := clientRequest{
@@ -42,7 +82,36 @@ func (cioLite CioLite) GetUserEmailAccountsFolderMessageFlags(userID string, lab
 	var response GetUserEmailAccountsFolderMessageFlagsResponse
 
 	// Request
-	err := cioLite.doFormRequest(request, &response)
+	err := cioLite.doFormRequest(ctx, request, &response)
+
+	return response, err
+}
+
+// SetUserEmailAccountsFolderMessageFlags sets and/or clears any of the message flags in one call.
+// formValues may optionally contain Delimiter, Seen, Answered, Flagged, Draft, Deleted
+// 	https://context.io/docs/lite/users/email_accounts/folders/messages/flags#post
+func (cioLite CioLite) SetUserEmailAccountsFolderMessageFlags(userID string, label string, folder string, messageID string, formValues SetUserEmailAccountsFolderMessageFlagsParams) (SetUserEmailAccountsFolderMessageFlagsResponse, error) {
+	return cioLite.SetUserEmailAccountsFolderMessageFlagsContext(context.Background(), userID, label, folder, messageID, formValues)
+}
+
+// SetUserEmailAccountsFolderMessageFlagsContext is the same as SetUserEmailAccountsFolderMessageFlags, but uses the provided context.Context
+// for cancellation and deadlines of the request (including any retries).
+func (cioLite CioLite) SetUserEmailAccountsFolderMessageFlagsContext(ctx context.Context, userID string, label string, folder string, messageID string, formValues SetUserEmailAccountsFolderMessageFlagsParams) (SetUserEmailAccountsFolderMessageFlagsResponse, error) {
+
+	// Make request
+	request := clientRequest{
+		Method:       "POST",
+		Path:         fmt.Sprintf("/lite/users/%s/email_accounts/%s/folders/%s/messages/%s/flags", userID, label, url.QueryEscape(folder), url.QueryEscape(messageID)),
+		FormValues:   formValues,
+		UserID:       userID,
+		AccountLabel: label,
+	}
+
+	// Make response
+	var response SetUserEmailAccountsFolderMessageFlagsResponse
+
+	// Request
+	err := cioLite.doFormRequest(ctx, request, &response)
 
 	return response, err
 }
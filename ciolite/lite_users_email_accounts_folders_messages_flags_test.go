@@ -0,0 +1,45 @@
+package ciolite
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+// TestSimulatedSetUserEmailAccountsFolderMessageFlags tests that only the set or cleared flags are sent
+func TestSimulatedSetUserEmailAccountsFolderMessageFlags(t *testing.T) {
+	t.Parallel()
+
+	cioLite, logger, testServer, mux := NewTestCioLiteWithLoggerAndTestServer(t)
+	defer testServer.Close()
+
+	mux.HandleFunc("/lite/users/123abc/email_accounts/0/folders/INBOX/messages/msg1/flags", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Error("Expected method: ", "POST", "; Got: ", r.Method)
+		}
+		Must(r.ParseForm())
+		expectedForm := url.Values{"flagged": {"1"}, "answered": {"1"}, "seen": {"0"}}
+		if !reflect.DeepEqual(r.PostForm, expectedForm) {
+			t.Error("Expected form: ", expectedForm, "; Got: ", r.PostForm)
+		}
+		_, err := io.WriteString(w, `{"success":true,"flags":{"read":false,"answered":true,"flagged":true,"draft":false}}`)
+		Must(err)
+	})
+
+	expectedResponse := SetUserEmailAccountsFolderMessageFlagsResponse{
+		Success: true,
+		Flags:   UserEmailAccountsFolderMessageFlags{Answered: true, Flagged: true},
+	}
+
+	response, err := cioLite.SetUserEmailAccountsFolderMessageFlags("123abc", "0", "INBOX", "msg1", SetUserEmailAccountsFolderMessageFlagsParams{
+		Flagged:  FlagSet,
+		Answered: FlagSet,
+		Seen:     FlagClear,
+	})
+
+	if err != nil || !reflect.DeepEqual(response, expectedResponse) {
+		t.Error("Expected: ", expectedResponse, "; Got: ", response, "; With Error: ", err, "; With Log: ", logger.String())
+	}
+}
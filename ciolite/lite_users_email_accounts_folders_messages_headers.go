@@ -3,6 +3,7 @@ package ciolite
 // Api functions that support: https://context.io/docs/lite/users/email_accounts/folders/messages/headers
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 )
@@ -28,6 +29,12 @@ type GetUserEmailAccountsFolderMessageHeadersResponse struct {
 // queryValues may optionally contain Delimiter, Raw
 // 	https://context.io/docs/lite/users/email_accounts/folders/messages/headers#get
 func (cioLite CioLite) GetUserEmailAccountsFolderMessageHeaders(userID string, label string, folder string, messageID string, queryValues GetUserEmailAccountsFolderMessageHeadersParams) (GetUserEmailAccountsFolderMessageHeadersResponse, error) {
+	return cioLite.GetUserEmailAccountsFolderMessageHeadersContext(context.Background(), userID, label, folder, messageID, queryValues)
+}
+
+// GetUserEmailAccountsFolderMessageHeadersContext is the same as GetUserEmailAccountsFolderMessageHeaders, but uses the provided context.Context
+// for cancellation and deadlines of the request (including any retries).
+func (cioLite CioLite) GetUserEmailAccountsFolderMessageHeadersContext(ctx context.Context, userID string, label string, folder string, messageID string, queryValues GetUserEmailAccountsFolderMessageHeadersParams) (GetUserEmailAccountsFolderMessageHeadersResponse, error) {
 
 	// Make request
 	request := clientRequest{
@@ -42,7 +49,7 @@ func (cioLite CioLite) GetUserEmailAccountsFolderMessageHeaders(userID string, l
 	var response GetUserEmailAccountsFolderMessageHeadersResponse
 
 	// Request
-	err := cioLite.doFormRequest(request, &response)
+	err := cioLite.doFormRequest(ctx, request, &response)
 
 	return response, err
 }
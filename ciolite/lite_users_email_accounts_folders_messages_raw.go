@@ -3,8 +3,13 @@ package ciolite
 // Api functions that support: https://context.io/docs/lite/users/email_accounts/folders/messages/raw
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"net/url"
+
+	"github.com/pkg/errors"
 )
 
 // GetUserEmailAccountsFolderMessageRawResponse data struct
@@ -15,6 +20,59 @@ type GetUserEmailAccountsFolderMessageRawResponse string
 // queryValues may optionally contain Delimiter
 // 	https://context.io/docs/lite/users/email_accounts/folders/messages/raw#get
 func (cioLite CioLite) GetUserEmailAccountsFolderMessageRaw(userID string, label string, folder string, messageID string, queryValues EmailAccountFolderDelimiterParam) (GetUserEmailAccountsFolderMessageRawResponse, error) {
+	return cioLite.GetUserEmailAccountsFolderMessageRawContext(context.Background(), userID, label, folder, messageID, queryValues)
+}
+
+// GetUserEmailAccountsFolderMessageRawContext is the same as GetUserEmailAccountsFolderMessageRaw, but uses the provided context.Context
+// for cancellation and deadlines of the request (including any retries).
+func (cioLite CioLite) GetUserEmailAccountsFolderMessageRawContext(ctx context.Context, userID string, label string, folder string, messageID string, queryValues EmailAccountFolderDelimiterParam) (GetUserEmailAccountsFolderMessageRawResponse, error) {
+
+	// Request
+	stream, err := cioLite.StreamUserEmailAccountsFolderMessageRawContext(ctx, userID, label, folder, messageID, queryValues)
+	if err != nil {
+		return "", err
+	}
+	defer stream.Close()
+
+	// Read the whole message
+	raw, err := ioutil.ReadAll(stream)
+	if err != nil {
+		return "", errors.Wrap(err, "CIO: Could not read raw message")
+	}
+
+	return GetUserEmailAccountsFolderMessageRawResponse(raw), nil
+}
+
+// RawMessageStream is the streamed raw RFC-822 message text of a given email,
+// which must be closed once read.
+type RawMessageStream struct {
+	io.ReadCloser
+}
+
+// WriteTo copies the rest of the raw message to w (ex: an object storage upload), and closes the stream.
+// Implements io.WriterTo.
+func (stream *RawMessageStream) WriteTo(w io.Writer) (int64, error) {
+	n, err := io.Copy(w, stream.ReadCloser)
+	if closeErr := stream.Close(); err == nil && closeErr != nil {
+		err = closeErr
+	}
+	if err != nil {
+		return n, errors.Wrap(err, "CIO: Could not copy raw message")
+	}
+	return n, nil
+}
+
+// StreamUserEmailAccountsFolderMessageRaw fetches the raw RFC-822 message text of a given email,
+// without reading it into memory. The caller must close the returned *RawMessageStream.
+// queryValues may optionally contain Delimiter
+// 	https://context.io/docs/lite/users/email_accounts/folders/messages/raw#get
+func (cioLite CioLite) StreamUserEmailAccountsFolderMessageRaw(userID string, label string, folder string, messageID string, queryValues EmailAccountFolderDelimiterParam) (*RawMessageStream, error) {
+	return cioLite.StreamUserEmailAccountsFolderMessageRawContext(context.Background(), userID, label, folder, messageID, queryValues)
+}
+
+// StreamUserEmailAccountsFolderMessageRawContext is the same as StreamUserEmailAccountsFolderMessageRaw, but uses the provided context.Context
+// for cancellation and deadlines of the request (including any retries), and of reading the stream.
+func (cioLite CioLite) StreamUserEmailAccountsFolderMessageRawContext(ctx context.Context, userID string, label string, folder string, messageID string, queryValues EmailAccountFolderDelimiterParam) (*RawMessageStream, error) {
 
 	// Make request
 	request := clientRequest{
@@ -23,13 +81,14 @@ func (cioLite CioLite) GetUserEmailAccountsFolderMessageRaw(userID string, label
 		QueryValues:  queryValues,
 		UserID:       userID,
 		AccountLabel: label,
+		Accept:       "*/*",
 	}
 
-	// Make response
-	var response GetUserEmailAccountsFolderMessageRawResponse
-
 	// Request
-	err := cioLite.doFormRequest(request, &response)
+	res, err := cioLite.doStreamRequest(ctx, request)
+	if err != nil {
+		return nil, err
+	}
 
-	return response, err
+	return &RawMessageStream{ReadCloser: res.Body}, nil
 }
@@ -0,0 +1,87 @@
+package ciolite
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// testRawMessage is a minimal RFC-822 message
+const testRawMessage = "From: a@example.com\r\nTo: b@example.com\r\nSubject: Hello\r\n\r\nHello World\r\n"
+
+// TestSimulatedStreamUserEmailAccountsFolderMessageRaw tests streaming a raw message, including after a retry
+func TestSimulatedStreamUserEmailAccountsFolderMessageRaw(t *testing.T) {
+	t.Parallel()
+
+	cioLite, logger, testServer, mux := NewTestCioLiteWithLoggerAndTestServer(t)
+	defer testServer.Close()
+
+	cioLite.RetryPolicy = &RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond, MaxDelay: time.Second, RetryableStatusCodes: DefaultRetryableStatusCodes}
+
+	attempts := 0
+	mux.HandleFunc("/lite/users/123abc/email_accounts/0/folders/INBOX/messages/<a@b.c>/raw", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if r.Header.Get("Accept") == "application/json" {
+			t.Error("Expected a non-json Accept header")
+		}
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, err := io.WriteString(w, `{"type":"error","value":"unavailable"}`)
+			Must(err)
+			return
+		}
+		w.Header().Set("Content-Type", "message/rfc822")
+		_, err := io.WriteString(w, testRawMessage)
+		Must(err)
+	})
+
+	stream, err := cioLite.StreamUserEmailAccountsFolderMessageRaw("123abc", "0", "INBOX", "<a@b.c>", EmailAccountFolderDelimiterParam{})
+	if err != nil {
+		t.Fatal("Expected no error; Got: ", err, "; With Log: ", logger.String())
+	}
+
+	var buf bytes.Buffer
+	n, err := stream.WriteTo(&buf)
+	if err != nil {
+		t.Error("Expected no error; Got: ", err)
+	}
+	if buf.String() != testRawMessage || n != int64(len(testRawMessage)) {
+		t.Error("Expected: ", testRawMessage, "; Got: ", buf.String(), "; With Length: ", n)
+	}
+	if attempts != 2 {
+		t.Error("Expected attempts: ", 2, "; Got: ", attempts)
+	}
+
+	// The buffered version should return the same message, without trying to unmarshal it as json
+	raw, err := cioLite.GetUserEmailAccountsFolderMessageRaw("123abc", "0", "INBOX", "<a@b.c>", EmailAccountFolderDelimiterParam{})
+	if err != nil || string(raw) != testRawMessage {
+		t.Error("Expected: ", testRawMessage, "; Got: ", raw, "; With Error: ", err)
+	}
+}
+
+// TestSimulatedStreamUserEmailAccountsFolderMessageRawError tests that an error response is returned as a RequestError
+func TestSimulatedStreamUserEmailAccountsFolderMessageRawError(t *testing.T) {
+	t.Parallel()
+
+	cioLite, _, testServer, mux := NewTestCioLiteWithLoggerAndTestServer(t)
+	defer testServer.Close()
+
+	mux.HandleFunc("/lite/users/123abc/email_accounts/0/folders/INBOX/messages/missing/raw", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, err := io.WriteString(w, `{"type":"error","value":"not found"}`)
+		Must(err)
+	})
+
+	stream, err := cioLite.StreamUserEmailAccountsFolderMessageRaw("123abc", "0", "INBOX", "missing", EmailAccountFolderDelimiterParam{})
+	if stream != nil {
+		t.Error("Expected nil stream; Got: ", stream)
+	}
+	if ErrorStatusCode(err) != http.StatusNotFound {
+		t.Error("Expected error status code: ", http.StatusNotFound, "; Got: ", ErrorStatusCode(err))
+	}
+	if ErrorPayload(err) != `{"type":"error","value":"not found"}` {
+		t.Error("Expected error payload; Got: ", ErrorPayload(err))
+	}
+}
@@ -3,6 +3,7 @@ package ciolite
 // Api functions that support: https://context.io/docs/lite/users/email_accounts/folders/messages/read
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 )
@@ -18,6 +19,12 @@ type UserEmailAccountsFolderMessageReadResponse struct {
 // formValues may optionally contain Delimiter
 // 	https://context.io/docs/lite/users/email_accounts/folders/messages/read#post
 func (cioLite CioLite) MarkUserEmailAccountsFolderMessageRead(userID string, label string, folder string, messageID string, formValues EmailAccountFolderDelimiterParam) (UserEmailAccountsFolderMessageReadResponse, error) {
+	return cioLite.MarkUserEmailAccountsFolderMessageReadContext(context.Background(), userID, label, folder, messageID, formValues)
+}
+
+// MarkUserEmailAccountsFolderMessageReadContext is the same as MarkUserEmailAccountsFolderMessageRead, but uses the provided context.Context
+// for cancellation and deadlines of the request (including any retries).
+func (cioLite CioLite) MarkUserEmailAccountsFolderMessageReadContext(ctx context.Context, userID string, label string, folder string, messageID string, formValues EmailAccountFolderDelimiterParam) (UserEmailAccountsFolderMessageReadResponse, error) {
 
 	// Make request
 	request := clientRequest{
@@ -32,7 +39,7 @@ func (cioLite CioLite) MarkUserEmailAccountsFolderMessageRead(userID string, lab
 	var response UserEmailAccountsFolderMessageReadResponse
 
 	// Request
-	err := cioLite.doFormRequest(request, &response)
+	err := cioLite.doFormRequest(ctx, request, &response)
 
 	return response, err
 }
@@ -41,6 +48,12 @@ func (cioLite CioLite) MarkUserEmailAccountsFolderMessageRead(userID string, lab
 // formValues may optionally contain Delimiter
 // 	https://context.io/docs/lite/users/email_accounts/folders/messages/read#delete
 func (cioLite CioLite) MarkUserEmailAccountsFolderMessageUnRead(userID string, label string, folder string, messageID string, formValues EmailAccountFolderDelimiterParam) (UserEmailAccountsFolderMessageReadResponse, error) {
+	return cioLite.MarkUserEmailAccountsFolderMessageUnReadContext(context.Background(), userID, label, folder, messageID, formValues)
+}
+
+// MarkUserEmailAccountsFolderMessageUnReadContext is the same as MarkUserEmailAccountsFolderMessageUnRead, but uses the provided context.Context
+// for cancellation and deadlines of the request (including any retries).
+func (cioLite CioLite) MarkUserEmailAccountsFolderMessageUnReadContext(ctx context.Context, userID string, label string, folder string, messageID string, formValues EmailAccountFolderDelimiterParam) (UserEmailAccountsFolderMessageReadResponse, error) {
 
 	// Make request
 	request := clientRequest{
@@ -55,7 +68,7 @@ func (cioLite CioLite) MarkUserEmailAccountsFolderMessageUnRead(userID string, l
 	var response UserEmailAccountsFolderMessageReadResponse
 
 	// Request
-	err := cioLite.doFormRequest(request, &response)
+	err := cioLite.doFormRequest(ctx, request, &response)
 
 	return response, err
 }
@@ -55,3 +55,126 @@ func TestSimulatedGetUserEmailAccountFolder(t *testing.T) {
 		t.Error("Expected some output from logger; Got: ", logger.String())
 	}
 }
+
+// TestSimulatedCreateUserEmailAccountFolderAll tests that only the missing folders are created, parents first
+func TestSimulatedCreateUserEmailAccountFolderAll(t *testing.T) {
+	t.Parallel()
+
+	cioLite, logger, testServer, mux := NewTestCioLiteWithLoggerAndTestServer(t)
+	defer testServer.Close()
+
+	mux.HandleFunc("/lite/users/123abc/email_accounts/0/folders", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("include_names_only") != "" {
+			t.Error("Expected the full folder info to be listed; Got: ", r.URL.RawQuery)
+		}
+		_, err := io.WriteString(w, `[{"name":"INBOX","delimiter":"."},{"name":"Archive","delimiter":"."}]`)
+		Must(err)
+	})
+
+	var created []string
+	mux.HandleFunc("/lite/users/123abc/email_accounts/0/folders/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Error("Expected method: ", "POST", "; Got: ", r.Method)
+		}
+		Must(r.ParseForm())
+		if r.PostForm.Get("delimiter") != "." {
+			t.Error("Expected delimiter: ", ".", "; Got: ", r.PostForm.Get("delimiter"))
+		}
+		created = append(created, strings.TrimPrefix(r.URL.Path, "/lite/users/123abc/email_accounts/0/folders/"))
+		_, err := io.WriteString(w, `{"success":true}`)
+		Must(err)
+	})
+
+	createdFolders, err := cioLite.CreateUserEmailAccountFolderAll("123abc", "0", "Archive.2026.Q3", EmailAccountFolderDelimiterParam{})
+
+	expected := []string{"Archive.2026", "Archive.2026.Q3"}
+	if err != nil || !reflect.DeepEqual(createdFolders, expected) || !reflect.DeepEqual(created, expected) {
+		t.Error("Expected: ", expected, "; Got: ", createdFolders, created, "; With Error: ", err, "; With Log: ", logger.String())
+	}
+}
+
+// TestSimulatedCreateUserEmailAccountFolderAllInbox tests that INBOX is matched case-insensitively, as in IMAP
+func TestSimulatedCreateUserEmailAccountFolderAllInbox(t *testing.T) {
+	t.Parallel()
+
+	cioLite, logger, testServer, mux := NewTestCioLiteWithLoggerAndTestServer(t)
+	defer testServer.Close()
+
+	mux.HandleFunc("/lite/users/123abc/email_accounts/0/folders", func(w http.ResponseWriter, r *http.Request) {
+		_, err := io.WriteString(w, `[{"name":"Inbox","delimiter":"/"},{"name":"Inbox/Foo","delimiter":"/"}]`)
+		Must(err)
+	})
+
+	var created []string
+	mux.HandleFunc("/lite/users/123abc/email_accounts/0/folders/", func(w http.ResponseWriter, r *http.Request) {
+		created = append(created, strings.TrimPrefix(r.URL.Path, "/lite/users/123abc/email_accounts/0/folders/"))
+		_, err := io.WriteString(w, `{"success":true}`)
+		Must(err)
+	})
+
+	createdFolders, err := cioLite.CreateUserEmailAccountFolderAll("123abc", "0", "INBOX/foo/Bar", EmailAccountFolderDelimiterParam{})
+
+	// Only INBOX is case-insensitive, so foo is not the existing Foo
+	expected := []string{"INBOX/foo", "INBOX/foo/Bar"}
+	if err != nil || !reflect.DeepEqual(createdFolders, expected) || !reflect.DeepEqual(created, expected) {
+		t.Error("Expected: ", expected, "; Got: ", createdFolders, created, "; With Error: ", err, "; With Log: ", logger.String())
+	}
+
+	created = nil
+	createdFolders, err = cioLite.CreateUserEmailAccountFolderAll("123abc", "0", "INBOX/Foo", EmailAccountFolderDelimiterParam{})
+	if err != nil || len(createdFolders) != 0 || len(created) != 0 {
+		t.Error("Expected no folders created; Got: ", createdFolders, created, "; With Error: ", err, "; With Log: ", logger.String())
+	}
+}
+
+// TestSimulatedCreateUserEmailAccountFolderAllNoDelimiter tests that no folders are created without a known delimiter
+func TestSimulatedCreateUserEmailAccountFolderAllNoDelimiter(t *testing.T) {
+	t.Parallel()
+
+	cioLite, logger, testServer, mux := NewTestCioLiteWithLoggerAndTestServer(t)
+	defer testServer.Close()
+
+	mux.HandleFunc("/lite/users/123abc/email_accounts/0/folders", func(w http.ResponseWriter, r *http.Request) {
+		_, err := io.WriteString(w, `[{"name":"INBOX"},{"name":"Archive"}]`)
+		Must(err)
+	})
+	mux.HandleFunc("/lite/users/123abc/email_accounts/0/folders/", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("Expected no folder to be created; Got: ", r.Method, r.URL.Path)
+	})
+
+	createdFolders, err := cioLite.CreateUserEmailAccountFolderAll("123abc", "0", "Archive.2026.Q3", EmailAccountFolderDelimiterParam{})
+
+	if err == nil || len(createdFolders) != 0 {
+		t.Error("Expected an error and no folders created; Got: ", createdFolders, "; With Error: ", err, "; With Log: ", logger.String())
+	}
+}
+
+// TestSimulatedDeleteAndRenameUserEmailAccountFolder tests the folder delete and rename requests
+func TestSimulatedDeleteAndRenameUserEmailAccountFolder(t *testing.T) {
+	t.Parallel()
+
+	cioLite, logger, testServer, mux := NewTestCioLiteWithLoggerAndTestServer(t)
+	defer testServer.Close()
+
+	mux.HandleFunc("/lite/users/123abc/email_accounts/0/folders/Old Name", func(w http.ResponseWriter, r *http.Request) {
+		Must(r.ParseForm())
+		switch {
+		case r.Method == "DELETE":
+		case r.Method == "PUT" && r.PostForm.Get("new_folder_id") == "Archive/New Name":
+		default:
+			t.Error("Unexpected request: ", r.Method, r.PostForm)
+		}
+		_, err := io.WriteString(w, `{"success":true}`)
+		Must(err)
+	})
+
+	renameResponse, err := cioLite.RenameUserEmailAccountFolder("123abc", "0", "Old Name", RenameUserEmailAccountFolderParams{NewFolderID: "Archive/New Name"})
+	if err != nil || !renameResponse.Success {
+		t.Error("Expected success; Got: ", renameResponse, "; With Error: ", err, "; With Log: ", logger.String())
+	}
+
+	deleteResponse, err := cioLite.DeleteUserEmailAccountFolder("123abc", "0", "Old Name", EmailAccountFolderDelimiterParam{})
+	if err != nil || !deleteResponse.Success {
+		t.Error("Expected success; Got: ", deleteResponse, "; With Error: ", err, "; With Log: ", logger.String())
+	}
+}
@@ -3,6 +3,7 @@ package ciolite
 // Api functions that support: https://context.io/docs/lite/users/webhooks
 
 import (
+	"context"
 	"bytes"
 	"encoding/json"
 	"fmt"
@@ -227,6 +228,12 @@ func (m *WebhookMessageDataAddresses) UnmarshalJSON(b []byte) error {
 // GetUserWebhooks gets listings of Webhooks configured for a user.
 // 	https://context.io/docs/lite/users/webhooks#get
 func (cioLite CioLite) GetUserWebhooks(userID string) ([]GetUsersWebhooksResponse, error) {
+	return cioLite.GetUserWebhooksContext(context.Background(), userID)
+}
+
+// GetUserWebhooksContext is the same as GetUserWebhooks, but uses the provided context.Context
+// for cancellation and deadlines of the request (including any retries).
+func (cioLite CioLite) GetUserWebhooksContext(ctx context.Context, userID string) ([]GetUsersWebhooksResponse, error) {
 
 	// Make request
 	request := clientRequest{
@@ -239,7 +246,7 @@ func (cioLite CioLite) GetUserWebhooks(userID string) ([]GetUsersWebhooksRespons
 	var response []GetUsersWebhooksResponse
 
 	// Request
-	err := cioLite.doFormRequest(request, &response)
+	err := cioLite.doFormRequest(ctx, request, &response)
 
 	return response, err
 }
@@ -247,6 +254,12 @@ func (cioLite CioLite) GetUserWebhooks(userID string) ([]GetUsersWebhooksRespons
 // GetUserWebhook gets the properties of a given Webhook.
 // 	https://context.io/docs/lite/users/webhooks#id-get
 func (cioLite CioLite) GetUserWebhook(userID string, webhookID string) (GetUsersWebhooksResponse, error) {
+	return cioLite.GetUserWebhookContext(context.Background(), userID, webhookID)
+}
+
+// GetUserWebhookContext is the same as GetUserWebhook, but uses the provided context.Context
+// for cancellation and deadlines of the request (including any retries).
+func (cioLite CioLite) GetUserWebhookContext(ctx context.Context, userID string, webhookID string) (GetUsersWebhooksResponse, error) {
 
 	// Make request
 	request := clientRequest{
@@ -259,7 +272,7 @@ func (cioLite CioLite) GetUserWebhook(userID string, webhookID string) (GetUsers
 	var response GetUsersWebhooksResponse
 
 	// Request
-	err := cioLite.doFormRequest(request, &response)
+	err := cioLite.doFormRequest(ctx, request, &response)
 
 	return response, err
 }
@@ -271,6 +284,12 @@ func (cioLite CioLite) GetUserWebhook(userID string, webhookID string) (GetUsers
 // FilterFromDomain, IncludeBody, BodyType
 // 	https://context.io/docs/lite/users/webhooks#post
 func (cioLite CioLite) CreateUserWebhook(userID string, formValues CreateUserWebhookParams) (CreateUserWebhookResponse, error) {
+	return cioLite.CreateUserWebhookContext(context.Background(), userID, formValues)
+}
+
+// CreateUserWebhookContext is the same as CreateUserWebhook, but uses the provided context.Context
+// for cancellation and deadlines of the request (including any retries).
+func (cioLite CioLite) CreateUserWebhookContext(ctx context.Context, userID string, formValues CreateUserWebhookParams) (CreateUserWebhookResponse, error) {
 
 	// Make request
 	request := clientRequest{
@@ -284,7 +303,7 @@ func (cioLite CioLite) CreateUserWebhook(userID string, formValues CreateUserWeb
 	var response CreateUserWebhookResponse
 
 	// Request
-	err := cioLite.doFormRequest(request, &response)
+	err := cioLite.doFormRequest(ctx, request, &response)
 
 	return response, err
 }
@@ -293,6 +312,12 @@ func (cioLite CioLite) CreateUserWebhook(userID string, formValues CreateUserWeb
 // formValues requires Active
 // 	https://context.io/docs/lite/users/webhooks#id-post
 func (cioLite CioLite) ModifyUserWebhook(userID string, webhookID string, formValues ModifyUserWebhookParams) (ModifyWebhookResponse, error) {
+	return cioLite.ModifyUserWebhookContext(context.Background(), userID, webhookID, formValues)
+}
+
+// ModifyUserWebhookContext is the same as ModifyUserWebhook, but uses the provided context.Context
+// for cancellation and deadlines of the request (including any retries).
+func (cioLite CioLite) ModifyUserWebhookContext(ctx context.Context, userID string, webhookID string, formValues ModifyUserWebhookParams) (ModifyWebhookResponse, error) {
 
 	// Make request
 	request := clientRequest{
@@ -306,7 +331,7 @@ func (cioLite CioLite) ModifyUserWebhook(userID string, webhookID string, formVa
 	var response ModifyWebhookResponse
 
 	// Request
-	err := cioLite.doFormRequest(request, &response)
+	err := cioLite.doFormRequest(ctx, request, &response)
 
 	return response, err
 }
@@ -314,6 +339,12 @@ func (cioLite CioLite) ModifyUserWebhook(userID string, webhookID string, formVa
 // DeleteUserWebhookAccount cancels a Webhook.
 // 	https://context.io/docs/lite/users/webhooks#id-delete
 func (cioLite CioLite) DeleteUserWebhookAccount(userID string, webhookID string) (DeleteWebhookResponse, error) {
+	return cioLite.DeleteUserWebhookAccountContext(context.Background(), userID, webhookID)
+}
+
+// DeleteUserWebhookAccountContext is the same as DeleteUserWebhookAccount, but uses the provided context.Context
+// for cancellation and deadlines of the request (including any retries).
+func (cioLite CioLite) DeleteUserWebhookAccountContext(ctx context.Context, userID string, webhookID string) (DeleteWebhookResponse, error) {
 
 	// Make request
 	request := clientRequest{
@@ -326,7 +357,7 @@ func (cioLite CioLite) DeleteUserWebhookAccount(userID string, webhookID string)
 	var response DeleteWebhookResponse
 
 	// Request
-	err := cioLite.doFormRequest(request, &response)
+	err := cioLite.doFormRequest(ctx, request, &response)
 
 	return response, err
 }
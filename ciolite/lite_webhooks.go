@@ -3,12 +3,19 @@ package ciolite
 // Api functions that support: https://context.io/docs/lite/webhooks
 
 import (
+	"context"
 	"fmt"
 )
 
 // GetWebhooks gets listings of Webhooks configured for the application.
 // 	https://context.io/docs/lite/webhooks#get
 func (cioLite CioLite) GetWebhooks() ([]GetUsersWebhooksResponse, error) {
+	return cioLite.GetWebhooksContext(context.Background())
+}
+
+// GetWebhooksContext is the same as GetWebhooks, but uses the provided context.Context
+// for cancellation and deadlines of the request (including any retries).
+func (cioLite CioLite) GetWebhooksContext(ctx context.Context) ([]GetUsersWebhooksResponse, error) {
 
 	// Make request
 	request := clientRequest{
@@ -20,7 +27,7 @@ func (cioLite CioLite) GetWebhooks() ([]GetUsersWebhooksResponse, error) {
 	var response []GetUsersWebhooksResponse
 
 	// Request
-	err := cioLite.doFormRequest(request, &response)
+	err := cioLite.doFormRequest(ctx, request, &response)
 
 	return response, err
 }
@@ -28,6 +35,12 @@ func (cioLite CioLite) GetWebhooks() ([]GetUsersWebhooksResponse, error) {
 // GetWebhook gets the properties of a given Webhook.
 // 	https://context.io/docs/lite/webhooks#id-get
 func (cioLite CioLite) GetWebhook(webhookID string) (GetUsersWebhooksResponse, error) {
+	return cioLite.GetWebhookContext(context.Background(), webhookID)
+}
+
+// GetWebhookContext is the same as GetWebhook, but uses the provided context.Context
+// for cancellation and deadlines of the request (including any retries).
+func (cioLite CioLite) GetWebhookContext(ctx context.Context, webhookID string) (GetUsersWebhooksResponse, error) {
 
 	// Make request
 	request := clientRequest{
@@ -39,7 +52,7 @@ func (cioLite CioLite) GetWebhook(webhookID string) (GetUsersWebhooksResponse, e
 	var response GetUsersWebhooksResponse
 
 	// Request
-	err := cioLite.doFormRequest(request, &response)
+	err := cioLite.doFormRequest(ctx, request, &response)
 
 	return response, err
 }
@@ -51,6 +64,12 @@ func (cioLite CioLite) GetWebhook(webhookID string) (GetUsersWebhooksResponse, e
 // FilterFromDomain, IncludeBody, BodyType
 // 	https://context.io/docs/lite/webhooks#post
 func (cioLite CioLite) CreateWebhook(formValues CreateUserWebhookParams) (CreateUserWebhookResponse, error) {
+	return cioLite.CreateWebhookContext(context.Background(), formValues)
+}
+
+// CreateWebhookContext is the same as CreateWebhook, but uses the provided context.Context
+// for cancellation and deadlines of the request (including any retries).
+func (cioLite CioLite) CreateWebhookContext(ctx context.Context, formValues CreateUserWebhookParams) (CreateUserWebhookResponse, error) {
 
 	// Make request
 	request := clientRequest{
@@ -63,7 +82,7 @@ func (cioLite CioLite) CreateWebhook(formValues CreateUserWebhookParams) (Create
 	var response CreateUserWebhookResponse
 
 	// Request
-	err := cioLite.doFormRequest(request, &response)
+	err := cioLite.doFormRequest(ctx, request, &response)
 
 	return response, err
 }
@@ -72,6 +91,12 @@ func (cioLite CioLite) CreateWebhook(formValues CreateUserWebhookParams) (Create
 // formValues requires Active
 // 	https://context.io/docs/lite/webhooks#id-post
 func (cioLite CioLite) ModifyWebhook(webhookID string, formValues ModifyUserWebhookParams) (ModifyWebhookResponse, error) {
+	return cioLite.ModifyWebhookContext(context.Background(), webhookID, formValues)
+}
+
+// ModifyWebhookContext is the same as ModifyWebhook, but uses the provided context.Context
+// for cancellation and deadlines of the request (including any retries).
+func (cioLite CioLite) ModifyWebhookContext(ctx context.Context, webhookID string, formValues ModifyUserWebhookParams) (ModifyWebhookResponse, error) {
 
 	// Make request
 	request := clientRequest{
@@ -84,7 +109,7 @@ func (cioLite CioLite) ModifyWebhook(webhookID string, formValues ModifyUserWebh
 	var response ModifyWebhookResponse
 
 	// Request
-	err := cioLite.doFormRequest(request, &response)
+	err := cioLite.doFormRequest(ctx, request, &response)
 
 	return response, err
 }
@@ -92,6 +117,12 @@ func (cioLite CioLite) ModifyWebhook(webhookID string, formValues ModifyUserWebh
 // DeleteWebhookAccount cancels a Webhook.
 // 	https://context.io/docs/lite/webhooks#id-delete
 func (cioLite CioLite) DeleteWebhookAccount(webhookID string) (DeleteWebhookResponse, error) {
+	return cioLite.DeleteWebhookAccountContext(context.Background(), webhookID)
+}
+
+// DeleteWebhookAccountContext is the same as DeleteWebhookAccount, but uses the provided context.Context
+// for cancellation and deadlines of the request (including any retries).
+func (cioLite CioLite) DeleteWebhookAccountContext(ctx context.Context, webhookID string) (DeleteWebhookResponse, error) {
 
 	// Make request
 	request := clientRequest{
@@ -103,7 +134,7 @@ func (cioLite CioLite) DeleteWebhookAccount(webhookID string) (DeleteWebhookResp
 	var response DeleteWebhookResponse
 
 	// Request
-	err := cioLite.doFormRequest(request, &response)
+	err := cioLite.doFormRequest(ctx, request, &response)
 
 	return response, err
 }
@@ -0,0 +1,77 @@
+package ciolite
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestActualWebhooksRequestToCio tests actual CreateWebhook, GetWebhook,
+// GetWebhooks, ModifyWebhook, and DeleteWebhookAccount requests to CIO
+// (internet connection and real CIO key/secret required, or replays a recorded cassette; see NewTestCioLiteWithCassette).
+func TestActualWebhooksRequestToCio(t *testing.T) {
+	t.Parallel()
+
+	cioLite, saveCassette := NewTestCioLiteWithCassette(t, "webhooks")
+	defer saveCassette()
+	logger := addLogging(&cioLite)
+
+	// create
+	webhook, err := cioLite.CreateWebhook(CreateUserWebhookParams{
+		CallbackURL:     "https://bogusurl.com/callback",
+		FailureNotifURL: "https://bogusurl.com/failure",
+		FilterSubject:   "bogus subject",
+	})
+
+	if err != nil || !webhook.Success || len(webhook.WebhookID) == 0 {
+		t.Fatal("Expected successful webhook; Got: ", webhook, "; With Error: ", err, "; With Log: ", logger.String())
+	}
+
+	// get this one
+	getWebhook, err := cioLite.GetWebhook(webhook.WebhookID)
+
+	if err != nil ||
+		getWebhook.WebhookID != webhook.WebhookID ||
+		getWebhook.CallbackURL != "https://bogusurl.com/callback" ||
+		getWebhook.FailureNotifURL != "https://bogusurl.com/failure" ||
+		getWebhook.FilterSubject != "bogus subject" ||
+		!getWebhook.Active {
+
+		t.Error("Expected GetUsersWebhooksResponse matching: ", webhook, "; Got: ", getWebhook, "; With Error: ", err, "; With Log: ", logger.String())
+	}
+
+	// get all
+	getWebhooks, err := cioLite.GetWebhooks()
+
+	found := false
+	for _, getWebhookValue := range getWebhooks {
+		if reflect.DeepEqual(getWebhookValue, getWebhook) {
+			found = true
+		}
+	}
+
+	if err != nil || !found {
+		t.Error("Expected to include: ", getWebhook, "; Got: ", getWebhooks, "; With Error: ", err, "; With Log: ", logger.String())
+	}
+
+	// deactivate
+	modifyResponse, err := cioLite.ModifyWebhook(webhook.WebhookID, ModifyUserWebhookParams{Active: false})
+	if err != nil || !modifyResponse.Success {
+		t.Error("Expected successful modify of webhook; Got: ", modifyResponse, "; With Error: ", err, "; With Log: ", logger.String())
+	}
+
+	getWebhook, err = cioLite.GetWebhook(webhook.WebhookID)
+	if err != nil || getWebhook.Active {
+		t.Error("Expected inactive webhook; Got: ", getWebhook, "; With Error: ", err, "; With Log: ", logger.String())
+	}
+
+	// delete
+	deleteResponse, err := cioLite.DeleteWebhookAccount(webhook.WebhookID)
+
+	if err != nil || !deleteResponse.Success {
+		t.Error("Expected successful delete of webhook; Got: ", deleteResponse, "; With Error: ", err, "; With Log: ", logger.String())
+	}
+
+	if len(logger.String()) < 20 {
+		t.Error("Expected some output from logger; Got: ", logger.String())
+	}
+}
@@ -0,0 +1,105 @@
+package ciolite
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// DefaultNonceStoreMaxEntries is the default maximum number of tokens remembered by a MemoryNonceStore
+const DefaultNonceStoreMaxEntries = 100000
+
+// NonceStore remembers the tokens of callbacks that have already been received,
+// so that replays of a captured Webhook Callback or User Account Status Callback can be rejected.
+// Implementations must be safe for concurrent use, and may be backed by a shared
+// store (ex: redis SET NX with an expiry) when running more than one receiver.
+type NonceStore interface {
+	// MarkSeen records the token until the expiry time, and returns true
+	// if the token had already been recorded (and had not yet expired).
+	MarkSeen(token string, expires time.Time) (bool, error)
+
+	// Forget removes the token, so that the callback will be accepted if it is sent again
+	// (ex: CIO retrying a callback that our receiver failed to process).
+	Forget(token string) error
+}
+
+// MemoryNonceStore is an in-memory NonceStore, which forgets tokens once they expire,
+// or when it is full, forgets the least recently seen tokens first.
+type MemoryNonceStore struct {
+	maxEntries int
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List // front is most recently seen
+}
+
+// memoryNonce is an entry in a MemoryNonceStore
+type memoryNonce struct {
+	token   string
+	expires time.Time
+}
+
+// NewMemoryNonceStore returns a *MemoryNonceStore holding at most maxEntries tokens
+// (DefaultNonceStoreMaxEntries if maxEntries is not positive).
+func NewMemoryNonceStore(maxEntries int) *MemoryNonceStore {
+	if maxEntries <= 0 {
+		maxEntries = DefaultNonceStoreMaxEntries
+	}
+	return &MemoryNonceStore{
+		maxEntries: maxEntries,
+		entries:    make(map[string]*list.Element),
+		order:      list.New(),
+	}
+}
+
+// MarkSeen implements NonceStore
+func (store *MemoryNonceStore) MarkSeen(token string, expires time.Time) (bool, error) {
+	now := time.Now()
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	if elem, ok := store.entries[token]; ok {
+		nonce := elem.Value.(*memoryNonce)
+		if now.Before(nonce.expires) {
+			store.order.MoveToFront(elem)
+			return true, nil
+		}
+		// Expired, so treat it as new
+		nonce.expires = expires
+		store.order.MoveToFront(elem)
+		return false, nil
+	}
+
+	store.entries[token] = store.order.PushFront(&memoryNonce{token: token, expires: expires})
+
+	// Remove expired tokens from the back, then the least recently seen ones if still over capacity
+	for elem := store.order.Back(); elem != nil && store.order.Len() > 1; elem = store.order.Back() {
+		nonce := elem.Value.(*memoryNonce)
+		if now.Before(nonce.expires) && store.order.Len() <= store.maxEntries {
+			break
+		}
+		store.order.Remove(elem)
+		delete(store.entries, nonce.token)
+	}
+	return false, nil
+}
+
+// Forget implements NonceStore
+func (store *MemoryNonceStore) Forget(token string) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	if elem, ok := store.entries[token]; ok {
+		store.order.Remove(elem)
+		delete(store.entries, token)
+	}
+	return nil
+}
+
+// Len returns the number of tokens currently remembered
+func (store *MemoryNonceStore) Len() int {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	return store.order.Len()
+}
@@ -0,0 +1,121 @@
+package ciolite
+
+import (
+	"context"
+	"crypto/sha256"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestMemoryNonceStore tests the replay detection, expiry, and capacity of MemoryNonceStore
+func TestMemoryNonceStore(t *testing.T) {
+	t.Parallel()
+
+	store := NewMemoryNonceStore(3)
+	future := time.Now().Add(time.Hour)
+
+	if seen, err := store.MarkSeen("a", future); seen || err != nil {
+		t.Error("Expected token a to be new; Got: ", seen, err)
+	}
+	if seen, _ := store.MarkSeen("a", future); !seen {
+		t.Error("Expected token a to have been seen")
+	}
+
+	// Expired tokens are accepted again
+	if seen, _ := store.MarkSeen("b", time.Now().Add(-time.Second)); seen {
+		t.Error("Expected token b to be new")
+	}
+	if seen, _ := store.MarkSeen("b", future); seen {
+		t.Error("Expected expired token b to be accepted again")
+	}
+
+	// Forget
+	Must(store.Forget("b"))
+	if seen, _ := store.MarkSeen("b", future); seen {
+		t.Error("Expected forgotten token b to be accepted again")
+	}
+
+	// Capacity evicts the least recently seen token
+	store.MarkSeen("c", future)
+	store.MarkSeen("d", future)
+	if store.Len() != 3 {
+		t.Error("Expected 3 tokens; Got: ", store.Len())
+	}
+	if seen, _ := store.MarkSeen("a", future); seen {
+		t.Error("Expected evicted token a to be accepted again")
+	}
+}
+
+// TestValidateCallbackStrict tests the signature, timestamp and replay checks of ValidateCallbackStrict
+func TestValidateCallbackStrict(t *testing.T) {
+	t.Parallel()
+
+	cioLite := NewCioLite("key", "secret")
+	store := NewMemoryNonceStore(0)
+
+	sign := func(token string, timestamp int) string {
+		return hashHmac(sha256.New, strconv.Itoa(timestamp)+token, "secret")
+	}
+	now := int(time.Now().Unix())
+
+	if err := cioLite.ValidateCallbackStrict("token1", sign("token1", now), now, time.Minute, store); err != nil {
+		t.Error("Expected valid callback; Got: ", err)
+	}
+	if err := cioLite.ValidateCallbackStrict("token1", sign("token1", now), now, time.Minute, store); err != ErrCallbackReplayed {
+		t.Error("Expected ErrCallbackReplayed; Got: ", err)
+	}
+	if err := cioLite.ValidateCallbackStrict("token2", sign("token2", now-120), now-120, time.Minute, store); err != ErrCallbackTimestamp {
+		t.Error("Expected ErrCallbackTimestamp; Got: ", err)
+	}
+	if err := cioLite.ValidateCallbackStrict("token3", sign("token3", now+120), now+120, time.Minute, store); err != ErrCallbackTimestamp {
+		t.Error("Expected ErrCallbackTimestamp for future timestamp; Got: ", err)
+	}
+	if err := cioLite.ValidateCallbackStrict("token4", "bogus", now, time.Minute, store); err != ErrCallbackSignature {
+		t.Error("Expected ErrCallbackSignature; Got: ", err)
+	}
+
+	// Invalid callbacks must not be remembered
+	if err := cioLite.ValidateCallbackStrict("token4", sign("token4", now), now, time.Minute, nil); err != nil {
+		t.Error("Expected valid callback without a store; Got: ", err)
+	}
+	if seen, _ := store.MarkSeen("token4", time.Now().Add(time.Minute)); seen {
+		t.Error("Expected invalid callback token4 not to have been stored")
+	}
+}
+
+// TestWebhookHandlerReplay tests that WebhookHandler acknowledges but does not dispatch replays
+func TestWebhookHandlerReplay(t *testing.T) {
+	t.Parallel()
+
+	cioLite := NewCioLite("key", "secret")
+
+	dispatched := 0
+	var loggedErrors []error
+	handler := NewWebhookHandler(cioLite, WebhookHandlerOptions{
+		NonceStore: NewMemoryNonceStore(0),
+		OnMessage: func(ctx context.Context, callback WebhookCallback) error {
+			dispatched++
+			return nil
+		},
+		ErrorHook: func(r *http.Request, err error) {
+			loggedErrors = append(loggedErrors, err)
+		},
+	})
+
+	body := signedWebhookBody(WebhookCallback{Token: "token1", Timestamp: int(time.Now().Unix())}, "secret")
+	for i := 0; i < 2; i++ {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest("POST", "/webhook", strings.NewReader(body)))
+		if recorder.Code != http.StatusOK {
+			t.Error("Expected status: ", http.StatusOK, "; Got: ", recorder.Code)
+		}
+	}
+
+	if dispatched != 1 || len(loggedErrors) != 1 || loggedErrors[0] != ErrCallbackReplayed {
+		t.Error("Expected one dispatch and one replay; Got: ", dispatched, loggedErrors)
+	}
+}
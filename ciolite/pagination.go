@@ -0,0 +1,210 @@
+package ciolite
+
+import (
+	"context"
+)
+
+// DefaultPageSize is the number of results requested per page by iterators when no Limit is set
+const DefaultPageSize = 100
+
+// pager keeps track of the offset and limit of paged requests, and when the last page has been reached
+type pager struct {
+	limit  int
+	offset int
+	done   bool
+}
+
+// newPager returns a pager starting at the offset, using the limit (or DefaultPageSize) as page size
+func newPager(limit int, offset int) pager {
+	if limit <= 0 {
+		limit = DefaultPageSize
+	}
+	return pager{limit: limit, offset: offset}
+}
+
+// advance moves the offset past the page just received, and
+// marks the pager done if the page was shorter than requested
+func (p *pager) advance(pageLen int) {
+	p.offset += pageLen
+	if pageLen < p.limit {
+		p.done = true
+	}
+}
+
+// UsersIterator pages through all users matching GetUsersParams.
+// 	for iter.Next() { user := iter.Value() }
+// 	if err := iter.Err(); err != nil { ... }
+type UsersIterator struct {
+	cioLite     CioLite
+	ctx         context.Context
+	queryValues GetUsersParams
+	pager       pager
+
+	page  []GetUsersResponse
+	index int
+	value GetUsersResponse
+	err   error
+}
+
+// NewUsersIterator returns a *UsersIterator over all users matching queryValues.
+// queryValues.Limit sets the page size (default DefaultPageSize), and queryValues.Offset the starting offset.
+func (cioLite CioLite) NewUsersIterator(ctx context.Context, queryValues GetUsersParams) *UsersIterator {
+	return &UsersIterator{
+		cioLite:     cioLite,
+		ctx:         ctx,
+		queryValues: queryValues,
+		pager:       newPager(queryValues.Limit, queryValues.Offset),
+	}
+}
+
+// Next advances to the next user, fetching the next page if needed.
+// Returns false when there are no more users, or an error occurred.
+func (iter *UsersIterator) Next() bool {
+	for iter.index >= len(iter.page) {
+		if iter.err != nil || iter.pager.done {
+			return false
+		}
+		iter.queryValues.Limit = iter.pager.limit
+		iter.queryValues.Offset = iter.pager.offset
+		iter.page, iter.err = iter.cioLite.GetUsersContext(iter.ctx, iter.queryValues)
+		iter.index = 0
+		if iter.err != nil {
+			iter.page = nil
+			return false
+		}
+		iter.pager.advance(len(iter.page))
+	}
+	iter.value = iter.page[iter.index]
+	iter.index++
+	return true
+}
+
+// Value returns the current user
+func (iter *UsersIterator) Value() GetUsersResponse {
+	return iter.value
+}
+
+// Err returns any error that stopped the iteration
+func (iter *UsersIterator) Err() error {
+	return iter.err
+}
+
+// StreamUsers pages through all users matching queryValues in a new goroutine, sending each user on
+// the returned channel, which is closed when done. Any error is then sent on the error channel.
+// Canceling the context stops the stream.
+func (cioLite CioLite) StreamUsers(ctx context.Context, queryValues GetUsersParams) (<-chan GetUsersResponse, <-chan error) {
+	users := make(chan GetUsersResponse)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(errs)
+		defer close(users)
+
+		iter := cioLite.NewUsersIterator(ctx, queryValues)
+		for iter.Next() {
+			select {
+			case users <- iter.Value():
+			case <-ctx.Done():
+				errs <- ctx.Err()
+				return
+			}
+		}
+		if err := iter.Err(); err != nil {
+			errs <- err
+		}
+	}()
+
+	return users, errs
+}
+
+// FolderMessagesIterator pages through all messages in an email account folder.
+// 	for iter.Next() { message := iter.Value() }
+// 	if err := iter.Err(); err != nil { ... }
+type FolderMessagesIterator struct {
+	cioLite     CioLite
+	ctx         context.Context
+	userID      string
+	label       string
+	folder      string
+	queryValues GetUserEmailAccountsFolderMessageParams
+	pager       pager
+
+	page  []GetUsersEmailAccountFolderMessagesResponse
+	index int
+	value GetUsersEmailAccountFolderMessagesResponse
+	err   error
+}
+
+// NewFolderMessagesIterator returns a *FolderMessagesIterator over all messages in the folder.
+// queryValues.Limit sets the page size (default DefaultPageSize), and queryValues.Offset the starting offset.
+func (cioLite CioLite) NewFolderMessagesIterator(ctx context.Context, userID string, label string, folder string, queryValues GetUserEmailAccountsFolderMessageParams) *FolderMessagesIterator {
+	return &FolderMessagesIterator{
+		cioLite:     cioLite,
+		ctx:         ctx,
+		userID:      userID,
+		label:       label,
+		folder:      folder,
+		queryValues: queryValues,
+		pager:       newPager(queryValues.Limit, queryValues.Offset),
+	}
+}
+
+// Next advances to the next message, fetching the next page if needed.
+// Returns false when there are no more messages, or an error occurred.
+func (iter *FolderMessagesIterator) Next() bool {
+	for iter.index >= len(iter.page) {
+		if iter.err != nil || iter.pager.done {
+			return false
+		}
+		iter.queryValues.Limit = iter.pager.limit
+		iter.queryValues.Offset = iter.pager.offset
+		iter.page, iter.err = iter.cioLite.GetUserEmailAccountsFolderMessagesContext(iter.ctx, iter.userID, iter.label, iter.folder, iter.queryValues)
+		iter.index = 0
+		if iter.err != nil {
+			iter.page = nil
+			return false
+		}
+		iter.pager.advance(len(iter.page))
+	}
+	iter.value = iter.page[iter.index]
+	iter.index++
+	return true
+}
+
+// Value returns the current message
+func (iter *FolderMessagesIterator) Value() GetUsersEmailAccountFolderMessagesResponse {
+	return iter.value
+}
+
+// Err returns any error that stopped the iteration
+func (iter *FolderMessagesIterator) Err() error {
+	return iter.err
+}
+
+// StreamUserEmailAccountsFolderMessages pages through all messages in the folder in a new goroutine, sending
+// each message on the returned channel, which is closed when done. Any error is then sent on the error channel.
+// Canceling the context stops the stream.
+func (cioLite CioLite) StreamUserEmailAccountsFolderMessages(ctx context.Context, userID string, label string, folder string, queryValues GetUserEmailAccountsFolderMessageParams) (<-chan GetUsersEmailAccountFolderMessagesResponse, <-chan error) {
+	messages := make(chan GetUsersEmailAccountFolderMessagesResponse)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(errs)
+		defer close(messages)
+
+		iter := cioLite.NewFolderMessagesIterator(ctx, userID, label, folder, queryValues)
+		for iter.Next() {
+			select {
+			case messages <- iter.Value():
+			case <-ctx.Done():
+				errs <- ctx.Err()
+				return
+			}
+		}
+		if err := iter.Err(); err != nil {
+			errs <- err
+		}
+	}()
+
+	return messages, errs
+}
@@ -0,0 +1,106 @@
+package ciolite
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+)
+
+// pagedHandler returns an http.HandlerFunc serving total items using the limit and offset query values,
+// and records the number of requests made
+func pagedHandler(total int, item func(int) interface{}, requests *int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		page := []interface{}{}
+		for i := offset; i < total && i < offset+limit; i++ {
+			page = append(page, item(i))
+		}
+		Must(json.NewEncoder(w).Encode(page))
+	}
+}
+
+// TestSimulatedUsersIterator tests that UsersIterator pages through all users and stops on the last short page
+func TestSimulatedUsersIterator(t *testing.T) {
+	t.Parallel()
+
+	cioLite, logger, testServer, mux := NewTestCioLiteWithLoggerAndTestServer(t)
+	defer testServer.Close()
+
+	requests := 0
+	mux.HandleFunc("/lite/users", pagedHandler(25, func(i int) interface{} {
+		return GetUsersResponse{ID: fmt.Sprintf("user%d", i)}
+	}, &requests))
+
+	iter := cioLite.NewUsersIterator(context.Background(), GetUsersParams{Limit: 10})
+	count := 0
+	for iter.Next() {
+		if expected := fmt.Sprintf("user%d", count); iter.Value().ID != expected {
+			t.Error("Expected: ", expected, "; Got: ", iter.Value().ID)
+		}
+		count++
+	}
+
+	if iter.Err() != nil || count != 25 || requests != 3 {
+		t.Error("Expected 25 users in 3 requests; Got: ", count, " users in ", requests, " requests; With Error: ", iter.Err(), "; With Log: ", logger.String())
+	}
+}
+
+// TestSimulatedFolderMessagesIterator tests that FolderMessagesIterator handles an exact last page
+func TestSimulatedFolderMessagesIterator(t *testing.T) {
+	t.Parallel()
+
+	cioLite, logger, testServer, mux := NewTestCioLiteWithLoggerAndTestServer(t)
+	defer testServer.Close()
+
+	requests := 0
+	mux.HandleFunc("/lite/users/123abc/email_accounts/0/folders/Inbox/messages", pagedHandler(20, func(i int) interface{} {
+		return GetUsersEmailAccountFolderMessagesResponse{MessageID: fmt.Sprintf("msg%d", i)}
+	}, &requests))
+
+	iter := cioLite.NewFolderMessagesIterator(context.Background(), "123abc", "0", "Inbox", GetUserEmailAccountsFolderMessageParams{Limit: 10})
+	count := 0
+	for iter.Next() {
+		count++
+	}
+
+	// The third request returns an empty page
+	if iter.Err() != nil || count != 20 || requests != 3 {
+		t.Error("Expected 20 messages in 3 requests; Got: ", count, " messages in ", requests, " requests; With Error: ", iter.Err(), "; With Log: ", logger.String())
+	}
+}
+
+// TestSimulatedStreamUsers tests that StreamUsers sends all users and reports errors
+func TestSimulatedStreamUsers(t *testing.T) {
+	t.Parallel()
+
+	cioLite, logger, testServer, mux := NewTestCioLiteWithLoggerAndTestServer(t)
+	defer testServer.Close()
+
+	requests := 0
+	handler := pagedHandler(7, func(i int) interface{} {
+		return GetUsersResponse{ID: fmt.Sprintf("user%d", i)}
+	}, &requests)
+	mux.HandleFunc("/lite/users", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("offset") == "6" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		handler(w, r)
+	})
+
+	users, errs := cioLite.StreamUsers(context.Background(), GetUsersParams{Limit: 3})
+	count := 0
+	for range users {
+		count++
+	}
+	err := <-errs
+
+	if count != 6 || ErrorStatusCode(err) != http.StatusInternalServerError {
+		t.Error("Expected 6 users then a 500 error; Got: ", count, " users; With Error: ", err, "; With Log: ", logger.String())
+	}
+}
@@ -0,0 +1,229 @@
+package ciolite
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrRateLimited is the cause of errors returned when a fail-fast RateLimiter refuses a request
+var ErrRateLimited = errors.New("CIO: Client-side rate limit exceeded")
+
+// maxBuckets is how many per-user/per-account buckets are kept, before idle (full) ones are pruned
+// (or if none are idle, the least recently used one is evicted)
+const maxBuckets = 1000
+
+// RateLimit defines a token bucket allowing Requests per Interval on average,
+// with bursts of up to Burst requests at once. A zero RateLimit is unlimited.
+type RateLimit struct {
+	Requests int
+	Interval time.Duration
+
+	// Burst defaults to Requests if not set
+	Burst int
+}
+
+// RateLimiterOptions configures a RateLimiter.
+// Any RateLimit left as its zero value is not enforced.
+type RateLimiterOptions struct {
+	// Global limits all requests made by the application
+	Global RateLimit
+
+	// PerUser limits the requests made for each User ID
+	PerUser RateLimit
+
+	// PerAccount limits the requests made for each User ID and Account Label combination
+	PerAccount RateLimit
+
+	// FailFast makes requests that would have to wait return an ErrRateLimited error instead
+	FailFast bool
+}
+
+// RateLimiter is a client-side token bucket rate limiter for CIO requests.
+// It is safe for concurrent use, and because CioLite holds a pointer to it,
+// the same limits are shared by every copy of a CioLite it is assigned to.
+type RateLimiter struct {
+	options RateLimiterOptions
+
+	mu             sync.Mutex
+	global         *tokenBucket
+	userBuckets    map[string]*tokenBucket
+	accountBuckets map[string]*tokenBucket
+}
+
+// NewRateLimiter returns a new *RateLimiter, which can be set on CioLite.RateLimiter
+func NewRateLimiter(options RateLimiterOptions) *RateLimiter {
+	return &RateLimiter{
+		options:        options,
+		global:         newTokenBucket(options.Global, time.Now()),
+		userBuckets:    make(map[string]*tokenBucket),
+		accountBuckets: make(map[string]*tokenBucket),
+	}
+}
+
+// Wait blocks until a request for the User ID and Account Label (either may be empty)
+// is allowed by all applicable limits, or the context is done.
+// If the RateLimiter is FailFast, it returns ErrRateLimited instead of blocking.
+func (limiter *RateLimiter) Wait(ctx context.Context, userID string, label string) error {
+	now := time.Now()
+
+	limiter.mu.Lock()
+	buckets := limiter.buckets(userID, label, now)
+
+	// Fail fast without reserving anything if any bucket is empty
+	if limiter.options.FailFast {
+		for _, bucket := range buckets {
+			if !bucket.available(now) {
+				limiter.mu.Unlock()
+				return ErrRateLimited
+			}
+		}
+	}
+
+	// Reserve a token from every bucket, waiting for the longest of them
+	var delay time.Duration
+	for _, bucket := range buckets {
+		if bucketDelay := bucket.reserve(now); bucketDelay > delay {
+			delay = bucketDelay
+		}
+	}
+	limiter.mu.Unlock()
+
+	if err := sleepContext(ctx, delay); err != nil {
+		// Give back the reserved tokens, since no request will be made
+		limiter.mu.Lock()
+		for _, bucket := range buckets {
+			bucket.cancel()
+		}
+		limiter.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// buckets returns the token buckets that apply to this User ID and Account Label.
+// Must be called while holding the lock.
+func (limiter *RateLimiter) buckets(userID string, label string, now time.Time) []*tokenBucket {
+	var buckets []*tokenBucket
+	if limiter.global != nil {
+		buckets = append(buckets, limiter.global)
+	}
+	if len(userID) > 0 {
+		if bucket := bucketFor(limiter.userBuckets, userID, limiter.options.PerUser, now); bucket != nil {
+			buckets = append(buckets, bucket)
+		}
+		if len(label) > 0 {
+			if bucket := bucketFor(limiter.accountBuckets, userID+"/"+label, limiter.options.PerAccount, now); bucket != nil {
+				buckets = append(buckets, bucket)
+			}
+		}
+	}
+	return buckets
+}
+
+// bucketFor returns the token bucket for the key, creating it if needed
+// and evicting buckets if the map has grown too large
+func bucketFor(bucketMap map[string]*tokenBucket, key string, limit RateLimit, now time.Time) *tokenBucket {
+	if bucket, ok := bucketMap[key]; ok {
+		return bucket
+	}
+	bucket := newTokenBucket(limit, now)
+	if bucket == nil {
+		return nil
+	}
+	if len(bucketMap) >= maxBuckets {
+		evictBuckets(bucketMap, now)
+	}
+	bucketMap[key] = bucket
+	return bucket
+}
+
+// evictBuckets prunes the idle (full) buckets, or if none are idle, evicts the least recently used bucket,
+// so that the map never grows past maxBuckets
+func evictBuckets(bucketMap map[string]*tokenBucket, now time.Time) {
+	var (
+		oldestKey  string
+		oldestUsed time.Time
+	)
+	for k, b := range bucketMap {
+		if b.full(now) {
+			delete(bucketMap, k)
+		} else if len(oldestKey) == 0 || b.used.Before(oldestUsed) {
+			oldestKey, oldestUsed = k, b.used
+		}
+	}
+	if len(bucketMap) >= maxBuckets {
+		delete(bucketMap, oldestKey)
+	}
+}
+
+// tokenBucket is a single token bucket, which is not safe for concurrent use on its own
+type tokenBucket struct {
+	rate   float64 // tokens per second
+	burst  float64
+	tokens float64
+	last   time.Time
+	used   time.Time // when a token was last reserved (or the bucket created)
+}
+
+// newTokenBucket returns a full token bucket for the limit, or nil if the limit is unlimited
+func newTokenBucket(limit RateLimit, now time.Time) *tokenBucket {
+	if limit.Requests <= 0 || limit.Interval <= 0 {
+		return nil
+	}
+	burst := limit.Burst
+	if burst <= 0 {
+		burst = limit.Requests
+	}
+	return &tokenBucket{
+		rate:   float64(limit.Requests) / limit.Interval.Seconds(),
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   now,
+		used:   now,
+	}
+}
+
+// advance refills the bucket with the tokens earned since it was last used
+func (bucket *tokenBucket) advance(now time.Time) {
+	if elapsed := now.Sub(bucket.last); elapsed > 0 {
+		bucket.tokens += elapsed.Seconds() * bucket.rate
+		if bucket.tokens > bucket.burst {
+			bucket.tokens = bucket.burst
+		}
+		bucket.last = now
+	}
+}
+
+// available returns true if a token can be taken right now
+func (bucket *tokenBucket) available(now time.Time) bool {
+	bucket.advance(now)
+	return bucket.tokens >= 1
+}
+
+// full returns true if the bucket has not been used recently enough to matter
+func (bucket *tokenBucket) full(now time.Time) bool {
+	bucket.advance(now)
+	return bucket.tokens >= bucket.burst
+}
+
+// reserve takes a token (possibly going into debt), and returns how long to wait until it is usable
+func (bucket *tokenBucket) reserve(now time.Time) time.Duration {
+	bucket.advance(now)
+	bucket.used = now
+	bucket.tokens--
+	if bucket.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-bucket.tokens / bucket.rate * float64(time.Second))
+}
+
+// cancel returns a previously reserved token
+func (bucket *tokenBucket) cancel() {
+	bucket.tokens++
+	if bucket.tokens > bucket.burst {
+		bucket.tokens = bucket.burst
+	}
+}
@@ -0,0 +1,134 @@
+package ciolite
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// TestRateLimiterFailFast tests that a fail-fast RateLimiter refuses requests over the global and per-user limits
+func TestRateLimiterFailFast(t *testing.T) {
+	t.Parallel()
+
+	limiter := NewRateLimiter(RateLimiterOptions{
+		Global:   RateLimit{Requests: 3, Interval: time.Hour},
+		PerUser:  RateLimit{Requests: 2, Interval: time.Hour},
+		FailFast: true,
+	})
+	ctx := context.Background()
+
+	// Per user limit
+	for i := 0; i < 2; i++ {
+		if err := limiter.Wait(ctx, "user1", "label"); err != nil {
+			t.Error("Expected request to be allowed; Got: ", err)
+		}
+	}
+	if err := limiter.Wait(ctx, "user1", "label"); err != ErrRateLimited {
+		t.Error("Expected ErrRateLimited; Got: ", err)
+	}
+
+	// Global limit still has one left, which another user can use
+	if err := limiter.Wait(ctx, "user2", ""); err != nil {
+		t.Error("Expected request to be allowed; Got: ", err)
+	}
+	if err := limiter.Wait(ctx, "user3", ""); err != ErrRateLimited {
+		t.Error("Expected ErrRateLimited; Got: ", err)
+	}
+}
+
+// TestRateLimiterWait tests that a blocking RateLimiter waits, and honors context cancellation
+func TestRateLimiterWait(t *testing.T) {
+	t.Parallel()
+
+	limiter := NewRateLimiter(RateLimiterOptions{
+		PerAccount: RateLimit{Requests: 1, Interval: 50 * time.Millisecond},
+	})
+	ctx := context.Background()
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := limiter.Wait(ctx, "user1", "label"); err != nil {
+			t.Error("Expected request to be allowed; Got: ", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Error("Expected to wait at least 100ms; Got: ", elapsed)
+	}
+
+	// Different account is not limited by the first one
+	start = time.Now()
+	if err := limiter.Wait(ctx, "user1", "other"); err != nil || time.Since(start) > 40*time.Millisecond {
+		t.Error("Expected request to be allowed immediately; Got: ", err, time.Since(start))
+	}
+
+	// Context canceled while waiting
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if err := limiter.Wait(ctx, "user1", "label"); err != context.DeadlineExceeded {
+		t.Error("Expected context.DeadlineExceeded; Got: ", err)
+	}
+}
+
+// TestRateLimiterEviction tests that per-user buckets are capped, evicting the least recently used one
+func TestRateLimiterEviction(t *testing.T) {
+	t.Parallel()
+
+	limiter := NewRateLimiter(RateLimiterOptions{
+		PerUser:  RateLimit{Requests: 1, Interval: time.Hour},
+		FailFast: true,
+	})
+	ctx := context.Background()
+
+	// None of the buckets are idle, as each user has used their only request
+	for i := 0; i < maxBuckets+10; i++ {
+		if err := limiter.Wait(ctx, strconv.Itoa(i), ""); err != nil {
+			t.Error("Expected request to be allowed; Got: ", err)
+		}
+	}
+	if len(limiter.userBuckets) != maxBuckets {
+		t.Error("Expected: ", maxBuckets, "; Got: ", len(limiter.userBuckets))
+	}
+
+	// The oldest users were evicted, but the most recent are still limited
+	if _, ok := limiter.userBuckets["0"]; ok {
+		t.Error("Expected the least recently used bucket to be evicted")
+	}
+	if err := limiter.Wait(ctx, strconv.Itoa(maxBuckets+9), ""); err != ErrRateLimited {
+		t.Error("Expected ErrRateLimited; Got: ", err)
+	}
+}
+
+// TestSimulatedRateLimiterSharedAcrossCopies tests that copies of CioLite share the same RateLimiter
+func TestSimulatedRateLimiterSharedAcrossCopies(t *testing.T) {
+	t.Parallel()
+
+	cioLite, logger, testServer, mux := NewTestCioLiteWithLoggerAndTestServer(t)
+	defer testServer.Close()
+
+	mux.HandleFunc("/lite/users/123abc", func(w http.ResponseWriter, r *http.Request) {
+		_, err := io.WriteString(w, `{"id":"123abc"}`)
+		Must(err)
+	})
+
+	cioLite.RateLimiter = NewRateLimiter(RateLimiterOptions{PerUser: RateLimit{Requests: 1, Interval: time.Hour}, FailFast: true})
+	cioLiteCopy := cioLite
+
+	if _, err := cioLite.GetUser("123abc"); err != nil {
+		t.Error("Expected request to be allowed; Got: ", err, "; With Log: ", logger.String())
+	}
+
+	if _, err := cioLiteCopy.GetUser("123abc"); errors.Cause(err) != ErrRateLimited {
+		t.Error("Expected ErrRateLimited cause; Got: ", err, "; With Log: ", logger.String())
+	}
+
+	// The refused request is still logged
+	if !strings.Contains(logger.String(), "Rate limiter did not allow request") {
+		t.Error("Expected the refused request to be logged; Got: ", logger.String())
+	}
+}
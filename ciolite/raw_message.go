@@ -0,0 +1,388 @@
+package ciolite
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+// maxMessageDepth limits how deeply multipart and message/rfc822 parts are parsed
+const maxMessageDepth = 32
+
+// MessageParser parses raw RFC-822 messages (see ParseRawMessage, which uses the zero MessageParser).
+// It holds no state while parsing, so the same MessageParser can be used concurrently.
+type MessageParser struct {
+	// CharsetReader, if set, is used to convert text in charsets other than
+	// UTF-8, US-ASCII, ISO-8859-1, ISO-8859-15 and Windows-1252 into UTF-8
+	// (ex: charset.NewReaderLabel from golang.org/x/net/html/charset).
+	CharsetReader func(charset string, input io.Reader) (io.Reader, error)
+}
+
+// Message is a parsed raw RFC-822 message (see ParseRawMessage).
+// Section numbers follow IMAP, and so line up with the BodySection of
+// UsersEmailAccountFolderMessageBody and UsersEmailAccountFolderMessageAttachment.
+type Message struct {
+	// Header contains every header, with RFC 2047 encoded words decoded
+	Header mail.Header
+
+	Subject    string
+	MessageID  string
+	InReplyTo  string
+	References []string
+
+	From    []*mail.Address
+	Sender  []*mail.Address
+	ReplyTo []*mail.Address
+	To      []*mail.Address
+	Cc      []*mail.Address
+	Bcc     []*mail.Address
+
+	// Date is the zero time if the Date header is missing or invalid
+	Date time.Time
+
+	// Bodies are the text/plain and text/html parts, in order
+	Bodies []MessageBody
+
+	// Attachments are all other parts, including inline images and nested messages
+	Attachments []MessageAttachment
+}
+
+// MessageBody is a text/plain or text/html part of a Message
+type MessageBody struct {
+	BodySection string
+	Type        string
+
+	// Charset is the original charset, and Content has been converted to UTF-8 if Converted is true
+	Charset   string
+	Converted bool
+	Content   string
+
+	// Encoding is the original Content-Transfer-Encoding, and Size the length of the decoded content
+	Encoding string
+	Size     int
+}
+
+// MessageAttachment is a part of a Message that is not a body
+type MessageAttachment struct {
+	BodySection        string
+	Type               string
+	FileName           string
+	ContentDisposition string
+	ContentID          string
+
+	// Inline is true for parts that are meant to be displayed within a body (ex: images referenced by cid:)
+	Inline bool
+
+	// Encoding is the original Content-Transfer-Encoding, and Size the length of the decoded Content
+	Encoding string
+	Size     int
+	Content  []byte
+
+	// Message is the parsed nested message, if Type is message/rfc822
+	Message *Message
+}
+
+// Parse parses the raw message text into a *Message
+func (raw GetUserEmailAccountsFolderMessageRawResponse) Parse() (*Message, error) {
+	return ParseRawMessage(strings.NewReader(string(raw)))
+}
+
+// ParseRawMessage parses raw RFC-822 message text (ex: from GetUserEmailAccountsFolderMessageRaw,
+// or a RawMessageStream) into a *Message, decoding headers, transfer encodings, and charsets.
+// Use a MessageParser to convert additional charsets.
+func ParseRawMessage(raw io.Reader) (*Message, error) {
+	return MessageParser{}.Parse(raw)
+}
+
+// Parse parses raw RFC-822 message text into a *Message, the same as ParseRawMessage,
+// but also converting any charsets supported by the CharsetReader
+func (parser MessageParser) Parse(raw io.Reader) (*Message, error) {
+	return parser.parseMessage(raw, "", 0)
+}
+
+// parseMessage parses a message whose parts are numbered under the section prefix
+func (parser MessageParser) parseMessage(raw io.Reader, prefix string, depth int) (*Message, error) {
+	mailMsg, err := mail.ReadMessage(raw)
+	if err != nil {
+		return nil, errors.Wrap(err, "CIO: Could not parse raw message headers")
+	}
+
+	msg := &Message{Header: make(mail.Header, len(mailMsg.Header))}
+	for key, values := range mailMsg.Header {
+		decoded := make([]string, len(values))
+		for i, value := range values {
+			decoded[i] = parser.decodeHeader(value)
+		}
+		msg.Header[key] = decoded
+	}
+
+	msg.Subject = msg.Header.Get("Subject")
+	msg.MessageID = strings.TrimSpace(msg.Header.Get("Message-Id"))
+	msg.InReplyTo = strings.TrimSpace(msg.Header.Get("In-Reply-To"))
+	msg.References = strings.Fields(msg.Header.Get("References"))
+	msg.Date, _ = mailMsg.Header.Date()
+
+	msg.From = parser.parseAddressList(mailMsg.Header.Get("From"))
+	msg.Sender = parser.parseAddressList(mailMsg.Header.Get("Sender"))
+	msg.ReplyTo = parser.parseAddressList(mailMsg.Header.Get("Reply-To"))
+	msg.To = parser.parseAddressList(mailMsg.Header.Get("To"))
+	msg.Cc = parser.parseAddressList(mailMsg.Header.Get("Cc"))
+	msg.Bcc = parser.parseAddressList(mailMsg.Header.Get("Bcc"))
+
+	header := textproto.MIMEHeader(mailMsg.Header)
+	if mediaType, params := contentType(header); strings.HasPrefix(mediaType, "multipart/") {
+		err = parser.parseMultipart(msg, mailMsg.Body, params["boundary"], prefix, depth)
+	} else {
+		err = parser.parsePart(msg, header, mailMsg.Body, childSection(prefix, 1), depth)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return msg, nil
+}
+
+// parseMultipart parses each part of a multipart body into the message, numbering them under the section prefix
+func (parser MessageParser) parseMultipart(msg *Message, body io.Reader, boundary string, prefix string, depth int) error {
+	if depth >= maxMessageDepth {
+		return errors.New("CIO: Raw message is nested too deeply")
+	}
+	if len(boundary) == 0 {
+		return errors.New("CIO: Multipart section " + prefix + " has no boundary")
+	}
+
+	reader := multipart.NewReader(body, boundary)
+	for i := 1; ; i++ {
+		part, err := reader.NextRawPart()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrap(err, "CIO: Could not read multipart section "+childSection(prefix, i))
+		}
+
+		section := childSection(prefix, i)
+		if mediaType, params := contentType(part.Header); strings.HasPrefix(mediaType, "multipart/") {
+			err = parser.parseMultipart(msg, part, params["boundary"], section, depth+1)
+		} else {
+			err = parser.parsePart(msg, part.Header, part, section, depth+1)
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// parsePart parses a single (non-multipart) part into the message, as either a body or an attachment
+func (parser MessageParser) parsePart(msg *Message, header textproto.MIMEHeader, body io.Reader, section string, depth int) error {
+	mediaType, params := contentType(header)
+	encoding := strings.ToLower(strings.TrimSpace(header.Get("Content-Transfer-Encoding")))
+
+	content, err := ioutil.ReadAll(transferDecoder(encoding, body))
+	if err != nil {
+		return errors.Wrap(err, "CIO: Could not decode section "+section)
+	}
+
+	disposition, dispositionParams, _ := mime.ParseMediaType(header.Get("Content-Disposition"))
+	fileName := dispositionParams["filename"]
+	if len(fileName) == 0 {
+		fileName = params["name"]
+	}
+	fileName = parser.decodeHeader(fileName)
+
+	// Text parts are bodies, unless they are explicitly attached files
+	if (mediaType == "text/plain" || mediaType == "text/html") && disposition != "attachment" && len(fileName) == 0 {
+		text, converted := parser.toUTF8(params["charset"], content)
+		msg.Bodies = append(msg.Bodies, MessageBody{
+			BodySection: section,
+			Type:        mediaType,
+			Charset:     params["charset"],
+			Converted:   converted,
+			Content:     text,
+			Encoding:    encoding,
+			Size:        len(content),
+		})
+		return nil
+	}
+
+	contentID := strings.Trim(strings.TrimSpace(header.Get("Content-Id")), "<>")
+	attachment := MessageAttachment{
+		BodySection:        section,
+		Type:               mediaType,
+		FileName:           fileName,
+		ContentDisposition: disposition,
+		ContentID:          contentID,
+		Inline:             disposition == "inline" || (len(disposition) == 0 && len(contentID) > 0),
+		Encoding:           encoding,
+		Size:               len(content),
+		Content:            content,
+	}
+
+	if mediaType == "message/rfc822" {
+		if depth >= maxMessageDepth {
+			return errors.New("CIO: Raw message is nested too deeply")
+		}
+		if attachment.Message, err = parser.parseMessage(bytes.NewReader(content), section, depth+1); err != nil {
+			return err
+		}
+	}
+
+	msg.Attachments = append(msg.Attachments, attachment)
+	return nil
+}
+
+// childSection returns the IMAP section number of the i-th (1-based) child of the section prefix
+func childSection(prefix string, i int) string {
+	if len(prefix) == 0 {
+		return strconv.Itoa(i)
+	}
+	return prefix + "." + strconv.Itoa(i)
+}
+
+// contentType returns the lowercased media type and params of the Content-Type header,
+// defaulting to text/plain as per RFC 2045
+func contentType(header textproto.MIMEHeader) (string, map[string]string) {
+	mediaType, params, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil || len(mediaType) == 0 {
+		return "text/plain", map[string]string{"charset": "us-ascii"}
+	}
+	return mediaType, params
+}
+
+// transferDecoder returns a reader that decodes the Content-Transfer-Encoding
+func transferDecoder(encoding string, body io.Reader) io.Reader {
+	switch encoding {
+	case "base64":
+		return base64.NewDecoder(base64.StdEncoding, &base64Cleaner{reader: body})
+	case "quoted-printable":
+		return quotedprintable.NewReader(body)
+	default:
+		// 7bit, 8bit, binary
+		return body
+	}
+}
+
+// base64Cleaner strips characters that are not part of the base64 alphabet (ex: whitespace),
+// which mail clients sometimes include within base64 encoded parts
+type base64Cleaner struct {
+	reader io.Reader
+}
+
+// Read implements io.Reader
+func (cleaner *base64Cleaner) Read(p []byte) (int, error) {
+	for {
+		n, err := cleaner.reader.Read(p)
+		kept := 0
+		for _, c := range p[:n] {
+			if ('A' <= c && c <= 'Z') || ('a' <= c && c <= 'z') || ('0' <= c && c <= '9') || c == '+' || c == '/' || c == '=' {
+				p[kept] = c
+				kept++
+			}
+		}
+		if kept > 0 || err != nil {
+			return kept, err
+		}
+	}
+}
+
+// wordDecoder returns a decoder of RFC 2047 encoded words, in any charset supported by toUTF8
+func (parser MessageParser) wordDecoder() *mime.WordDecoder {
+	return &mime.WordDecoder{
+		CharsetReader: func(charset string, input io.Reader) (io.Reader, error) {
+			content, err := ioutil.ReadAll(input)
+			if err != nil {
+				return nil, err
+			}
+			text, converted := parser.toUTF8(charset, content)
+			if !converted {
+				return nil, errors.New("CIO: Unsupported charset " + charset)
+			}
+			return strings.NewReader(text), nil
+		},
+	}
+}
+
+// decodeHeader decodes any RFC 2047 encoded words in the header value, returning it unchanged if it can not be decoded
+func (parser MessageParser) decodeHeader(value string) string {
+	decoded, err := parser.wordDecoder().DecodeHeader(value)
+	if err != nil {
+		return value
+	}
+	return decoded
+}
+
+// parseAddressList parses an address list header, returning nil if it is empty or invalid
+func (parser MessageParser) parseAddressList(value string) []*mail.Address {
+	if len(strings.TrimSpace(value)) == 0 {
+		return nil
+	}
+	addressParser := mail.AddressParser{WordDecoder: parser.wordDecoder()}
+	addresses, err := addressParser.ParseList(value)
+	if err != nil {
+		return nil
+	}
+	return addresses
+}
+
+// toUTF8 converts the content from the charset into UTF-8, returning false
+// (and the content unchanged) if the charset is not supported
+func (parser MessageParser) toUTF8(charset string, content []byte) (string, bool) {
+	switch strings.ToLower(strings.TrimSpace(charset)) {
+	case "", "utf-8", "utf8", "us-ascii", "ascii":
+		return string(content), utf8.Valid(content)
+	case "iso-8859-1", "latin1", "l1":
+		return singleByteToUTF8(content, nil), true
+	case "iso-8859-15", "latin-9", "l9":
+		return singleByteToUTF8(content, iso885915), true
+	case "windows-1252", "cp1252":
+		return singleByteToUTF8(content, windows1252), true
+	}
+
+	if parser.CharsetReader != nil {
+		if reader, err := parser.CharsetReader(charset, bytes.NewReader(content)); err == nil {
+			if converted, err := ioutil.ReadAll(reader); err == nil {
+				return string(converted), true
+			}
+		}
+	}
+	return string(content), false
+}
+
+// singleByteToUTF8 converts single byte encoded content, using the overrides
+// for bytes that differ from ISO-8859-1
+func singleByteToUTF8(content []byte, overrides map[byte]rune) string {
+	runes := make([]rune, len(content))
+	for i, b := range content {
+		if r, ok := overrides[b]; ok {
+			runes[i] = r
+		} else {
+			runes[i] = rune(b)
+		}
+	}
+	return string(runes)
+}
+
+// iso885915 are the bytes of ISO-8859-15 that differ from ISO-8859-1
+var iso885915 = map[byte]rune{
+	0xA4: '€', 0xA6: 'Š', 0xA8: 'š', 0xB4: 'Ž', 0xB8: 'ž', 0xBC: 'Œ', 0xBD: 'œ', 0xBE: 'Ÿ',
+}
+
+// windows1252 are the bytes of Windows-1252 that differ from ISO-8859-1
+var windows1252 = map[byte]rune{
+	0x80: '€', 0x82: '‚', 0x83: 'ƒ', 0x84: '„', 0x85: '…', 0x86: '†', 0x87: '‡', 0x88: 'ˆ',
+	0x89: '‰', 0x8A: 'Š', 0x8B: '‹', 0x8C: 'Œ', 0x8E: 'Ž', 0x91: '‘', 0x92: '’', 0x93: '“',
+	0x94: '”', 0x95: '•', 0x96: '–', 0x97: '—', 0x98: '˜', 0x99: '™', 0x9A: 'š', 0x9B: '›',
+	0x9C: 'œ', 0x9E: 'ž', 0x9F: 'Ÿ',
+}
@@ -0,0 +1,158 @@
+package ciolite
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+// testMultipartRawMessage has alternative bodies, an inline image, an attachment, and a nested message
+var testMultipartRawMessage = strings.Replace(`From: =?UTF-8?B?SsO8cmdlbg==?= <j@example.com>
+To: "Bob" <bob@example.com>, carol@example.com
+Subject: =?ISO-8859-1?Q?Caf=E9?= menu
+Message-ID: <m1@example.com>
+References: <a@example.com> <b@example.com>
+Date: Mon, 02 Jan 2017 15:04:05 +0000
+MIME-Version: 1.0
+Content-Type: multipart/mixed; boundary="outer"
+
+--outer
+Content-Type: multipart/related; boundary="related"
+
+--related
+Content-Type: multipart/alternative; boundary="alt"
+
+--alt
+Content-Type: text/plain; charset=iso-8859-1
+Content-Transfer-Encoding: quoted-printable
+
+Caf=E9 au lait
+--alt
+Content-Type: text/html; charset=windows-1252
+Content-Transfer-Encoding: base64
+
+PHA+k2hplDwvcD4=
+--alt--
+--related
+Content-Type: image/png
+Content-ID: <logo@example.com>
+Content-Transfer-Encoding: base64
+
+iVBORw0K
+--related--
+--outer
+Content-Type: text/plain; name="notes.txt"
+Content-Disposition: attachment; filename*=UTF-8''n%C3%B6tes.txt
+
+some notes
+--outer
+Content-Type: message/rfc822
+
+Subject: Forwarded
+Content-Type: multipart/mixed; boundary="inner"
+
+--inner
+Content-Type: text/plain
+
+Inner body
+--inner
+Content-Type: application/pdf; name="=?UTF-8?Q?r=C3=A9sum=C3=A9.pdf?="
+
+%PDF
+--inner--
+--outer--
+`, "\n", "\r\n", -1)
+
+// TestParseRawMessage tests parsing headers, bodies, attachments, and section numbers of a raw message
+func TestParseRawMessage(t *testing.T) {
+	t.Parallel()
+
+	msg, err := GetUserEmailAccountsFolderMessageRawResponse(testMultipartRawMessage).Parse()
+	if err != nil {
+		t.Fatal("Expected no error; Got: ", err)
+	}
+
+	if msg.Subject != "Café menu" || msg.MessageID != "<m1@example.com>" || len(msg.References) != 2 || msg.Date.Year() != 2017 {
+		t.Error("Unexpected headers: ", msg.Subject, msg.MessageID, msg.References, msg.Date)
+	}
+	if len(msg.From) != 1 || msg.From[0].Name != "Jürgen" || len(msg.To) != 2 || msg.To[1].Address != "carol@example.com" {
+		t.Error("Unexpected addresses: ", msg.From, msg.To)
+	}
+
+	expectedBodies := []MessageBody{
+		{BodySection: "1.1.1", Type: "text/plain", Content: "Café au lait"},
+		{BodySection: "1.1.2", Type: "text/html", Content: "<p>“hi”</p>"},
+	}
+	if len(msg.Bodies) != len(expectedBodies) {
+		t.Fatal("Expected bodies: ", len(expectedBodies), "; Got: ", msg.Bodies)
+	}
+	for i, expected := range expectedBodies {
+		body := msg.Bodies[i]
+		if body.BodySection != expected.BodySection || body.Type != expected.Type || body.Content != expected.Content || !body.Converted {
+			t.Error("Expected: ", expected, "; Got: ", body)
+		}
+	}
+
+	expectedAttachments := []MessageAttachment{
+		{BodySection: "1.2", Type: "image/png", ContentID: "logo@example.com", Inline: true},
+		{BodySection: "2", Type: "text/plain", FileName: "nötes.txt", ContentDisposition: "attachment"},
+		{BodySection: "3", Type: "message/rfc822"},
+	}
+	if len(msg.Attachments) != len(expectedAttachments) {
+		t.Fatal("Expected attachments: ", len(expectedAttachments), "; Got: ", msg.Attachments)
+	}
+	for i, expected := range expectedAttachments {
+		attachment := msg.Attachments[i]
+		if attachment.BodySection != expected.BodySection || attachment.Type != expected.Type || attachment.FileName != expected.FileName ||
+			attachment.ContentDisposition != expected.ContentDisposition || attachment.ContentID != expected.ContentID || attachment.Inline != expected.Inline {
+			t.Error("Expected: ", expected, "; Got: ", attachment)
+		}
+	}
+	if string(msg.Attachments[1].Content) != "some notes" {
+		t.Error("Expected: ", "some notes", "; Got: ", string(msg.Attachments[1].Content))
+	}
+
+	nested := msg.Attachments[2].Message
+	if nested == nil {
+		t.Fatal("Expected nested message")
+	}
+	if nested.Subject != "Forwarded" || len(nested.Bodies) != 1 || nested.Bodies[0].BodySection != "3.1" || nested.Bodies[0].Content != "Inner body" {
+		t.Error("Unexpected nested message: ", nested.Subject, nested.Bodies)
+	}
+	if len(nested.Attachments) != 1 || nested.Attachments[0].BodySection != "3.2" || nested.Attachments[0].FileName != "résumé.pdf" {
+		t.Error("Unexpected nested attachments: ", nested.Attachments)
+	}
+}
+
+// TestParseRawMessageSinglePart tests that a non-multipart message body is section 1
+func TestParseRawMessageSinglePart(t *testing.T) {
+	t.Parallel()
+
+	msg, err := ParseRawMessage(strings.NewReader("Subject: Hi\r\n\r\nHello\r\n"))
+	if err != nil {
+		t.Fatal("Expected no error; Got: ", err)
+	}
+	if len(msg.Bodies) != 1 || msg.Bodies[0].BodySection != "1" || msg.Bodies[0].Type != "text/plain" || msg.Bodies[0].Content != "Hello\r\n" {
+		t.Error("Unexpected bodies: ", msg.Bodies)
+	}
+
+	// Unsupported charsets are left unconverted
+	msg, err = ParseRawMessage(strings.NewReader("Content-Type: text/plain; charset=x-unknown\r\n\r\n\xff"))
+	if err != nil || len(msg.Bodies) != 1 || msg.Bodies[0].Converted || msg.Bodies[0].Charset != "x-unknown" {
+		t.Error("Unexpected bodies: ", msg, "; With Error: ", err)
+	}
+
+	// Unless a MessageParser's CharsetReader supports them
+	parser := MessageParser{CharsetReader: func(charset string, input io.Reader) (io.Reader, error) {
+		if charset != "x-unknown" {
+			return nil, errors.New("Unsupported charset")
+		}
+		return strings.NewReader("ÿ"), nil
+	}}
+	msg, err = parser.Parse(strings.NewReader("Subject: =?x-unknown?q?=FF?=\r\nContent-Type: text/plain; charset=x-unknown\r\n\r\n\xff"))
+	if err != nil || msg.Subject != "ÿ" || len(msg.Bodies) != 1 || !msg.Bodies[0].Converted || msg.Bodies[0].Content != "ÿ" {
+		t.Error("Unexpected message: ", msg, "; With Error: ", err)
+	}
+}
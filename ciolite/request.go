@@ -2,6 +2,7 @@ package ciolite
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"io"
 	"io/ioutil"
@@ -22,10 +23,50 @@ type clientRequest struct {
 	QueryValues  interface{}
 	UserID       string
 	AccountLabel string
+	Accept       string // Defaults to application/json
 }
 
-// doFormRequest makes the actual request
-func (cio CioLite) doFormRequest(request clientRequest, result interface{}) error {
+// sendFunc sends the *http.Request, and returns the status code, the response body, the response headers, and any error
+type sendFunc func(httpReq *http.Request, cioURL string) (int, string, http.Header, error)
+
+// doFormRequest makes the actual request, using the context for cancellation and deadlines,
+// and unmarshals the json response into the result
+func (cio CioLite) doFormRequest(ctx context.Context, request clientRequest, result interface{}) error {
+	return cio.doRequest(ctx, request, func(httpReq *http.Request, cioURL string) (int, string, http.Header, error) {
+		return cio.sendRequest(httpReq, result, cioURL)
+	})
+}
+
+// doStreamRequest makes the actual request, using the context for cancellation and deadlines,
+// and returns the *http.Response without reading its body, which the caller must close.
+func (cio CioLite) doStreamRequest(ctx context.Context, request clientRequest) (*http.Response, error) {
+	var res *http.Response
+	err := cio.doRequest(ctx, request, func(httpReq *http.Request, cioURL string) (int, string, http.Header, error) {
+		// Close the body of any previous attempt that is being retried
+		if res != nil {
+			cio.closeResponseBody(res)
+			res = nil
+		}
+		var (
+			statusCode int
+			resBody    string
+			resHeader  http.Header
+			err        error
+		)
+		res, statusCode, resBody, resHeader, err = cio.sendStreamRequest(httpReq, cioURL)
+		return statusCode, resBody, resHeader, err
+	})
+	if err != nil {
+		if res != nil {
+			cio.closeResponseBody(res)
+		}
+		return nil, err
+	}
+	return res, nil
+}
+
+// doRequest makes the request, with rate limiting, logging hooks, and retries, using the sendFunc for each attempt
+func (cio CioLite) doRequest(ctx context.Context, request clientRequest, send sendFunc) error {
 
 	// url.QueryEscape turns spaces into +, and we need to turn them into %20
 	// but we can't get rid of url.QueryEscape because it turns / into %2F for delimited folder names
@@ -38,23 +79,104 @@ func (cio CioLite) doFormRequest(request clientRequest, result interface{}) erro
 	bodyValues := formValues(request.FormValues)
 	bodyString := bodyValues.Encode()
 
-	// Before-Request Hook Function (logging)
+	// Before-Request Hook Functions (logging)
 	if cio.PreRequestHook != nil {
 		cio.PreRequestHook(request.UserID, request.AccountLabel, request.Method, cioURL, redactBodyValues(bodyValues))
 	}
+	var requestInfo RequestInfo
+	if cio.RequestHook != nil || cio.ResponseHook != nil {
+		requestInfo = RequestInfo{
+			RequestID:    newRequestID(),
+			Method:       request.Method,
+			URL:          cioURL,
+			PathTemplate: pathTemplate(escapedPath),
+			UserID:       request.UserID,
+			AccountLabel: request.AccountLabel,
+			BodyValues:   redactBodyValues(bodyValues),
+			PayloadSize:  len(bodyString),
+		}
+	}
+	if cio.RequestHook != nil {
+		cio.RequestHook(ctx, requestInfo)
+	}
 
 	var (
 		statusCode int
 		resBody    string
+		resHeader  http.Header
 		err        error
 	)
 
 	beforeAll := time.Now().UTC()
+
+	// notSent reports an attempt that was never sent (which is not retried) to the ResponseHook
+	notSent := func(attempt int, beforeAttempt time.Time, err error) {
+		if cio.ResponseHook != nil {
+			cio.ResponseHook(ctx, ResponseInfo{
+				Request:         requestInfo,
+				Attempt:         attempt,
+				AttemptDuration: time.Since(beforeAttempt),
+				TotalDuration:   time.Since(beforeAll),
+				Err:             err,
+			})
+		}
+	}
+
 	for i := 1; ; i++ {
+		// Client-side rate limiting, which either waits or fails fast
+		if cio.RateLimiter != nil {
+			beforeWait := time.Now().UTC()
+			if limitErr := cio.RateLimiter.Wait(ctx, request.UserID, request.AccountLabel); limitErr != nil {
+				err = RequestError{errors.Wrap(limitErr, "CIO: Rate limiter did not allow request"), ErrorMetaData{Method: request.Method, URL: cioURL}}
+
+				// After-Request Hook Functions (logging), without retrying as the limiter would only refuse again
+				if cio.PostRequestShouldRetryHook != nil {
+					cio.PostRequestShouldRetryHook(i, request.UserID, request.AccountLabel, request.Method, cioURL, 0, "", beforeWait, beforeAll, err)
+				}
+				notSent(i, beforeWait, err)
+				break
+			}
+		}
+
 		beforeAttempt := time.Now().UTC()
-		statusCode, resBody, err = cio.createAndSendRequest(request, cioURL, bodyString, bodyValues, result)
+		var sent bool
+		statusCode, resBody, resHeader, sent, err = cio.createAndSendRequest(ctx, request, cioURL, bodyString, bodyValues, send)
+
 		// After-Request Hook Function (logging)
-		if cio.PostRequestShouldRetryHook == nil || !cio.PostRequestShouldRetryHook(i, request.UserID, request.AccountLabel, request.Method, cioURL, statusCode, resBody, beforeAttempt, beforeAll, err) {
+		hookRetry := cio.PostRequestShouldRetryHook != nil && cio.PostRequestShouldRetryHook(i, request.UserID, request.AccountLabel, request.Method, cioURL, statusCode, resBody, beforeAttempt, beforeAll, err)
+
+		// Built-in retry policy, which provides the backoff delay.
+		// Requests that could not even be formed (ex: bad url) will never succeed, so are not retried.
+		var delay time.Duration
+		policyRetry := false
+		if cio.RetryPolicy != nil && sent {
+			delay, policyRetry = cio.RetryPolicy.retryDelay(i, request.Method, statusCode, resHeader, err)
+		}
+
+		if cio.ResponseHook != nil {
+			cio.ResponseHook(ctx, ResponseInfo{
+				Request:         requestInfo,
+				Attempt:         i,
+				StatusCode:      statusCode,
+				Payload:         resBody,
+				PayloadSize:     len(resBody),
+				AttemptDuration: time.Since(beforeAttempt),
+				TotalDuration:   time.Since(beforeAll),
+				Err:             err,
+				WillRetry:       hookRetry || policyRetry,
+				RetryDelay:      delay,
+			})
+		}
+
+		if !hookRetry && !policyRetry {
+			break
+		}
+
+		// Do not retry if the context has been canceled or its deadline has passed
+		beforeSleep := time.Now().UTC()
+		if ctxErr := sleepContext(ctx, delay); ctxErr != nil {
+			err = RequestError{errors.Wrap(ctxErr, "CIO: Request context done before retry"), ErrorMetaData{Method: request.Method, URL: cioURL, StatusCode: statusCode, Payload: resBody}}
+			notSent(i+1, beforeSleep, err)
 			break
 		}
 	}
@@ -63,8 +185,8 @@ func (cio CioLite) doFormRequest(request clientRequest, result interface{}) erro
 }
 
 // createAndSendRequest creates the body io.Reader, the *http.Request, and sends the request, logging the response.
-// Returns the status code, the response body, and any error
-func (cio CioLite) createAndSendRequest(request clientRequest, cioURL string, bodyString string, bodyValues url.Values, result interface{}) (int, string, error) {
+// Returns the status code, the response body, the response headers, whether the request was sent, and any error
+func (cio CioLite) createAndSendRequest(ctx context.Context, request clientRequest, cioURL string, bodyString string, bodyValues url.Values, send sendFunc) (int, string, http.Header, bool, error) {
 
 	var bodyReader io.Reader
 	if len(bodyString) > 0 {
@@ -72,22 +194,24 @@ func (cio CioLite) createAndSendRequest(request clientRequest, cioURL string, bo
 	}
 
 	// Construct the request
-	httpReq, err := cio.createRequest(request, cioURL, bodyReader, bodyValues)
+	httpReq, err := cio.createRequest(ctx, request, cioURL, bodyReader, bodyValues)
 	if err != nil {
-		return 0, "", err
+		return 0, "", nil, false, err
 	}
 
 	// Send the request
-	return cio.sendRequest(httpReq, result, cioURL)
+	statusCode, resBody, resHeader, err := send(httpReq, cioURL)
+	return statusCode, resBody, resHeader, true, err
 }
 
-// createRequest creates the *http.Request object
-func (cio CioLite) createRequest(request clientRequest, cioURL string, bodyReader io.Reader, bodyValues url.Values) (*http.Request, error) {
+// createRequest creates the *http.Request object, bound to the context
+func (cio CioLite) createRequest(ctx context.Context, request clientRequest, cioURL string, bodyReader io.Reader, bodyValues url.Values) (*http.Request, error) {
 	// Construct the request
 	httpReq, err := http.NewRequest(request.Method, cioURL, bodyReader)
 	if err != nil {
 		return httpReq, RequestError{errors.Wrap(err, "CIO: Failed to form request"), ErrorMetaData{Method: request.Method, URL: cioURL}}
 	}
+	httpReq = httpReq.WithContext(ctx)
 
 	// oAuth signature
 	var client oauth.Client
@@ -95,7 +219,11 @@ func (cio CioLite) createRequest(request clientRequest, cioURL string, bodyReade
 
 	// Add headers
 	httpReq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-	httpReq.Header.Set("Accept", "application/json")
+	accept := request.Accept
+	if len(accept) == 0 {
+		accept = "application/json"
+	}
+	httpReq.Header.Set("Accept", accept)
 	httpReq.Header.Set("Accept-Charset", "utf-8")
 	httpReq.Header.Set("User-Agent", "Golang CIO Library")
 	httpReq.Header.Set("Authorization", client.AuthorizationHeader(nil, request.Method, httpReq.URL, bodyValues))
@@ -103,26 +231,22 @@ func (cio CioLite) createRequest(request clientRequest, cioURL string, bodyReade
 	return httpReq, nil
 }
 
-// sendRequest sends the *http.Request, and returns the status code, the response body, and any error
-func (cio CioLite) sendRequest(httpReq *http.Request, result interface{}, cioURL string) (int, string, error) {
+// sendRequest sends the *http.Request, and returns the status code, the response body, the response headers, and any error
+func (cio CioLite) sendRequest(httpReq *http.Request, result interface{}, cioURL string) (int, string, http.Header, error) {
 
 	// Make the request
 	res, err := cio.HTTPClient.Do(httpReq)
 	if err != nil {
-		return 0, "", RequestError{errors.Wrap(err, "CIO: Failed to make request"), ErrorMetaData{Method: httpReq.Method, URL: cioURL}}
+		return 0, "", nil, RequestError{errors.Wrap(err, "CIO: Failed to make request"), ErrorMetaData{Method: httpReq.Method, URL: cioURL}}
 	}
 
 	// Parse the response
-	defer func() {
-		if closeErr := res.Body.Close(); closeErr != nil && cio.ResponseBodyCloseErrorHook != nil {
-			cio.ResponseBodyCloseErrorHook(closeErr) // Logging
-		}
-	}()
+	defer cio.closeResponseBody(res)
 
 	resBody, err := ioutil.ReadAll(res.Body)
 	resBodyString := string(resBody)
 	if err != nil {
-		return res.StatusCode, resBodyString, RequestError{errors.Wrap(err, "CIO: Could not read response"), ErrorMetaData{Method: httpReq.Method, URL: cioURL, StatusCode: res.StatusCode, Payload: resBodyString}}
+		return res.StatusCode, resBodyString, res.Header, RequestError{errors.Wrap(err, "CIO: Could not read response"), ErrorMetaData{Method: httpReq.Method, URL: cioURL, StatusCode: res.StatusCode, Payload: resBodyString}}
 	}
 
 	// Unmarshal result
@@ -130,16 +254,47 @@ func (cio CioLite) sendRequest(httpReq *http.Request, result interface{}, cioURL
 
 	// Return own error if Status Code >= 400
 	if res.StatusCode >= 400 {
-		return res.StatusCode, resBodyString, RequestError{errors.New("CIO: Status Code >= 400"), ErrorMetaData{Method: httpReq.Method, URL: cioURL, StatusCode: res.StatusCode, Payload: resBodyString}}
+		return res.StatusCode, resBodyString, res.Header, RequestError{errors.New("CIO: Status Code >= 400"), ErrorMetaData{Method: httpReq.Method, URL: cioURL, StatusCode: res.StatusCode, Payload: resBodyString}}
 	}
 
 	// Return Unmarshal error (if any) if Status Code is < 400
 	if err != nil {
-		return res.StatusCode, resBodyString, RequestError{errors.Wrap(err, "CIO: Could not unmarshal payload"), ErrorMetaData{Method: httpReq.Method, URL: cioURL, StatusCode: res.StatusCode, Payload: resBodyString}}
+		return res.StatusCode, resBodyString, res.Header, RequestError{errors.Wrap(err, "CIO: Could not unmarshal payload"), ErrorMetaData{Method: httpReq.Method, URL: cioURL, StatusCode: res.StatusCode, Payload: resBodyString}}
+	}
+	return res.StatusCode, resBodyString, res.Header, nil
+}
+
+// sendStreamRequest sends the *http.Request, and returns the *http.Response with its body unread (if successful),
+// the status code, the response body (only if unsuccessful), the response headers, and any error
+func (cio CioLite) sendStreamRequest(httpReq *http.Request, cioURL string) (*http.Response, int, string, http.Header, error) {
+
+	// Make the request
+	res, err := cio.HTTPClient.Do(httpReq)
+	if err != nil {
+		return nil, 0, "", nil, RequestError{errors.Wrap(err, "CIO: Failed to make request"), ErrorMetaData{Method: httpReq.Method, URL: cioURL}}
+	}
+
+	// Return own error (with the response body) if Status Code >= 400
+	if res.StatusCode >= 400 {
+		defer cio.closeResponseBody(res)
+		resBody, _ := ioutil.ReadAll(res.Body)
+		resBodyString := string(resBody)
+		return nil, res.StatusCode, resBodyString, res.Header, RequestError{errors.New("CIO: Status Code >= 400"), ErrorMetaData{Method: httpReq.Method, URL: cioURL, StatusCode: res.StatusCode, Payload: resBodyString}}
+	}
+
+	return res, res.StatusCode, "", res.Header, nil
+}
+
+// closeResponseBody closes the response body, logging any error
+func (cio CioLite) closeResponseBody(res *http.Response) {
+	if closeErr := res.Body.Close(); closeErr != nil && cio.ResponseBodyCloseErrorHook != nil {
+		cio.ResponseBodyCloseErrorHook(closeErr) // Logging
 	}
-	return res.StatusCode, resBodyString, nil
 }
 
+// redactedKeys are the keys of sensitive values, which are redacted from logs and cassettes
+var redactedKeys = []string{"password", "provider_refresh_token", "provider_consumer_key", "provider_consumer_secret"}
+
 // redactBodyValues returns a copy of the body values redacted
 func redactBodyValues(bodyValues url.Values) url.Values {
 
@@ -150,17 +305,10 @@ func redactBodyValues(bodyValues url.Values) url.Values {
 	}
 
 	// Redact sensitive information
-	if val := redactedValues.Get("password"); len(val) > 0 {
-		redactedValues.Set("password", "redacted")
-	}
-	if val := redactedValues.Get("provider_refresh_token"); len(val) > 0 {
-		redactedValues.Set("provider_refresh_token", "redacted")
-	}
-	if val := redactedValues.Get("provider_consumer_key"); len(val) > 0 {
-		redactedValues.Set("provider_consumer_key", "redacted")
-	}
-	if val := redactedValues.Get("provider_consumer_secret"); len(val) > 0 {
-		redactedValues.Set("provider_consumer_secret", "redacted")
+	for _, key := range redactedKeys {
+		if val := redactedValues.Get(key); len(val) > 0 {
+			redactedValues.Set(key, "redacted")
+		}
 	}
 
 	return redactedValues
@@ -0,0 +1,82 @@
+package ciolite
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// TestSimulatedContextCanceledRetries tests that a canceled context stops the retry loop
+func TestSimulatedContextCanceledRetries(t *testing.T) {
+	t.Parallel()
+
+	cioLite, logger, testServer, mux := NewTestCioLiteWithLoggerAndTestServer(t)
+	defer testServer.Close()
+
+	mux.HandleFunc("/lite/users/123abc", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, err := io.WriteString(w, `{"type":"error","value":"unavailable"}`)
+		Must(err)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	attempts := 0
+	cioLite.PostRequestShouldRetryHook = func(attemptNum int, userID string, label string, method string, url string, statusCode int, responseBody string, beforeAttempt time.Time, beforeAll time.Time, err error) bool {
+		attempts = attemptNum
+		if attemptNum == 2 {
+			cancel()
+		}
+		return true // Always retry
+	}
+
+	_, err := cioLite.GetUserContext(ctx, "123abc")
+
+	if attempts != 2 {
+		t.Error("Expected attempts: ", 2, "; Got: ", attempts, "; With Log: ", logger.String())
+	}
+
+	if errors.Cause(err) != context.Canceled {
+		t.Error("Expected error cause: ", context.Canceled, "; Got: ", err)
+	}
+
+	if ErrorStatusCode(err) != http.StatusServiceUnavailable {
+		t.Error("Expected error status code: ", http.StatusServiceUnavailable, "; Got: ", ErrorStatusCode(err))
+	}
+}
+
+// TestSimulatedContextDeadline tests that a context deadline is applied to the request
+func TestSimulatedContextDeadline(t *testing.T) {
+	t.Parallel()
+
+	cioLite, logger, testServer, mux := NewTestCioLiteWithLoggerAndTestServer(t)
+	defer testServer.Close()
+
+	mux.HandleFunc("/lite/users", func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(2 * time.Second):
+		}
+		_, err := io.WriteString(w, `[]`)
+		Must(err)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := cioLite.GetUsersContext(ctx, GetUsersParams{})
+
+	if err == nil {
+		t.Error("Expected deadline exceeded error; Got: ", err, "; With Log: ", logger.String())
+	}
+
+	if urlErr, ok := errors.Cause(err).(*url.Error); !ok || !urlErr.Timeout() {
+		t.Error("Expected timeout url.Error cause; Got: ", errors.Cause(err))
+	}
+}
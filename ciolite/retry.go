@@ -0,0 +1,181 @@
+package ciolite
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// DefaultRetryMaxAttempts is the default number of attempts (including the first) made by DefaultRetryPolicy
+	DefaultRetryMaxAttempts = 4
+
+	// DefaultRetryBaseDelay is the default delay before the first retry, which doubles on each subsequent retry
+	DefaultRetryBaseDelay = 500 * time.Millisecond
+
+	// DefaultRetryMaxDelay is the default maximum delay between any two attempts
+	DefaultRetryMaxDelay = 30 * time.Second
+
+	// DefaultRetryJitter is the default fraction of each delay that is randomized
+	DefaultRetryJitter = 0.2
+)
+
+// DefaultRetryableStatusCodes are the response status codes retried by a RetryPolicy
+// that does not set its own RetryableStatusCodes.
+var DefaultRetryableStatusCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// RetryPolicy defines when failed requests are retried, and how long to wait between attempts.
+// Delays grow exponentially from BaseDelay up to MaxDelay, with a random Jitter applied.
+// Requests are retried if the response status code is one of the RetryableStatusCodes,
+// or if the request was sent but no response was received at all (ex: connection reset or timeout).
+// Requests that could not be formed (ex: bad url or method) are never retried, as they can never succeed.
+// Only idempotent requests (GET/HEAD) are retried unless RetryNonIdempotent is set.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first one.
+	// Values less than 2 disable retries.
+	MaxAttempts int
+
+	// BaseDelay is the delay before the first retry, doubled on each subsequent retry
+	BaseDelay time.Duration
+
+	// MaxDelay caps the delay between attempts (0 means no cap)
+	MaxDelay time.Duration
+
+	// Jitter is the fraction (0.0 to 1.0) of each delay that is randomized,
+	// so that many clients failing at once do not all retry at the same moment
+	Jitter float64
+
+	// RetryableStatusCodes are the response status codes that will be retried.
+	// If nil, DefaultRetryableStatusCodes is used.
+	RetryableStatusCodes []int
+
+	// RespectRetryAfter will wait at least as long as the response's Retry-After header asks.
+	// If the Retry-After is longer than MaxDelay the request is not retried.
+	RespectRetryAfter bool
+
+	// RetryNonIdempotent allows POST/PUT/DELETE requests to be retried as well,
+	// which may cause the same change to be applied more than once.
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryPolicy returns a RetryPolicy with sensible defaults,
+// which retries idempotent requests on 429/502/503/504 responses and connection errors.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:       DefaultRetryMaxAttempts,
+		BaseDelay:         DefaultRetryBaseDelay,
+		MaxDelay:          DefaultRetryMaxDelay,
+		Jitter:            DefaultRetryJitter,
+		RespectRetryAfter: true,
+	}
+}
+
+// retryDelay returns the delay to wait before the next attempt, and whether the request should be retried at all
+func (policy *RetryPolicy) retryDelay(attempt int, method string, statusCode int, header http.Header, err error) (time.Duration, bool) {
+
+	// Only retry errors
+	if err == nil || attempt >= policy.MaxAttempts {
+		return 0, false
+	}
+
+	// Only retry idempotent requests, unless told otherwise
+	if !policy.RetryNonIdempotent && method != "GET" && method != "HEAD" {
+		return 0, false
+	}
+
+	// A status code of 0 means the request was sent but no response was received, which is always retryable
+	// (requests that could not be formed are never passed to the retry policy)
+	if statusCode != 0 && !policy.retryableStatusCode(statusCode) {
+		return 0, false
+	}
+
+	delay := policy.backoff(attempt)
+
+	if policy.RespectRetryAfter {
+		if retryAfter, ok := parseRetryAfter(header); ok {
+			if policy.MaxDelay > 0 && retryAfter > policy.MaxDelay {
+				return 0, false
+			}
+			if retryAfter > delay {
+				delay = retryAfter
+			}
+		}
+	}
+
+	return delay, true
+}
+
+// retryableStatusCode returns true if the status code is one that should be retried
+func (policy *RetryPolicy) retryableStatusCode(statusCode int) bool {
+	codes := policy.RetryableStatusCodes
+	if codes == nil {
+		codes = DefaultRetryableStatusCodes
+	}
+	for _, code := range codes {
+		if code == statusCode {
+			return true
+		}
+	}
+	return false
+}
+
+// backoff returns the exponential delay (with jitter) to wait after the numbered attempt
+func (policy *RetryPolicy) backoff(attempt int) time.Duration {
+	delay := policy.BaseDelay
+	for i := 1; i < attempt && (policy.MaxDelay <= 0 || delay < policy.MaxDelay); i++ {
+		delay *= 2
+	}
+	if policy.MaxDelay > 0 && delay > policy.MaxDelay {
+		delay = policy.MaxDelay
+	}
+
+	// Randomly remove up to Jitter fraction of the delay
+	if policy.Jitter > 0 && delay > 0 {
+		jitter := policy.Jitter
+		if jitter > 1 {
+			jitter = 1
+		}
+		delay -= time.Duration(jitter * rand.Float64() * float64(delay))
+	}
+	return delay
+}
+
+// parseRetryAfter parses the Retry-After header, which may be in seconds or an http date
+func parseRetryAfter(header http.Header) (time.Duration, bool) {
+	value := header.Get("Retry-After")
+	if len(value) == 0 {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if delay := time.Until(date); delay > 0 {
+			return delay, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// sleepContext waits for the duration, returning early with the context's error if it is done first
+func sleepContext(ctx context.Context, delay time.Duration) error {
+	if delay <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
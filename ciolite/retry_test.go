@@ -0,0 +1,139 @@
+package ciolite
+
+import (
+	"io"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// TestSimulatedRetryPolicy tests that the RetryPolicy retries idempotent requests until success
+func TestSimulatedRetryPolicy(t *testing.T) {
+	t.Parallel()
+
+	cioLite, logger, testServer, mux := NewTestCioLiteWithLoggerAndTestServer(t)
+	defer testServer.Close()
+
+	cioLite.RetryPolicy = &RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 10 * time.Millisecond, Jitter: 0.5}
+
+	calls := 0
+	mux.HandleFunc("/lite/users/123abc", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, err := io.WriteString(w, `{"id":"123abc"}`)
+		Must(err)
+	})
+
+	user, err := cioLite.GetUser("123abc")
+
+	if err != nil || user.ID != "123abc" || calls != 3 {
+		t.Error("Expected user 123abc after 3 calls; Got: ", user, "; With Calls: ", calls, "; With Error: ", err, "; With Log: ", logger.String())
+	}
+}
+
+// TestSimulatedRetryPolicyNonIdempotent tests that the RetryPolicy does not retry POST requests by default
+func TestSimulatedRetryPolicyNonIdempotent(t *testing.T) {
+	t.Parallel()
+
+	cioLite, logger, testServer, mux := NewTestCioLiteWithLoggerAndTestServer(t)
+	defer testServer.Close()
+
+	cioLite.RetryPolicy = &RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}
+
+	calls := 0
+	mux.HandleFunc("/lite/users/123abc", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	_, err := cioLite.ModifyUser("123abc", ModifyUserParams{FirstName: "first", LastName: "last"})
+
+	if err == nil || calls != 1 {
+		t.Error("Expected a single failed call; Got Calls: ", calls, "; With Error: ", err, "; With Log: ", logger.String())
+	}
+
+	// Allow non-idempotent retries
+	calls = 0
+	cioLite.RetryPolicy.RetryNonIdempotent = true
+
+	_, err = cioLite.ModifyUser("123abc", ModifyUserParams{FirstName: "first", LastName: "last"})
+
+	if err == nil || calls != 3 {
+		t.Error("Expected three failed calls; Got Calls: ", calls, "; With Error: ", err, "; With Log: ", logger.String())
+	}
+}
+
+// TestSimulatedRetryPolicyFormError tests that the RetryPolicy does not retry requests that could not be formed
+func TestSimulatedRetryPolicyFormError(t *testing.T) {
+	t.Parallel()
+
+	cioLite, logger, testServer, _ := NewTestCioLiteWithLoggerAndTestServer(t)
+	defer testServer.Close()
+
+	cioLite.RetryPolicy = &RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}
+	cioLite.Host = "http://bad host"
+
+	attempts := 0
+	cioLite.PostRequestShouldRetryHook = func(attemptNum int, userID string, label string, method string, url string, statusCode int, responseBody string, beforeAttempt time.Time, beforeAll time.Time, err error) bool {
+		attempts++
+		return false
+	}
+
+	_, err := cioLite.GetUser("123abc")
+
+	if err == nil || attempts != 1 {
+		t.Error("Expected a single failed attempt; Got Attempts: ", attempts, "; With Error: ", err, "; With Log: ", logger.String())
+	}
+}
+
+// TestRetryPolicyDelay tests the backoff, status code, and Retry-After handling of RetryPolicy
+func TestRetryPolicyDelay(t *testing.T) {
+	t.Parallel()
+
+	policy := &RetryPolicy{MaxAttempts: 10, BaseDelay: time.Second, MaxDelay: 5 * time.Second, RespectRetryAfter: true}
+	someErr := RequestError{}
+
+	// Exponential, capped at MaxDelay
+	for attempt, expected := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 3: 4 * time.Second, 4: 5 * time.Second, 9: 5 * time.Second} {
+		if delay, retry := policy.retryDelay(attempt, "GET", http.StatusBadGateway, http.Header{}, someErr); !retry || delay != expected {
+			t.Error("Expected delay for attempt ", attempt, ": ", expected, "; Got: ", delay, retry)
+		}
+	}
+
+	// Out of attempts, no error, or not retryable status codes
+	if _, retry := policy.retryDelay(10, "GET", http.StatusBadGateway, http.Header{}, someErr); retry {
+		t.Error("Expected no retry after MaxAttempts")
+	}
+	if _, retry := policy.retryDelay(1, "GET", http.StatusOK, http.Header{}, nil); retry {
+		t.Error("Expected no retry without an error")
+	}
+	if _, retry := policy.retryDelay(1, "GET", http.StatusNotFound, http.Header{}, someErr); retry {
+		t.Error("Expected no retry of status code 404")
+	}
+
+	// Connection errors are retried
+	if _, retry := policy.retryDelay(1, "GET", 0, nil, someErr); !retry {
+		t.Error("Expected retry of connection error")
+	}
+
+	// Retry-After
+	header := http.Header{"Retry-After": []string{"3"}}
+	if delay, retry := policy.retryDelay(1, "GET", http.StatusTooManyRequests, header, someErr); !retry || delay != 3*time.Second {
+		t.Error("Expected Retry-After delay: ", 3*time.Second, "; Got: ", delay, retry)
+	}
+	header.Set("Retry-After", "60")
+	if _, retry := policy.retryDelay(1, "GET", http.StatusTooManyRequests, header, someErr); retry {
+		t.Error("Expected no retry with Retry-After greater than MaxDelay")
+	}
+
+	// Jitter only ever shortens the delay
+	policy.Jitter = 1
+	for i := 0; i < 20; i++ {
+		if delay := policy.backoff(1); delay < 0 || delay > time.Second {
+			t.Error("Expected jittered delay between 0 and 1s; Got: ", delay)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package ciolite
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// FailureReason is the classification of a StatusCallback's Failure value
+type FailureReason int
+
+// FailureReason values
+const (
+	// FailureNone means the account is fine (the callback has no Failure)
+	FailureNone FailureReason = iota
+	// FailureUnknown means the Failure value was not recognized
+	FailureUnknown
+	// FailureInvalidCredentials means the password or OAuth token is no longer valid, and the user must re-authenticate
+	FailureInvalidCredentials
+	// FailureConnectionImpossible means the mail server could not be reached
+	FailureConnectionImpossible
+	// FailureNoAccessToAllMail means a Gmail account has hidden its "All Mail" folder from IMAP
+	FailureNoAccessToAllMail
+	// FailureTempDisabled means the account has been temporarily disabled by CIO or the provider
+	FailureTempDisabled
+	// FailureDisabled means the account has been disabled
+	FailureDisabled
+)
+
+// failureReasons maps the normalized Failure values sent by CIO to their FailureReason
+var failureReasons = map[string]FailureReason{
+	"invalid_credentials":    FailureInvalidCredentials,
+	"authentication_failure": FailureInvalidCredentials,
+	"connection_impossible":  FailureConnectionImpossible,
+	"no_access_to_all_mail":  FailureNoAccessToAllMail,
+	"temp_disabled":          FailureTempDisabled,
+	"temporarily_disabled":   FailureTempDisabled,
+	"disabled":               FailureDisabled,
+	"account_disabled":       FailureDisabled,
+}
+
+// String returns the name of the FailureReason
+func (reason FailureReason) String() string {
+	switch reason {
+	case FailureNone:
+		return "None"
+	case FailureInvalidCredentials:
+		return "InvalidCredentials"
+	case FailureConnectionImpossible:
+		return "ConnectionImpossible"
+	case FailureNoAccessToAllMail:
+		return "NoAccessToAllMail"
+	case FailureTempDisabled:
+		return "TempDisabled"
+	case FailureDisabled:
+		return "Disabled"
+	default:
+		return "Unknown"
+	}
+}
+
+// NeedsReauthentication returns true if the user must re-authenticate (ex: with a new connect token)
+// for CIO to regain access to the account.
+func (reason FailureReason) NeedsReauthentication() bool {
+	return reason == FailureInvalidCredentials || reason == FailureNoAccessToAllMail
+}
+
+// IsFailure returns true if this StatusCallback reports a failure with the account
+func (callback StatusCallback) IsFailure() bool {
+	return len(callback.Failure) > 0
+}
+
+// FailureReason classifies the Failure value of this StatusCallback
+func (callback StatusCallback) FailureReason() FailureReason {
+	if !callback.IsFailure() {
+		return FailureNone
+	}
+	normalized := strings.Replace(strings.ToLower(strings.TrimSpace(callback.Failure)), " ", "_", -1)
+	if reason, ok := failureReasons[normalized]; ok {
+		return reason
+	}
+	return FailureUnknown
+}
+
+// StatusCallbackHandlerOptions configures a StatusCallbackHandler.
+type StatusCallbackHandlerOptions struct {
+	// OnStatus is called with each authenticated status callback
+	OnStatus func(context.Context, StatusCallback) error
+
+	// ErrorHook is a function (purely for logging) that is called with any error
+	// that causes the handler to respond with a non-200 status code,
+	// and with ErrCallbackReplayed when a replayed callback is ignored
+	ErrorHook func(*http.Request, error)
+
+	// MaxAge rejects callbacks whose Timestamp is older (or further in the future) than this,
+	// to prevent replays. Defaults to DefaultCallbackMaxAge, and a negative value disables the check.
+	MaxAge time.Duration
+
+	// NonceStore, if set, is used to ignore callbacks whose Token has already been received
+	// (see NewMemoryNonceStore). Replays are acknowledged with a 200 but not dispatched.
+	NonceStore NonceStore
+
+	// MaxBodyBytes limits the size of the callback body. Defaults to DefaultCallbackMaxBodyBytes.
+	MaxBodyBytes int64
+}
+
+// StatusCallbackHandler is an http.Handler that receives User Account Status Callbacks from CIO,
+// for both the app-level status callback url (see CreateStatusCallbackURL) and the per-account
+// ones (see ModifyUserEmailAccountParams.StatusCallbackURL), authenticates them,
+// and passes them to the registered callback.
+// 	https://context.io/docs/lite/users/email_accounts#post
+// 	https://context.io/docs/app/status_callback_url
+type StatusCallbackHandler struct {
+	cioLite CioLite
+	options StatusCallbackHandlerOptions
+}
+
+// NewStatusCallbackHandler returns a *StatusCallbackHandler that validates callbacks using the CioLite's secret.
+// It responds with the same status codes as WebhookHandler.
+func NewStatusCallbackHandler(cioLite CioLite, options StatusCallbackHandlerOptions) *StatusCallbackHandler {
+	return &StatusCallbackHandler{cioLite: cioLite, options: options}
+}
+
+// ServeHTTP implements http.Handler
+func (handler *StatusCallbackHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var callback StatusCallback
+	if status, err := readCallback(w, r, handler.options.MaxBodyBytes, &callback); err != nil {
+		respondCallbackError(w, r, status, err, handler.options.ErrorHook)
+		return
+	}
+
+	if ok := handler.cioLite.authenticateCallback(w, r, callback.Token, callback.Signature, callback.Timestamp, handler.options.MaxAge, handler.options.NonceStore, handler.options.ErrorHook); !ok {
+		return
+	}
+
+	if handler.options.OnStatus != nil {
+		if err := handler.options.OnStatus(r.Context(), callback); err != nil {
+			forgetCallback(handler.options.NonceStore, callback.Token, r, handler.options.ErrorHook)
+			respondCallbackError(w, r, http.StatusInternalServerError, err, handler.options.ErrorHook)
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
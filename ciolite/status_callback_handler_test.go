@@ -0,0 +1,87 @@
+package ciolite
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// signedStatusCallbackBody returns the json of a StatusCallback signed with the secret
+func signedStatusCallbackBody(callback StatusCallback, secret string) string {
+	callback.Signature = hashHmac(sha256.New, strconv.Itoa(callback.Timestamp)+callback.Token, secret)
+	body, err := json.Marshal(callback)
+	Must(err)
+	return string(body)
+}
+
+// TestStatusCallbackFailureReason tests the classification of StatusCallback Failure values
+func TestStatusCallbackFailureReason(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]FailureReason{
+		"":                      FailureNone,
+		"INVALID_CREDENTIALS":   FailureInvalidCredentials,
+		"invalid_credentials":   FailureInvalidCredentials,
+		"CONNECTION_IMPOSSIBLE": FailureConnectionImpossible,
+		"no_access_to_all_mail": FailureNoAccessToAllMail,
+		"TEMP_DISABLED":         FailureTempDisabled,
+		"DISABLED":              FailureDisabled,
+		"something new":         FailureUnknown,
+	}
+
+	for failure, expected := range tests {
+		if reason := (StatusCallback{Failure: failure}).FailureReason(); reason != expected {
+			t.Error("Expected reason for ", failure, ": ", expected, "; Got: ", reason)
+		}
+	}
+
+	if !FailureInvalidCredentials.NeedsReauthentication() || FailureConnectionImpossible.NeedsReauthentication() {
+		t.Error("Expected only invalid credentials to need re-authentication")
+	}
+}
+
+// TestStatusCallbackHandler tests that StatusCallbackHandler authenticates and dispatches callbacks
+func TestStatusCallbackHandler(t *testing.T) {
+	t.Parallel()
+
+	cioLite := NewCioLite("key", "secret")
+
+	var received []StatusCallback
+	handler := NewStatusCallbackHandler(cioLite, StatusCallbackHandlerOptions{
+		OnStatus: func(ctx context.Context, callback StatusCallback) error {
+			received = append(received, callback)
+			return nil
+		},
+	})
+
+	callback := StatusCallback{
+		AccountID:    "abc",
+		UserID:       "123abc",
+		EmailAccount: "test@gmail.com",
+		Failure:      "INVALID_CREDENTIALS",
+		Token:        "token1",
+		Timestamp:    int(time.Now().Unix()),
+	}
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest("POST", "/status", strings.NewReader(signedStatusCallbackBody(callback, "secret"))))
+	if recorder.Code != http.StatusOK {
+		t.Error("Expected status: ", http.StatusOK, "; Got: ", recorder.Code)
+	}
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest("POST", "/status", strings.NewReader(signedStatusCallbackBody(callback, "wrong"))))
+	if recorder.Code != http.StatusUnauthorized {
+		t.Error("Expected status: ", http.StatusUnauthorized, "; Got: ", recorder.Code)
+	}
+
+	if len(received) != 1 || received[0].FailureReason() != FailureInvalidCredentials || received[0].UserID != "123abc" {
+		t.Error("Expected one invalid credentials status callback; Got: ", received)
+	}
+}
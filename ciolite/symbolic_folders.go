@@ -0,0 +1,237 @@
+package ciolite
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Symbolic (IMAP special-use) folder names, as found in GetUsersEmailAccountFoldersResponse.SymbolicName
+// 	https://tools.ietf.org/html/rfc6154
+const (
+	SymbolicInbox   = `\Inbox`
+	SymbolicSent    = `\Sent`
+	SymbolicDrafts  = `\Drafts`
+	SymbolicTrash   = `\Trash`
+	SymbolicJunk    = `\Junk`
+	SymbolicAll     = `\All`
+	SymbolicArchive = `\Archive`
+	SymbolicFlagged = `\Flagged`
+)
+
+// DefaultFolderCacheTTL is the default time that a FolderCache keeps an account's folders
+const DefaultFolderCacheTTL = 10 * time.Minute
+
+// ErrFolderNotFound is the cause of the error returned by FindFolderBySymbolicName if the account has no such folder
+var ErrFolderNotFound = errors.New("CIO: Folder not found")
+
+// symbolicAliases maps the non-standard names used by some providers (ex: Gmail's XLIST) to the RFC 6154 ones
+var symbolicAliases = map[string]string{
+	"allmail": "all",
+	"spam":    "junk",
+	"starred": "flagged",
+}
+
+// FolderCache caches the folders of each account, so that repeated lookups
+// (ex: FindFolderBySymbolicName) do not each need to list the account's folders.
+// It is safe for concurrent use.
+type FolderCache struct {
+	ttl time.Duration
+
+	mu      sync.Mutex
+	entries map[string]folderCacheEntry
+}
+
+// folderCacheEntry is an account's cached folders
+type folderCacheEntry struct {
+	tree    *FolderTree
+	expires time.Time
+}
+
+// NewFolderCache returns a *FolderCache that keeps each account's folders for the ttl
+// (DefaultFolderCacheTTL if ttl is not positive), which can be set on CioLite.FolderCache
+func NewFolderCache(ttl time.Duration) *FolderCache {
+	if ttl <= 0 {
+		ttl = DefaultFolderCacheTTL
+	}
+	return &FolderCache{ttl: ttl, entries: make(map[string]folderCacheEntry)}
+}
+
+// Invalidate removes the account's cached folders, so that they are listed again on the next lookup
+func (cache *FolderCache) Invalidate(userID string, label string) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	delete(cache.entries, userID+"/"+label)
+}
+
+// get returns the account's cached folders, if they have not expired
+func (cache *FolderCache) get(userID string, label string, now time.Time) (*FolderTree, bool) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	entry, ok := cache.entries[userID+"/"+label]
+	if !ok || !now.Before(entry.expires) {
+		return nil, false
+	}
+	return entry.tree, true
+}
+
+// set caches the account's folders, and removes any expired accounts
+func (cache *FolderCache) set(userID string, label string, tree *FolderTree, now time.Time) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	for key, entry := range cache.entries {
+		if !now.Before(entry.expires) {
+			delete(cache.entries, key)
+		}
+	}
+	cache.entries[userID+"/"+label] = folderCacheEntry{tree: tree, expires: now.Add(cache.ttl)}
+}
+
+// invalidateFolderCache invalidates the account's cached folders, if there is a FolderCache
+func (cioLite CioLite) invalidateFolderCache(userID string, label string) {
+	if cioLite.FolderCache != nil {
+		cioLite.FolderCache.Invalidate(userID, label)
+	}
+}
+
+// FindFolderBySymbolicName returns the folder with the symbolic (IMAP special-use) name, ex: SymbolicSent,
+// so that the right folder can be found regardless of the provider or locale (ex: "[Gmail]/Sent Mail", "Gesendet").
+// Each of the Symbolic constants also has a named lookup (ex: FindSentFolder, FindTrashFolder).
+// The INBOX is found by name if it has no symbolic name. Uses the CioLite's FolderCache, if set.
+// Returns an error with the cause ErrFolderNotFound if the account has no such folder.
+func (cioLite CioLite) FindFolderBySymbolicName(userID string, label string, symbolicName string) (GetUsersEmailAccountFoldersResponse, error) {
+	return cioLite.FindFolderBySymbolicNameContext(context.Background(), userID, label, symbolicName)
+}
+
+// FindFolderBySymbolicNameContext is the same as FindFolderBySymbolicName, but uses the provided context.Context
+// for cancellation and deadlines of the request (including any retries).
+func (cioLite CioLite) FindFolderBySymbolicNameContext(ctx context.Context, userID string, label string, symbolicName string) (GetUsersEmailAccountFoldersResponse, error) {
+	var tree *FolderTree
+	if cioLite.FolderCache != nil {
+		tree, _ = cioLite.FolderCache.get(userID, label, time.Now())
+	}
+
+	if tree == nil {
+		var err error
+		if tree, err = cioLite.GetUserEmailAccountsFolderTreeContext(ctx, userID, label); err != nil {
+			return GetUsersEmailAccountFoldersResponse{}, err
+		}
+		if cioLite.FolderCache != nil {
+			cioLite.FolderCache.set(userID, label, tree, time.Now())
+		}
+	}
+
+	if node, ok := tree.LookupSymbolicName(symbolicName); ok {
+		return node.Folder, nil
+	}
+
+	// The INBOX always exists in IMAP, but not every provider marks it
+	if symbolicKey(symbolicName) == symbolicKey(SymbolicInbox) {
+		for _, node := range tree.Folders {
+			if node.Exists && strings.EqualFold(node.Path, "INBOX") {
+				return node.Folder, nil
+			}
+		}
+	}
+
+	return GetUsersEmailAccountFoldersResponse{}, errors.Wrap(ErrFolderNotFound, "CIO: No folder with symbolic name "+symbolicName)
+}
+
+// FindInboxFolder returns the account's INBOX (found by name if it has no symbolic name), using FindFolderBySymbolicName with SymbolicInbox.
+// Returns an error with the cause ErrFolderNotFound if the account has no such folder.
+func (cioLite CioLite) FindInboxFolder(userID string, label string) (GetUsersEmailAccountFoldersResponse, error) {
+	return cioLite.FindFolderBySymbolicNameContext(context.Background(), userID, label, SymbolicInbox)
+}
+
+// FindInboxFolderContext is the same as FindInboxFolder, but uses the provided context.Context
+// for cancellation and deadlines of the request (including any retries).
+func (cioLite CioLite) FindInboxFolderContext(ctx context.Context, userID string, label string) (GetUsersEmailAccountFoldersResponse, error) {
+	return cioLite.FindFolderBySymbolicNameContext(ctx, userID, label, SymbolicInbox)
+}
+
+// FindSentFolder returns the account's sent mail folder, using FindFolderBySymbolicName with SymbolicSent.
+// Returns an error with the cause ErrFolderNotFound if the account has no such folder.
+func (cioLite CioLite) FindSentFolder(userID string, label string) (GetUsersEmailAccountFoldersResponse, error) {
+	return cioLite.FindFolderBySymbolicNameContext(context.Background(), userID, label, SymbolicSent)
+}
+
+// FindSentFolderContext is the same as FindSentFolder, but uses the provided context.Context
+// for cancellation and deadlines of the request (including any retries).
+func (cioLite CioLite) FindSentFolderContext(ctx context.Context, userID string, label string) (GetUsersEmailAccountFoldersResponse, error) {
+	return cioLite.FindFolderBySymbolicNameContext(ctx, userID, label, SymbolicSent)
+}
+
+// FindDraftsFolder returns the account's drafts folder, using FindFolderBySymbolicName with SymbolicDrafts.
+// Returns an error with the cause ErrFolderNotFound if the account has no such folder.
+func (cioLite CioLite) FindDraftsFolder(userID string, label string) (GetUsersEmailAccountFoldersResponse, error) {
+	return cioLite.FindFolderBySymbolicNameContext(context.Background(), userID, label, SymbolicDrafts)
+}
+
+// FindDraftsFolderContext is the same as FindDraftsFolder, but uses the provided context.Context
+// for cancellation and deadlines of the request (including any retries).
+func (cioLite CioLite) FindDraftsFolderContext(ctx context.Context, userID string, label string) (GetUsersEmailAccountFoldersResponse, error) {
+	return cioLite.FindFolderBySymbolicNameContext(ctx, userID, label, SymbolicDrafts)
+}
+
+// FindTrashFolder returns the account's trash folder, using FindFolderBySymbolicName with SymbolicTrash.
+// Returns an error with the cause ErrFolderNotFound if the account has no such folder.
+func (cioLite CioLite) FindTrashFolder(userID string, label string) (GetUsersEmailAccountFoldersResponse, error) {
+	return cioLite.FindFolderBySymbolicNameContext(context.Background(), userID, label, SymbolicTrash)
+}
+
+// FindTrashFolderContext is the same as FindTrashFolder, but uses the provided context.Context
+// for cancellation and deadlines of the request (including any retries).
+func (cioLite CioLite) FindTrashFolderContext(ctx context.Context, userID string, label string) (GetUsersEmailAccountFoldersResponse, error) {
+	return cioLite.FindFolderBySymbolicNameContext(ctx, userID, label, SymbolicTrash)
+}
+
+// FindJunkFolder returns the account's junk (spam) folder, using FindFolderBySymbolicName with SymbolicJunk.
+// Returns an error with the cause ErrFolderNotFound if the account has no such folder.
+func (cioLite CioLite) FindJunkFolder(userID string, label string) (GetUsersEmailAccountFoldersResponse, error) {
+	return cioLite.FindFolderBySymbolicNameContext(context.Background(), userID, label, SymbolicJunk)
+}
+
+// FindJunkFolderContext is the same as FindJunkFolder, but uses the provided context.Context
+// for cancellation and deadlines of the request (including any retries).
+func (cioLite CioLite) FindJunkFolderContext(ctx context.Context, userID string, label string) (GetUsersEmailAccountFoldersResponse, error) {
+	return cioLite.FindFolderBySymbolicNameContext(ctx, userID, label, SymbolicJunk)
+}
+
+// FindAllMailFolder returns the account's folder containing all mail (ex: Gmail's All Mail), using FindFolderBySymbolicName with SymbolicAll.
+// Returns an error with the cause ErrFolderNotFound if the account has no such folder.
+func (cioLite CioLite) FindAllMailFolder(userID string, label string) (GetUsersEmailAccountFoldersResponse, error) {
+	return cioLite.FindFolderBySymbolicNameContext(context.Background(), userID, label, SymbolicAll)
+}
+
+// FindAllMailFolderContext is the same as FindAllMailFolder, but uses the provided context.Context
+// for cancellation and deadlines of the request (including any retries).
+func (cioLite CioLite) FindAllMailFolderContext(ctx context.Context, userID string, label string) (GetUsersEmailAccountFoldersResponse, error) {
+	return cioLite.FindFolderBySymbolicNameContext(ctx, userID, label, SymbolicAll)
+}
+
+// FindArchiveFolder returns the account's archive folder, using FindFolderBySymbolicName with SymbolicArchive.
+// Returns an error with the cause ErrFolderNotFound if the account has no such folder.
+func (cioLite CioLite) FindArchiveFolder(userID string, label string) (GetUsersEmailAccountFoldersResponse, error) {
+	return cioLite.FindFolderBySymbolicNameContext(context.Background(), userID, label, SymbolicArchive)
+}
+
+// FindArchiveFolderContext is the same as FindArchiveFolder, but uses the provided context.Context
+// for cancellation and deadlines of the request (including any retries).
+func (cioLite CioLite) FindArchiveFolderContext(ctx context.Context, userID string, label string) (GetUsersEmailAccountFoldersResponse, error) {
+	return cioLite.FindFolderBySymbolicNameContext(ctx, userID, label, SymbolicArchive)
+}
+
+// FindFlaggedFolder returns the account's folder of flagged (starred) messages, using FindFolderBySymbolicName with SymbolicFlagged.
+// Returns an error with the cause ErrFolderNotFound if the account has no such folder.
+func (cioLite CioLite) FindFlaggedFolder(userID string, label string) (GetUsersEmailAccountFoldersResponse, error) {
+	return cioLite.FindFolderBySymbolicNameContext(context.Background(), userID, label, SymbolicFlagged)
+}
+
+// FindFlaggedFolderContext is the same as FindFlaggedFolder, but uses the provided context.Context
+// for cancellation and deadlines of the request (including any retries).
+func (cioLite CioLite) FindFlaggedFolderContext(ctx context.Context, userID string, label string) (GetUsersEmailAccountFoldersResponse, error) {
+	return cioLite.FindFolderBySymbolicNameContext(ctx, userID, label, SymbolicFlagged)
+}
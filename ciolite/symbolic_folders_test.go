@@ -0,0 +1,74 @@
+package ciolite
+
+import (
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// TestSimulatedFindFolderBySymbolicName tests finding special-use folders, with caching
+func TestSimulatedFindFolderBySymbolicName(t *testing.T) {
+	t.Parallel()
+
+	cioLite, logger, testServer, mux := NewTestCioLiteWithLoggerAndTestServer(t)
+	defer testServer.Close()
+
+	cioLite.FolderCache = NewFolderCache(time.Minute)
+
+	listings := 0
+	mux.HandleFunc("/lite/users/123abc/email_accounts/0/folders", func(w http.ResponseWriter, r *http.Request) {
+		listings++
+		_, err := io.WriteString(w, `[
+			{"name":"Inbox","delimiter":"/"},
+			{"name":"[Gmail]/Gesendet","symbolic_name":"\\Sent","delimiter":"/"},
+			{"name":"[Gmail]/Alle Nachrichten","symbolic_name":"\\AllMail","delimiter":"/"},
+			{"name":"[Gmail]/Spam","symbolic_name":"\\Spam","delimiter":"/"}
+		]`)
+		Must(err)
+	})
+	mux.HandleFunc("/lite/users/123abc/email_accounts/0/folders/", func(w http.ResponseWriter, r *http.Request) {
+		_, err := io.WriteString(w, `{"success":true}`)
+		Must(err)
+	})
+
+	for symbolicName, expectedName := range map[string]string{
+		SymbolicSent:  "[Gmail]/Gesendet",
+		SymbolicAll:   "[Gmail]/Alle Nachrichten",
+		SymbolicJunk:  "[Gmail]/Spam",
+		SymbolicInbox: "Inbox",
+	} {
+		folder, err := cioLite.FindFolderBySymbolicName("123abc", "0", symbolicName)
+		if err != nil || folder.Name != expectedName {
+			t.Error("Expected: ", expectedName, "; Got: ", folder.Name, "; With Error: ", err, "; With Log: ", logger.String())
+		}
+	}
+
+	// Named lookups
+	if folder, err := cioLite.FindSentFolder("123abc", "0"); err != nil || folder.Name != "[Gmail]/Gesendet" {
+		t.Error("Expected: ", "[Gmail]/Gesendet", "; Got: ", folder.Name, "; With Error: ", err)
+	}
+	if folder, err := cioLite.FindInboxFolder("123abc", "0"); err != nil || folder.Name != "Inbox" {
+		t.Error("Expected: ", "Inbox", "; Got: ", folder.Name, "; With Error: ", err)
+	}
+
+	_, err := cioLite.FindDraftsFolder("123abc", "0")
+	if errors.Cause(err) != ErrFolderNotFound {
+		t.Error("Expected: ", ErrFolderNotFound, "; Got: ", err)
+	}
+
+	if listings != 1 {
+		t.Error("Expected folder listings: ", 1, "; Got: ", listings)
+	}
+
+	// Creating a folder should invalidate the cache
+	_, err = cioLite.CreateUserEmailAccountFolder("123abc", "0", "New", EmailAccountFolderDelimiterParam{})
+	Must(err)
+	_, err = cioLite.FindFolderBySymbolicName("123abc", "0", SymbolicSent)
+	Must(err)
+	if listings != 2 {
+		t.Error("Expected folder listings: ", 2, "; Got: ", listings)
+	}
+}
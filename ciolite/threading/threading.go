@@ -0,0 +1,420 @@
+// Package threading groups CIO Lite messages into conversation threads,
+// using the JWZ algorithm on their Message-ID, In-Reply-To and References headers.
+// 	https://www.jwz.org/doc/threading.html
+package threading
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/contextio/contextio-go/ciolite"
+)
+
+// Message is the threading information of a single message
+type Message struct {
+	// ID is the Message-ID header, ex: <abc@example.com>
+	ID string
+
+	InReplyTo  string
+	References []string
+	Subject    string
+	Date       time.Time
+
+	// Value is the original message (ex: a GetUsersEmailAccountFolderMessagesResponse), for the caller's use
+	Value interface{}
+}
+
+// FromFolderMessage returns the threading information of a message listed from a folder
+func FromFolderMessage(message ciolite.GetUsersEmailAccountFolderMessagesResponse) Message {
+	date := message.SentAtTime()
+	if date.IsZero() {
+		date = message.ReceivedAtTime()
+	}
+	return Message{
+		ID:         message.EmailMessageID,
+		InReplyTo:  message.InReplyTo,
+		References: message.References,
+		Subject:    message.Subject,
+		Date:       date,
+		Value:      message,
+	}
+}
+
+// FromWebhookMessage returns the threading information of a message received by a webhook.
+// The In-Reply-To header is only included if the webhook was created with IncludeHeader,
+// so without it the last of the References (which is normally the same message) is used instead.
+func FromWebhookMessage(message ciolite.WebhookMessageData) Message {
+	date := message.DateTime()
+	if date.IsZero() {
+		date = message.DateReceivedTime()
+	}
+	inReplyTo := message.Headers.Get("In-Reply-To")
+	if len(inReplyTo) == 0 {
+		inReplyTo = lastReference(message.References)
+	}
+	return Message{
+		ID:         message.EmailMessageID,
+		InReplyTo:  inReplyTo,
+		References: message.References,
+		Subject:    message.Subject,
+		Date:       date,
+		Value:      message,
+	}
+}
+
+// Options configures BuildThreads.
+type Options struct {
+	// DisableSubjectGrouping stops threads with the same (normalized) subject but no
+	// common references from being grouped together
+	DisableSubjectGrouping bool
+}
+
+// Container is a node of a thread tree
+type Container struct {
+	// ID is the Message-ID of the message (which may be missing), or for a container
+	// created to group threads by subject, the thread ID of the earliest thread
+	ID string
+
+	// Message is nil if the message is missing (only referenced by other messages),
+	// or if this container groups threads by subject
+	Message *Message
+
+	// Parent is nil for the root, and Children are sorted by date
+	Parent   *Container
+	Children []*Container
+
+	// earliest is the earliest date within this subtree, used for sorting
+	earliest time.Time
+}
+
+// Thread is a conversation
+type Thread struct {
+	// ID identifies the thread, and is the Message-ID of its root, which stays the same as
+	// replies arrive, and even if the root message itself has not been fetched
+	ID string
+
+	// Subject is the normalized subject (without Re:, Fwd:, etc)
+	Subject string
+
+	Root *Container
+}
+
+// Messages returns the messages of the thread, depth-first in date order
+func (thread *Thread) Messages() []Message {
+	var messages []Message
+	var walk func(*Container)
+	walk = func(container *Container) {
+		if container.Message != nil {
+			messages = append(messages, *container.Message)
+		}
+		for _, child := range container.Children {
+			walk(child)
+		}
+	}
+	walk(thread.Root)
+	return messages
+}
+
+// LastDate returns the date of the latest message in the thread
+func (thread *Thread) LastDate() time.Time {
+	var last time.Time
+	for _, message := range thread.Messages() {
+		if message.Date.After(last) {
+			last = message.Date
+		}
+	}
+	return last
+}
+
+// BuildThreads groups the messages into threads, sorted by the date of their first message.
+// Messages whose parents are missing are still grouped together if they share references.
+func BuildThreads(messages []Message, options Options) []*Thread {
+	containers := make(map[string]*Container, len(messages))
+	get := func(id string) *Container {
+		container, ok := containers[id]
+		if !ok {
+			container = &Container{ID: id}
+			containers[id] = container
+		}
+		return container
+	}
+
+	for i := range messages {
+		message := &messages[i]
+
+		id := normalizeID(message.ID)
+		if len(id) == 0 || (containers[id] != nil && containers[id].Message != nil) {
+			// Missing or duplicate Message-ID, so give it a unique one that nothing can reference
+			id = "#" + strconv.Itoa(i) + id
+		}
+		container := get(id)
+		container.Message = message
+
+		// Link the references together, oldest first, without overriding existing links
+		var parent *Container
+		for _, ref := range references(message) {
+			refContainer := get(ref)
+			if parent != nil && refContainer.Parent == nil && refContainer != parent && !refContainer.hasDescendant(parent) {
+				parent.addChild(refContainer)
+			}
+			parent = refContainer
+		}
+
+		// The last reference is this message's parent, replacing any link guessed from other messages
+		if parent != nil && (parent == container || container.hasDescendant(parent)) {
+			parent = nil
+		}
+		if container.Parent != nil {
+			container.Parent.removeChild(container)
+		}
+		if parent != nil {
+			parent.addChild(container)
+		}
+	}
+
+	// Find the roots, removing empty containers
+	var roots []*Container
+	for _, container := range containers {
+		if container.Parent == nil {
+			roots = append(roots, container)
+		}
+	}
+	roots = pruneEmpty(roots, true)
+	for _, root := range roots {
+		root.sortByDate()
+	}
+	sortContainers(roots)
+
+	if !options.DisableSubjectGrouping {
+		roots = groupBySubject(roots)
+		sortContainers(roots)
+	}
+
+	threads := make([]*Thread, len(roots))
+	for i, root := range roots {
+		threads[i] = &Thread{ID: threadID(root), Subject: NormalizeSubject(root.subject()), Root: root}
+	}
+	return threads
+}
+
+// threadID returns the ID of the thread rooted at the container, which is the first (oldest)
+// reference of its message, so that it does not change when a missing root is later found
+// (or when a missing root is pruned because it only had one reply)
+func threadID(root *Container) string {
+	if root.Message != nil {
+		if refs := references(root.Message); len(refs) > 0 {
+			return refs[0]
+		}
+	}
+	return root.ID
+}
+
+// references returns the normalized references of the message, with In-Reply-To last if it is not already
+func references(message *Message) []string {
+	refs := make([]string, 0, len(message.References)+1)
+	for _, ref := range message.References {
+		for _, field := range strings.Fields(ref) {
+			if id := normalizeID(field); len(id) > 0 {
+				refs = append(refs, id)
+			}
+		}
+	}
+	if inReplyTo := normalizeID(firstID(message.InReplyTo)); len(inReplyTo) > 0 && (len(refs) == 0 || refs[len(refs)-1] != inReplyTo) {
+		refs = append(refs, inReplyTo)
+	}
+	return refs
+}
+
+// lastReference returns the last Message-ID of the References, or "" if there are none
+func lastReference(refs []string) string {
+	var last string
+	for _, ref := range refs {
+		if fields := strings.Fields(ref); len(fields) > 0 {
+			last = fields[len(fields)-1]
+		}
+	}
+	return last
+}
+
+// idPattern matches a <message-id>
+var idPattern = regexp.MustCompile(`<[^<>\s]+>`)
+
+// firstID returns the first <message-id> in the header value (In-Reply-To sometimes contains other text)
+func firstID(value string) string {
+	if id := idPattern.FindString(value); len(id) > 0 {
+		return id
+	}
+	return value
+}
+
+// normalizeID trims the whitespace around a Message-ID, so that references match
+func normalizeID(id string) string {
+	return strings.TrimSpace(id)
+}
+
+// addChild makes the child a child of this container
+func (container *Container) addChild(child *Container) {
+	child.Parent = container
+	container.Children = append(container.Children, child)
+}
+
+// removeChild removes the child from this container's children
+func (container *Container) removeChild(child *Container) {
+	for i, c := range container.Children {
+		if c == child {
+			container.Children = append(container.Children[:i], container.Children[i+1:]...)
+			break
+		}
+	}
+	child.Parent = nil
+}
+
+// hasDescendant returns true if the other container is within this container's subtree
+func (container *Container) hasDescendant(other *Container) bool {
+	for parent := other.Parent; parent != nil; parent = parent.Parent {
+		if parent == container {
+			return true
+		}
+	}
+	return false
+}
+
+// pruneEmpty removes empty containers, promoting their children (except to the root level,
+// where an empty container is kept to group two or more children)
+func pruneEmpty(containers []*Container, isRoot bool) []*Container {
+	var kept []*Container
+	for _, container := range containers {
+		container.Children = pruneEmpty(container.Children, false)
+		for _, child := range container.Children {
+			child.Parent = container
+		}
+
+		switch {
+		case container.Message != nil:
+			kept = append(kept, container)
+		case len(container.Children) == 0:
+			// Nothing to keep
+		case !isRoot || len(container.Children) == 1:
+			// Promote the children to this level
+			for _, child := range container.Children {
+				child.Parent = container.Parent
+			}
+			kept = append(kept, container.Children...)
+		default:
+			kept = append(kept, container)
+		}
+	}
+	return kept
+}
+
+// sortByDate sorts the children of the container and its descendants by date, and sets their earliest dates
+func (container *Container) sortByDate() time.Time {
+	container.earliest = time.Time{}
+	if container.Message != nil {
+		container.earliest = container.Message.Date
+	}
+	for _, child := range container.Children {
+		if childEarliest := child.sortByDate(); container.earliest.IsZero() || (!childEarliest.IsZero() && childEarliest.Before(container.earliest)) {
+			container.earliest = childEarliest
+		}
+	}
+	sortContainers(container.Children)
+	return container.earliest
+}
+
+// sortContainers sorts the containers by their earliest date, then ID, so that the order is stable
+func sortContainers(containers []*Container) {
+	sort.SliceStable(containers, func(i, j int) bool {
+		if !containers[i].earliest.Equal(containers[j].earliest) {
+			return containers[i].earliest.Before(containers[j].earliest)
+		}
+		return containers[i].ID < containers[j].ID
+	})
+}
+
+// subject returns the subject of the container's message, or of its first child
+func (container *Container) subject() string {
+	if container.Message != nil {
+		return container.Message.Subject
+	}
+	for _, child := range container.Children {
+		if subject := child.subject(); len(subject) > 0 {
+			return subject
+		}
+	}
+	return ""
+}
+
+// replyPrefix matches the reply prefix of a subject, after any mailing list tags
+var replyPrefix = regexp.MustCompile(`(?i)^\s*(\[[^\]]*\]\s*)*(re|aw|sv|antw)(\[\d+\])?\s*:`)
+
+// isReply returns true if the container's message subject starts with Re: (or similar)
+func (container *Container) isReply() bool {
+	return container.Message != nil && replyPrefix.MatchString(container.Message.Subject)
+}
+
+// groupBySubject merges the roots that have the same normalized subject (which must be sorted by date,
+// so that the earliest root of each subject is kept as the thread's root)
+func groupBySubject(roots []*Container) []*Container {
+	bySubject := make(map[string]*Container, len(roots))
+	var kept []*Container
+
+	for _, root := range roots {
+		subject := NormalizeSubject(root.subject())
+		existing, ok := bySubject[subject]
+		if len(subject) == 0 || !ok {
+			if len(subject) > 0 {
+				bySubject[subject] = root
+			}
+			kept = append(kept, root)
+			continue
+		}
+
+		switch {
+		case existing.Message == nil && root.Message == nil:
+			// Both are empty, so merge the children
+			for _, child := range root.Children {
+				existing.addChild(child)
+			}
+		case existing.Message == nil:
+			existing.addChild(root)
+		case root.Message == nil || (!existing.isReply() && root.isReply()):
+			// Either root is empty (but later), or it is a reply to the existing root
+			existing.addChild(root)
+		default:
+			// Siblings, so group them in a new empty container, which keeps the existing root's ID
+			group := &Container{ID: threadID(existing)}
+			for i, k := range kept {
+				if k == existing {
+					kept[i] = group
+				}
+			}
+			group.addChild(existing)
+			group.addChild(root)
+			bySubject[subject] = group
+			existing = group
+		}
+		existing.sortByDate()
+	}
+
+	return kept
+}
+
+// subjectPrefix matches the reply and forward prefixes, and mailing list tags, of a subject
+var subjectPrefix = regexp.MustCompile(`(?i)^\s*((re|fwd?|aw|sv|antw|wg|tr)(\[\d+\])?\s*:|\[[^\]]*\])\s*`)
+
+// NormalizeSubject returns the subject without its reply and forward prefixes
+// (ex: Re:, Fwd:, AW:) or mailing list tags, lowercased, for comparing subjects
+func NormalizeSubject(subject string) string {
+	for {
+		stripped := subjectPrefix.ReplaceAllString(subject, "")
+		if stripped == subject {
+			break
+		}
+		subject = stripped
+	}
+	return strings.ToLower(strings.Join(strings.Fields(subject), " "))
+}
@@ -0,0 +1,150 @@
+package threading
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/contextio/contextio-go/ciolite"
+)
+
+// threadShape returns the thread as "id(child,child(...))", for comparing trees
+func threadShape(container *Container) string {
+	shape := container.ID
+	if container.Message == nil {
+		shape = "[" + shape + "]"
+	}
+	if len(container.Children) > 0 {
+		var children []string
+		for _, child := range container.Children {
+			children = append(children, threadShape(child))
+		}
+		shape += "(" + strings.Join(children, ",") + ")"
+	}
+	return shape
+}
+
+// TestBuildThreads tests threading by references, with missing parents and subject grouping
+func TestBuildThreads(t *testing.T) {
+	t.Parallel()
+
+	day := func(d int) time.Time {
+		return time.Date(2017, 1, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	messages := []Message{
+		{ID: "<a>", Subject: "Lunch?", Date: day(1)},
+		{ID: "<c>", Subject: "Re: Lunch?", References: []string{"<a> <b>"}, Date: day(3)},
+		{ID: "<b>", Subject: "Re: Lunch?", InReplyTo: "<a>", Date: day(2)},
+		// Parent <x> was never fetched, but both replies share it
+		{ID: "<y>", Subject: "Re: Report", References: []string{"<x>"}, Date: day(5)},
+		{ID: "<z>", Subject: "Re: Report", InReplyTo: "Your message of Monday <x>", Date: day(6)},
+		// Parent <p> was never fetched, and has a single reply
+		{ID: "<q>", Subject: "Re: Plans", References: []string{"<p>"}, Date: day(7)},
+		// No references, but the same subject as <a>
+		{ID: "<d>", Subject: "RE: [team] Lunch?", Date: day(4)},
+		// A separate thread
+		{ID: "<e>", Subject: "Hello", Date: day(8)},
+	}
+
+	threads := BuildThreads(messages, Options{})
+
+	var shapes, ids, subjects []string
+	for _, thread := range threads {
+		shapes = append(shapes, threadShape(thread.Root))
+		ids = append(ids, thread.ID)
+		subjects = append(subjects, thread.Subject)
+	}
+
+	expectedShapes := []string{"<a>(<b>(<c>),<d>)", "[<x>](<y>,<z>)", "<q>", "<e>"}
+	if !reflect.DeepEqual(shapes, expectedShapes) {
+		t.Error("Expected: ", expectedShapes, "; Got: ", shapes)
+	}
+
+	expectedIDs := []string{"<a>", "<x>", "<p>", "<e>"}
+	if !reflect.DeepEqual(ids, expectedIDs) {
+		t.Error("Expected: ", expectedIDs, "; Got: ", ids)
+	}
+
+	expectedSubjects := []string{"lunch?", "report", "plans", "hello"}
+	if !reflect.DeepEqual(subjects, expectedSubjects) {
+		t.Error("Expected: ", expectedSubjects, "; Got: ", subjects)
+	}
+
+	if got := len(threads[0].Messages()); got != 4 || !threads[0].LastDate().Equal(day(4)) {
+		t.Error("Expected: ", 4, " messages, last on ", day(4), "; Got: ", got, threads[0].LastDate())
+	}
+
+	// Without subject grouping, <d> is its own thread
+	threads = BuildThreads(messages, Options{DisableSubjectGrouping: true})
+	if len(threads) != 5 || threadShape(threads[0].Root) != "<a>(<b>(<c>))" {
+		t.Error("Expected 5 threads; Got: ", len(threads), threadShape(threads[0].Root))
+	}
+}
+
+// TestBuildThreadsSiblingSubjects tests that unrelated messages with the same subject are grouped as siblings
+func TestBuildThreadsSiblingSubjects(t *testing.T) {
+	t.Parallel()
+
+	threads := BuildThreads([]Message{
+		{ID: "<1>", Subject: "Weekly status", Date: time.Unix(100, 0)},
+		{ID: "<2>", Subject: "Weekly status", Date: time.Unix(200, 0)},
+		{Subject: "No ID"},
+		{ID: "<1>", Subject: "Duplicate ID", Date: time.Unix(300, 0)},
+	}, Options{})
+
+	if len(threads) != 3 || threadShape(threads[1].Root) != "[<1>](<1>,<2>)" || threads[1].ID != "<1>" {
+		var shapes []string
+		for _, thread := range threads {
+			shapes = append(shapes, threadShape(thread.Root))
+		}
+		t.Error("Expected 3 threads, with <1> and <2> grouped; Got: ", shapes)
+	}
+}
+
+// TestFromFolderMessage tests converting CIO messages
+func TestFromFolderMessage(t *testing.T) {
+	t.Parallel()
+
+	message := FromFolderMessage(ciolite.GetUsersEmailAccountFolderMessagesResponse{MessageID: "cio1", EmailMessageID: "<a>", InReplyTo: "<b>", ReceivedAt: 100})
+	if message.ID != "<a>" || message.InReplyTo != "<b>" || message.Date.Unix() != 100 {
+		t.Error("Unexpected message: ", message)
+	}
+
+	message = FromWebhookMessage(ciolite.WebhookMessageData{MessageID: "cio2", EmailMessageID: "<c>", Headers: map[string][]string{"In-Reply-To": {"<d>"}}})
+	if message.ID != "<c>" || message.InReplyTo != "<d>" || !message.Date.IsZero() {
+		t.Error("Unexpected message: ", message)
+	}
+
+	// Without headers, In-Reply-To falls back to the last of the References
+	message = FromWebhookMessage(ciolite.WebhookMessageData{MessageID: "cio3", EmailMessageID: "<e>", References: []string{"<a> <c>"}})
+	if message.ID != "<e>" || message.InReplyTo != "<c>" {
+		t.Error("Unexpected message: ", message)
+	}
+
+	// Messages from either source thread together by their Message-ID headers
+	threads := BuildThreads([]Message{
+		FromFolderMessage(ciolite.GetUsersEmailAccountFolderMessagesResponse{MessageID: "cio1", EmailMessageID: "<a>", Subject: "Lunch"}),
+		FromWebhookMessage(ciolite.WebhookMessageData{MessageID: "cio2", EmailMessageID: "<b>", Subject: "Other", References: []string{"<a>"}}),
+	}, Options{DisableSubjectGrouping: true})
+	if len(threads) != 1 || len(threads[0].Messages()) != 2 {
+		t.Error("Expected 1 thread of 2 messages; Got: ", threads)
+	}
+}
+
+// TestNormalizeSubject tests removing reply and forward prefixes and list tags
+func TestNormalizeSubject(t *testing.T) {
+	t.Parallel()
+
+	for subject, expected := range map[string]string{
+		"Re: Fwd: RE[2]: Hello  World": "hello world",
+		"[list] AW: Hello":             "hello",
+		"Hello":                        "hello",
+		"Re:":                          "",
+	} {
+		if got := NormalizeSubject(subject); got != expected {
+			t.Error("Expected: ", expected, "; Got: ", got, "; For: ", subject)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package ciolite
+
+import (
+	"bytes"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// UnixTime is a Unix timestamp (in seconds), as used by CIO, where 0 means not set.
+// It marshals to and from the same json number as a plain int, so it can be used in place of one.
+type UnixTime int
+
+// NewUnixTime returns the UnixTime of t, or 0 for the zero time
+func NewUnixTime(t time.Time) UnixTime {
+	if t.IsZero() {
+		return 0
+	}
+	return UnixTime(t.Unix())
+}
+
+// Time returns the time.Time of the timestamp, or the zero time.Time if it is not set
+func (unixTime UnixTime) Time() time.Time {
+	if unixTime == 0 {
+		return time.Time{}
+	}
+	return time.Unix(int64(unixTime), 0)
+}
+
+// IsZero returns true if the timestamp is not set
+func (unixTime UnixTime) IsZero() bool {
+	return unixTime == 0
+}
+
+// String returns the time in RFC 3339 format, or an empty string if it is not set
+func (unixTime UnixTime) String() string {
+	if unixTime == 0 {
+		return ""
+	}
+	return unixTime.Time().UTC().Format(time.RFC3339)
+}
+
+// MarshalJSON allows UnixTime to implement json.Marshaler
+func (unixTime UnixTime) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Itoa(int(unixTime))), nil
+}
+
+// UnmarshalJSON allows UnixTime to implement json.Unmarshaler.
+// As well as an integer, it accepts a float, a numeric string, and null or false (as not set).
+func (unixTime *UnixTime) UnmarshalJSON(data []byte) error {
+	data = bytes.Trim(bytes.TrimSpace(data), `"`)
+	switch string(data) {
+	case "", "null", "false":
+		*unixTime = 0
+		return nil
+	}
+	timestamp, err := strconv.ParseFloat(string(data), 64)
+	if err != nil {
+		return errors.Wrap(err, "CIO: Invalid unix timestamp")
+	}
+	*unixTime = UnixTime(timestamp)
+	return nil
+}
+
+// SentAtTime returns SentAt as a time.Time (the zero time if not set)
+func (response GetUsersEmailAccountFolderMessagesResponse) SentAtTime() time.Time {
+	return UnixTime(response.SentAt).Time()
+}
+
+// ReceivedAtTime returns ReceivedAt as a time.Time (the zero time if not set)
+func (response GetUsersEmailAccountFolderMessagesResponse) ReceivedAtTime() time.Time {
+	return UnixTime(response.ReceivedAt).Time()
+}
+
+// CreatedTime returns Created as a time.Time (the zero time if not set)
+func (response GetUsersResponse) CreatedTime() time.Time {
+	return UnixTime(response.Created).Time()
+}
+
+// SuspendedTime returns Suspended as a time.Time (the zero time if the user is not suspended)
+func (response GetUsersResponse) SuspendedTime() time.Time {
+	return UnixTime(response.Suspended).Time()
+}
+
+// PasswordExpiredTime returns PasswordExpired as a time.Time (the zero time if the password has not expired)
+func (response GetUsersResponse) PasswordExpiredTime() time.Time {
+	return UnixTime(response.PasswordExpired).Time()
+}
+
+// CreatedTime returns Created as a time.Time (the zero time if not set)
+func (response GetConnectTokenResponse) CreatedTime() time.Time {
+	return UnixTime(response.Created).Time()
+}
+
+// UsedTime returns Used as a time.Time (the zero time if the token has not been used)
+func (response GetConnectTokenResponse) UsedTime() time.Time {
+	return UnixTime(response.Used).Time()
+}
+
+// CreatedTime returns Created as a time.Time (the zero time if not set)
+func (response GetConnectTokenUserResponse) CreatedTime() time.Time {
+	return UnixTime(response.Created).Time()
+}
+
+// Time returns when the token expires as a time.Time, or the zero time if the token has been used
+func (expires ExpiresMixed) Time() time.Time {
+	if expires.Expires == nil {
+		return time.Time{}
+	}
+	return UnixTime(*expires.Expires).Time()
+}
+
+// DateTime returns Date as a time.Time (the zero time if not set)
+func (data WebhookMessageData) DateTime() time.Time {
+	return UnixTime(data.Date).Time()
+}
+
+// DateReceivedTime returns DateReceived as a time.Time (the zero time if not set)
+func (data WebhookMessageData) DateReceivedTime() time.Time {
+	return UnixTime(data.DateReceived).Time()
+}
+
+// TimestampTime returns Timestamp as a time.Time (the zero time if not set)
+func (callback WebhookCallback) TimestampTime() time.Time {
+	return UnixTime(callback.Timestamp).Time()
+}
+
+// TimestampTime returns Timestamp as a time.Time (the zero time if not set)
+func (callback StatusCallback) TimestampTime() time.Time {
+	return UnixTime(callback.Timestamp).Time()
+}
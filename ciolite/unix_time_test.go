@@ -0,0 +1,81 @@
+package ciolite
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+// TestUnixTime tests the conversions and json encoding of UnixTime
+func TestUnixTime(t *testing.T) {
+	t.Parallel()
+
+	var value struct {
+		Created  UnixTime `json:"created"`
+		Used     UnixTime `json:"used"`
+		Float    UnixTime `json:"float"`
+		String   UnixTime `json:"string"`
+		Null     UnixTime `json:"null"`
+		False    UnixTime `json:"false"`
+		Missing  UnixTime `json:"missing,omitempty"`
+		Negative UnixTime `json:"negative"`
+	}
+	Must(json.Unmarshal([]byte(`{"created":1483369445,"used":0,"float":1483369445.5,"string":"1483369445","null":null,"false":false,"negative":-1}`), &value))
+
+	expected := time.Date(2017, 1, 2, 15, 4, 5, 0, time.UTC)
+	for _, unixTime := range []UnixTime{value.Created, value.Float, value.String} {
+		if !unixTime.Time().Equal(expected) || unixTime.String() != "2017-01-02T15:04:05Z" {
+			t.Error("Expected: ", expected, "; Got: ", unixTime.Time(), unixTime.String())
+		}
+	}
+	for _, unixTime := range []UnixTime{value.Used, value.Null, value.False, value.Missing} {
+		if !unixTime.IsZero() || !unixTime.Time().IsZero() || unixTime.String() != "" {
+			t.Error("Expected zero; Got: ", int(unixTime), unixTime.Time())
+		}
+	}
+	if value.Negative.Time().Unix() != -1 {
+		t.Error("Expected: ", -1, "; Got: ", value.Negative.Time().Unix())
+	}
+
+	// Marshals as the same json as a plain int
+	encoded, err := json.Marshal(value)
+	Must(err)
+	expectedJSON := `{"created":1483369445,"used":0,"float":1483369445,"string":1483369445,"null":0,"false":0,"negative":-1}`
+	if string(encoded) != expectedJSON {
+		t.Error("Expected: ", expectedJSON, "; Got: ", string(encoded))
+	}
+
+	if NewUnixTime(expected) != value.Created || NewUnixTime(time.Time{}) != 0 {
+		t.Error("Expected: ", value.Created, "; Got: ", NewUnixTime(expected), NewUnixTime(time.Time{}))
+	}
+
+	var invalid UnixTime
+	if err = json.Unmarshal([]byte(`"soon"`), &invalid); err == nil {
+		t.Error("Expected an error")
+	}
+}
+
+// TestTimeAccessors tests the time.Time accessors of the response structs
+func TestTimeAccessors(t *testing.T) {
+	t.Parallel()
+
+	user := GetUsersResponse{Created: 1483369445}
+	if user.CreatedTime().Unix() != 1483369445 || !user.SuspendedTime().IsZero() || !user.PasswordExpiredTime().IsZero() {
+		t.Error("Unexpected user times: ", user.CreatedTime(), user.SuspendedTime(), user.PasswordExpiredTime())
+	}
+
+	expires := 1483369445
+	token := GetConnectTokenResponse{Created: 100, Expires: ExpiresMixed{Expires: &expires}}
+	if token.CreatedTime().Unix() != 100 || !token.UsedTime().IsZero() || token.Expires.Time().Unix() != int64(expires) || !(ExpiresMixed{}).Time().IsZero() {
+		t.Error("Unexpected token times: ", token.CreatedTime(), token.UsedTime(), token.Expires.Time())
+	}
+
+	message := GetUsersEmailAccountFolderMessagesResponse{ReceivedAt: 200}
+	if !message.SentAtTime().IsZero() || message.ReceivedAtTime().Unix() != 200 {
+		t.Error("Unexpected message times: ", message.SentAtTime(), message.ReceivedAtTime())
+	}
+
+	if (WebhookCallback{Timestamp: 300}).TimestampTime().Unix() != 300 || !(StatusCallback{}).TimestampTime().IsZero() {
+		t.Error("Unexpected callback times")
+	}
+}
@@ -0,0 +1,158 @@
+package ciolite
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultCallbackMaxBodyBytes is the default maximum size of a callback's body
+const DefaultCallbackMaxBodyBytes = 10 << 20
+
+// ErrCallbackMethod is returned to the ErrorHook when a callback is not a POST
+var ErrCallbackMethod = errors.New("CIO: Callback must be a POST")
+
+// IsFailure returns true if this is a failure notification (sent to the FailureNotifURL),
+// in which case Data contains the error message, instead of a message event.
+func (callback WebhookCallback) IsFailure() bool {
+	return len(callback.Data) > 0
+}
+
+// WebhookHandlerOptions configures a WebhookHandler.
+type WebhookHandlerOptions struct {
+	// OnMessage is called with each authenticated message event callback
+	OnMessage func(context.Context, WebhookCallback) error
+
+	// OnFailure is called with each authenticated failure notification callback,
+	// where WebhookCallback.Data contains the error message
+	OnFailure func(context.Context, WebhookCallback) error
+
+	// ErrorHook is a function (purely for logging) that is called with any error
+	// that causes the handler to respond with a non-200 status code,
+	// and with ErrCallbackReplayed when a replayed callback is ignored
+	ErrorHook func(*http.Request, error)
+
+	// MaxAge rejects callbacks whose Timestamp is older (or further in the future) than this,
+	// to prevent replays. Defaults to DefaultCallbackMaxAge, and a negative value disables the check.
+	MaxAge time.Duration
+
+	// NonceStore, if set, is used to ignore callbacks whose Token has already been received
+	// (see NewMemoryNonceStore). Replays are acknowledged with a 200 but not dispatched.
+	NonceStore NonceStore
+
+	// MaxBodyBytes limits the size of the callback body. Defaults to DefaultCallbackMaxBodyBytes.
+	MaxBodyBytes int64
+}
+
+// WebhookHandler is an http.Handler that receives Webhook callbacks from CIO,
+// authenticates them, and dispatches them to the registered callbacks.
+// 	https://context.io/docs/lite/users/webhooks#callbacks
+type WebhookHandler struct {
+	cioLite CioLite
+	options WebhookHandlerOptions
+}
+
+// NewWebhookHandler returns a *WebhookHandler that validates callbacks using the CioLite's secret.
+// It responds with:
+// 	200 if the callback was handled (or there is no function registered for its type),
+// 	400 if the body could not be parsed,
+// 	401 if the signature or timestamp is invalid,
+// 	405 if the request is not a POST,
+// 	500 if the registered function returned an error, so that CIO will retry the callback later.
+func NewWebhookHandler(cioLite CioLite, options WebhookHandlerOptions) *WebhookHandler {
+	return &WebhookHandler{cioLite: cioLite, options: options}
+}
+
+// ServeHTTP implements http.Handler
+func (handler *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var callback WebhookCallback
+	if status, err := readCallback(w, r, handler.options.MaxBodyBytes, &callback); err != nil {
+		respondCallbackError(w, r, status, err, handler.options.ErrorHook)
+		return
+	}
+
+	if ok := handler.cioLite.authenticateCallback(w, r, callback.Token, callback.Signature, callback.Timestamp, handler.options.MaxAge, handler.options.NonceStore, handler.options.ErrorHook); !ok {
+		return
+	}
+
+	dispatch := handler.options.OnMessage
+	if callback.IsFailure() {
+		dispatch = handler.options.OnFailure
+	}
+	if dispatch != nil {
+		if err := dispatch(r.Context(), callback); err != nil {
+			forgetCallback(handler.options.NonceStore, callback.Token, r, handler.options.ErrorHook)
+			respondCallbackError(w, r, http.StatusInternalServerError, err, handler.options.ErrorHook)
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// readCallback checks the request method, then reads and unmarshals the json body into the callback.
+// Returns the http status code to respond with, and any error.
+func readCallback(w http.ResponseWriter, r *http.Request, maxBodyBytes int64, callback interface{}) (int, error) {
+	if r.Method != "POST" {
+		return http.StatusMethodNotAllowed, ErrCallbackMethod
+	}
+
+	if maxBodyBytes <= 0 {
+		maxBodyBytes = DefaultCallbackMaxBodyBytes
+	}
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+	if err != nil {
+		return http.StatusBadRequest, errors.Wrap(err, "CIO: Could not read callback body")
+	}
+
+	if err = json.Unmarshal(body, callback); err != nil {
+		return http.StatusBadRequest, errors.Wrap(err, "CIO: Could not unmarshal callback body")
+	}
+	return http.StatusOK, nil
+}
+
+// authenticateCallback validates the callback, and if it is not valid (or is a replay), responds and returns false
+func (cioLite CioLite) authenticateCallback(w http.ResponseWriter, r *http.Request, token string, signature string, timestamp int, maxAge time.Duration, store NonceStore, errorHook func(*http.Request, error)) bool {
+	err := cioLite.validateCallback(token, signature, timestamp, maxAge, store, time.Now())
+	switch errors.Cause(err) {
+	case nil:
+		return true
+	case ErrCallbackReplayed:
+		// Acknowledge, so that CIO does not keep retrying something we have already handled
+		if errorHook != nil {
+			errorHook(r, err)
+		}
+		w.WriteHeader(http.StatusOK)
+	case ErrCallbackSignature, ErrCallbackTimestamp:
+		respondCallbackError(w, r, http.StatusUnauthorized, err, errorHook)
+	default:
+		// NonceStore failure
+		respondCallbackError(w, r, http.StatusInternalServerError, err, errorHook)
+	}
+	return false
+}
+
+// forgetCallback removes the token from the store (if any), so that CIO can retry the callback
+func forgetCallback(store NonceStore, token string, r *http.Request, errorHook func(*http.Request, error)) {
+	if store == nil {
+		return
+	}
+	if err := store.Forget(token); err != nil && errorHook != nil {
+		errorHook(r, errors.Wrap(err, "CIO: Could not forget callback token"))
+	}
+}
+
+// respondCallbackError calls the error hook (if any), and responds with the status code
+func respondCallbackError(w http.ResponseWriter, r *http.Request, status int, err error, errorHook func(*http.Request, error)) {
+	if errorHook != nil {
+		errorHook(r, err)
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(status)
+	_, _ = io.WriteString(w, http.StatusText(status))
+}
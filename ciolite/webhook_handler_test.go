@@ -0,0 +1,89 @@
+package ciolite
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// signedWebhookBody returns the json of a WebhookCallback signed with the secret
+func signedWebhookBody(callback WebhookCallback, secret string) string {
+	callback.Signature = hashHmac(sha256.New, strconv.Itoa(callback.Timestamp)+callback.Token, secret)
+	body, err := json.Marshal(callback)
+	Must(err)
+	return string(body)
+}
+
+// TestWebhookHandler tests that WebhookHandler authenticates and dispatches callbacks
+func TestWebhookHandler(t *testing.T) {
+	t.Parallel()
+
+	cioLite := NewCioLite("key", "secret")
+
+	var messages, failures []WebhookCallback
+	var loggedErrors []error
+	handler := NewWebhookHandler(cioLite, WebhookHandlerOptions{
+		OnMessage: func(ctx context.Context, callback WebhookCallback) error {
+			if callback.MessageData.Subject == "fail me" {
+				return errors.New("handler failed")
+			}
+			messages = append(messages, callback)
+			return nil
+		},
+		OnFailure: func(ctx context.Context, callback WebhookCallback) error {
+			failures = append(failures, callback)
+			return nil
+		},
+		ErrorHook: func(r *http.Request, err error) {
+			loggedErrors = append(loggedErrors, err)
+		},
+	})
+
+	now := int(time.Now().Unix())
+	message := WebhookCallback{AccountID: "abc", WebhookID: "hook1", Token: "token1", Timestamp: now, MessageData: WebhookMessageData{Subject: "hello"}}
+	failure := WebhookCallback{AccountID: "abc", WebhookID: "hook1", Token: "token2", Timestamp: now, Data: "IMAP connection failed"}
+	handlerError := WebhookCallback{Token: "token3", Timestamp: now, MessageData: WebhookMessageData{Subject: "fail me"}}
+	stale := WebhookCallback{Token: "token4", Timestamp: now - 3600}
+
+	tests := []struct {
+		method string
+		body   string
+		status int
+	}{
+		{"POST", signedWebhookBody(message, "secret"), http.StatusOK},
+		{"POST", signedWebhookBody(failure, "secret"), http.StatusOK},
+		{"POST", signedWebhookBody(message, "wrong"), http.StatusUnauthorized},
+		{"POST", signedWebhookBody(stale, "secret"), http.StatusUnauthorized},
+		{"POST", signedWebhookBody(handlerError, "secret"), http.StatusInternalServerError},
+		{"POST", `{not json`, http.StatusBadRequest},
+		{"GET", "", http.StatusMethodNotAllowed},
+	}
+
+	for _, test := range tests {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(test.method, "/webhook", strings.NewReader(test.body)))
+		if recorder.Code != test.status {
+			t.Error("Expected status: ", test.status, "; Got: ", recorder.Code, "; For Body: ", test.body)
+		}
+	}
+
+	if len(messages) != 1 || messages[0].MessageData.Subject != "hello" {
+		t.Error("Expected one message callback; Got: ", messages)
+	}
+
+	if len(failures) != 1 || !failures[0].IsFailure() || failures[0].Data != "IMAP connection failed" {
+		t.Error("Expected one failure callback; Got: ", failures)
+	}
+
+	if len(loggedErrors) != 5 || loggedErrors[0] != ErrCallbackSignature || loggedErrors[1] != ErrCallbackTimestamp || loggedErrors[4] != ErrCallbackMethod {
+		t.Error("Expected 5 logged errors; Got: ", loggedErrors)
+	}
+}